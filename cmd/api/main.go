@@ -15,12 +15,19 @@ import (
 	mongo "github.com/vogiaan1904/ticketbottle-order/internal/infra/mongo"
 	"github.com/vogiaan1904/ticketbottle-order/internal/infra/temporal"
 	"github.com/vogiaan1904/ticketbottle-order/internal/interceptors"
+	itvGrpc "github.com/vogiaan1904/ticketbottle-order/internal/intervention/delivery/grpc"
+	itvRepo "github.com/vogiaan1904/ticketbottle-order/internal/intervention/repository"
+	itvSvc "github.com/vogiaan1904/ticketbottle-order/internal/intervention/service"
+	"github.com/vogiaan1904/ticketbottle-order/internal/migrations"
 	oGrpc "github.com/vogiaan1904/ticketbottle-order/internal/order/delivery/grpc"
 	oKafka "github.com/vogiaan1904/ticketbottle-order/internal/order/delivery/kafka/producer"
 	oRepo "github.com/vogiaan1904/ticketbottle-order/internal/order/repository"
 	oSvc "github.com/vogiaan1904/ticketbottle-order/internal/order/service"
+	outboxRepo "github.com/vogiaan1904/ticketbottle-order/internal/outbox/repository"
+	peRepo "github.com/vogiaan1904/ticketbottle-order/internal/processedevent/repository"
 	"github.com/vogiaan1904/ticketbottle-order/internal/workflows"
 	eSvc "github.com/vogiaan1904/ticketbottle-order/pkg/grpc/event"
+	itvpb "github.com/vogiaan1904/ticketbottle-order/pkg/grpc/intervention"
 	iSvc "github.com/vogiaan1904/ticketbottle-order/pkg/grpc/inventory"
 	opb "github.com/vogiaan1904/ticketbottle-order/pkg/grpc/order"
 	pSvc "github.com/vogiaan1904/ticketbottle-order/pkg/grpc/payment"
@@ -55,6 +62,14 @@ func main() {
 
 	db := mCli.Database(cfg.Mongo.Database)
 
+	// Bring the indexes and documents up to the schema of this build
+	if cfg.Mongo.MigrateOnStart {
+		if err := migrations.Up(ctx, l, db); err != nil {
+			l.Fatalf(ctx, "Failed to migrate MongoDB: %v", err)
+			os.Exit(1)
+		}
+	}
+
 	// Initialize gRpc service clients
 	iSvc, iClose, err := iSvc.NewInventoryClient(cfg.Microservice.Inventory)
 	if err != nil {
@@ -84,11 +99,17 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Initialize producers
-	oProd := oKafka.NewProducer(kProd, l)
-
 	// Initialize repositories
 	oRepo := oRepo.New(l, db)
+	itvRepo := itvRepo.New(l, db)
+	outboxRepo := outboxRepo.New(l, db)
+	peRepo := peRepo.New(l, db)
+
+	// Initialize producers
+	oProd := oKafka.NewProducer(kProd, l)
+	if oKafka.Mode(cfg.Kafka.ProducerMode) == oKafka.ModeOutbox {
+		oProd = oKafka.NewOutboxProducer(outboxRepo, l)
+	}
 
 	// Initialize JWT manager
 	jwtMgr := pkgJwt.NewManager(cfg.JWT.Secret, l)
@@ -102,9 +123,10 @@ func main() {
 	defer tCli.Close()
 
 	// Initialize activities
-	oActs := acts.NewOrderActivities(oRepo)
-	pActs := acts.NewPaymentActivities(pSvc)
-	iActs := acts.NewInventoryActivities(iSvc)
+	oActs := acts.NewOrderActivities(oRepo, oProd)
+	pActs := acts.NewPaymentActivities(pSvc, cfg.Microservice.RefundsEnabled)
+	iActs := acts.NewInventoryActivities(iSvc, cfg.Microservice.RefundsEnabled)
+	epActs := acts.NewEventPublishingActivities(oProd, oRepo)
 
 	w := temporal.NewOrderWorker(tCli, temporal.CreateOrderTaskQueue)
 
@@ -112,8 +134,25 @@ func main() {
 	w.RegisterActivity(oActs)
 	w.RegisterActivity(pActs)
 	w.RegisterActivity(iActs)
+	w.RegisterActivity(epActs)
+
+	rfW := temporal.NewOrderWorker(tCli, temporal.RefundOrderTaskQueue)
+
+	rfW.RegisterWorkflow(workflows.RefundOrder)
+	rfW.RegisterActivity(oActs)
+	rfW.RegisterActivity(pActs)
+	rfW.RegisterActivity(iActs)
+	rfW.RegisterActivity(epActs)
 
-	// Start worker
+	cnW := temporal.NewOrderWorker(tCli, temporal.CancelOrderTaskQueue)
+
+	cnW.RegisterWorkflow(workflows.CancelOrder)
+	cnW.RegisterActivity(oActs)
+	cnW.RegisterActivity(pActs)
+	cnW.RegisterActivity(iActs)
+	cnW.RegisterActivity(epActs)
+
+	// Start workers
 	go func() {
 		l.Infof(ctx, "Starting Temporal worker on task queue: %s", temporal.CreateOrderTaskQueue)
 		if err := w.Run(nil); err != nil {
@@ -121,11 +160,27 @@ func main() {
 		}
 	}()
 
+	go func() {
+		l.Infof(ctx, "Starting Temporal worker on task queue: %s", temporal.RefundOrderTaskQueue)
+		if err := rfW.Run(nil); err != nil {
+			l.Fatalf(ctx, "Temporal worker failed: %v", err)
+		}
+	}()
+
+	go func() {
+		l.Infof(ctx, "Starting Temporal worker on task queue: %s", temporal.CancelOrderTaskQueue)
+		if err := cnW.Run(nil); err != nil {
+			l.Fatalf(ctx, "Temporal worker failed: %v", err)
+		}
+	}()
+
 	// Initialize services
-	oSvc := oSvc.New(l, oRepo, jwtMgr, iSvc, eSvc, pSvc, oProd, tCli)
+	oSvc := oSvc.New(l, oRepo, peRepo, jwtMgr, iSvc, eSvc, pSvc, oProd, tCli, cfg.Microservice.RefundsEnabled)
+	itvSvc := itvSvc.New(l, itvRepo, tCli)
 
 	// Initialize gRpc services
 	oGrpc := oGrpc.NewGrpcService(oSvc, l)
+	itvGrpc := itvGrpc.NewGrpcService(itvSvc, l)
 
 	// Start gRpc server
 	lnr, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.Server.GRpcPort))
@@ -137,6 +192,7 @@ func main() {
 		grpc.UnaryInterceptor(interceptors.GrpcLoggingInterceptor(l)),
 	)
 	opb.RegisterOrderServiceServer(gRpcSrv, oGrpc)
+	itvpb.RegisterInterventionServiceServer(gRpcSrv, itvGrpc)
 
 	go func() {
 		l.Infof(ctx, "gRPC server is listening on port: %d", cfg.Server.GRpcPort)
@@ -152,6 +208,8 @@ func main() {
 	l.Info(ctx, "Server shutting down...")
 
 	w.Stop()
+	rfW.Stop()
+	cnW.Stop()
 
 	cancel()
 	time.Sleep(1 * time.Second)
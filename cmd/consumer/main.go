@@ -2,7 +2,9 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
@@ -12,16 +14,22 @@ import (
 	"github.com/vogiaan1904/ticketbottle-order/internal/infra/kafka"
 	"github.com/vogiaan1904/ticketbottle-order/internal/infra/mongo"
 	"github.com/vogiaan1904/ticketbottle-order/internal/infra/temporal"
+	itvRepo "github.com/vogiaan1904/ticketbottle-order/internal/intervention/repository"
+	"github.com/vogiaan1904/ticketbottle-order/internal/migrations"
 	oCons "github.com/vogiaan1904/ticketbottle-order/internal/order/delivery/kafka/consumer"
-	oProd "github.com/vogiaan1904/ticketbottle-order/internal/order/delivery/kafka/producer"
+	oKafka "github.com/vogiaan1904/ticketbottle-order/internal/order/delivery/kafka/producer"
 	oRepo "github.com/vogiaan1904/ticketbottle-order/internal/order/repository"
 	oSvc "github.com/vogiaan1904/ticketbottle-order/internal/order/service"
+	"github.com/vogiaan1904/ticketbottle-order/internal/outbox/relay"
+	outboxRepo "github.com/vogiaan1904/ticketbottle-order/internal/outbox/repository"
+	peRepo "github.com/vogiaan1904/ticketbottle-order/internal/processedevent/repository"
 	"github.com/vogiaan1904/ticketbottle-order/internal/workflows"
 	eSvc "github.com/vogiaan1904/ticketbottle-order/pkg/grpc/event"
 	iSvc "github.com/vogiaan1904/ticketbottle-order/pkg/grpc/inventory"
 	pSvc "github.com/vogiaan1904/ticketbottle-order/pkg/grpc/payment"
 	pkgJwt "github.com/vogiaan1904/ticketbottle-order/pkg/jwt"
 	pkgLog "github.com/vogiaan1904/ticketbottle-order/pkg/logger"
+	"github.com/vogiaan1904/ticketbottle-order/pkg/metrics"
 	pkgTemporal "github.com/vogiaan1904/ticketbottle-order/pkg/temporal"
 )
 
@@ -84,12 +92,27 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Initialize producers
-	oProd := oProd.NewProducer(kProd, l)
+	db := mCli.Database(cfg.Mongo.Database)
+
+	// Bring the indexes and documents up to the schema of this build
+	if cfg.Mongo.MigrateOnStart {
+		if err := migrations.Up(ctx, l, db); err != nil {
+			l.Fatalf(ctx, "Failed to migrate MongoDB: %v", err)
+			os.Exit(1)
+		}
+	}
 
 	// Initialize repositories
-	db := mCli.Database(cfg.Mongo.Database)
 	oRepo := oRepo.New(l, db)
+	itvRepo := itvRepo.New(l, db)
+	outboxRepo := outboxRepo.New(l, db)
+	peRepo := peRepo.New(l, db)
+
+	// Initialize producers
+	oProd := oKafka.NewProducer(kProd, l)
+	if oKafka.Mode(cfg.Kafka.ProducerMode) == oKafka.ModeOutbox {
+		oProd = oKafka.NewOutboxProducer(outboxRepo, l)
+	}
 
 	// Initialize JWT manager
 	jwtMgr := pkgJwt.NewManager(cfg.JWT.Secret, l)
@@ -103,10 +126,11 @@ func main() {
 	defer tCli.Close()
 
 	// Initialize activities
-	oActs := acts.NewOrderActivities(oRepo)
-	pActs := acts.NewPaymentActivities(pSvc)
-	iActs := acts.NewInventoryActivities(iSvc)
-	epActs := acts.NewEventPublishingActivities(oProd)
+	oActs := acts.NewOrderActivities(oRepo, oProd)
+	pActs := acts.NewPaymentActivities(pSvc, cfg.Microservice.RefundsEnabled)
+	iActs := acts.NewInventoryActivities(iSvc, cfg.Microservice.RefundsEnabled)
+	epActs := acts.NewEventPublishingActivities(oProd, oRepo)
+	itvActs := acts.NewInterventionActivities(itvRepo)
 
 	w := temporal.NewOrderWorker(tCli, temporal.ConfirmOrderTaskQueue)
 
@@ -115,8 +139,22 @@ func main() {
 	w.RegisterActivity(pActs)
 	w.RegisterActivity(iActs)
 	w.RegisterActivity(epActs)
+	w.RegisterActivity(itvActs)
+
+	flW := temporal.NewOrderWorker(tCli, temporal.FailOrderTaskQueue)
+
+	flW.RegisterWorkflow(workflows.FailOrder)
+	flW.RegisterActivity(oActs)
+	flW.RegisterActivity(pActs)
+	flW.RegisterActivity(iActs)
+	flW.RegisterActivity(epActs)
+
+	evW := temporal.NewOrderWorker(tCli, temporal.CancelEventOrdersTaskQueue)
 
-	// Start worker
+	evW.RegisterWorkflow(workflows.CancelEventOrders)
+	evW.RegisterActivity(oActs)
+
+	// Start workers
 	go func() {
 		l.Infof(ctx, "Starting Temporal worker on task queue: %s", temporal.ConfirmOrderTaskQueue)
 		if err := w.Run(nil); err != nil {
@@ -124,11 +162,28 @@ func main() {
 		}
 	}()
 
+	go func() {
+		l.Infof(ctx, "Starting Temporal worker on task queue: %s", temporal.FailOrderTaskQueue)
+		if err := flW.Run(nil); err != nil {
+			l.Fatalf(ctx, "Temporal worker failed: %v", err)
+		}
+	}()
+
+	go func() {
+		l.Infof(ctx, "Starting Temporal worker on task queue: %s", temporal.CancelEventOrdersTaskQueue)
+		if err := evW.Run(nil); err != nil {
+			l.Fatalf(ctx, "Temporal worker failed: %v", err)
+		}
+	}()
+
 	// Initialize services
-	oSvc := oSvc.New(l, oRepo, jwtMgr, iSvc, eSvc, pSvc, oProd, tCli)
+	oSvc := oSvc.New(l, oRepo, peRepo, jwtMgr, iSvc, eSvc, pSvc, oProd, tCli, cfg.Microservice.RefundsEnabled)
 
 	// Create consumer
-	cons := oCons.NewConsumer(kConsGr, oSvc, l)
+	cons := oCons.NewConsumer(kConsGr, kProd, oSvc, l, oCons.Config{
+		Workers:     cfg.Kafka.ConsumerWorkers,
+		MaxInFlight: cfg.Kafka.ConsumerMaxInFlight,
+	})
 
 	// Start message processors
 	if err := cons.Start(ctx); err != nil {
@@ -136,6 +191,37 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Start outbox relay, only the replica holding the lease publishes
+	relayDone := make(chan struct{})
+	if oProd.Mode() == oKafka.ModeOutbox {
+		rl := relay.New(outboxRepo, kProd, l, relay.Config{
+			PollInterval: cfg.Kafka.OutboxPollInterval,
+			BatchSize:    cfg.Kafka.OutboxBatchSize,
+			MaxAttempts:  cfg.Kafka.OutboxMaxAttempts,
+		})
+		go func() {
+			defer close(relayDone)
+			l.Info(ctx, "Starting outbox relay")
+			rl.Run(ctx)
+		}()
+	} else {
+		close(relayDone)
+	}
+
+	// Serve metrics
+	var mSrv *http.Server
+	if cfg.Server.ConsumerMetricsAddr != "" {
+		mux := http.NewServeMux()
+		mux.Handle("/debug/vars", metrics.Handler())
+		mSrv = &http.Server{Addr: cfg.Server.ConsumerMetricsAddr, Handler: mux}
+		go func() {
+			l.Infof(ctx, "Serving metrics on %s", cfg.Server.ConsumerMetricsAddr)
+			if err := mSrv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				l.Errorf(ctx, "Failed to serve metrics: %v", err)
+			}
+		}()
+	}
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
@@ -143,8 +229,11 @@ func main() {
 	l.Info(ctx, "Consumer Server shutting down...")
 
 	w.Stop()
+	flW.Stop()
+	evW.Stop()
 
 	cancel()
+	<-relayDone
 
 	if err := cons.Close(); err != nil {
 		l.Errorf(ctx, "Error closing consumer: %v", err)
@@ -154,5 +243,11 @@ func main() {
 		l.Errorf(ctx, "Error closing Kafka producer: %v", err)
 	}
 
+	if mSrv != nil {
+		if err := mSrv.Close(); err != nil {
+			l.Errorf(ctx, "Error closing metrics server: %v", err)
+		}
+	}
+
 	l.Info(ctx, "Consumer server exited")
 }
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/vogiaan1904/ticketbottle-order/config"
+	"github.com/vogiaan1904/ticketbottle-order/internal/infra/kafka"
+	oCons "github.com/vogiaan1904/ticketbottle-order/internal/order/delivery/kafka/consumer"
+	pkgLog "github.com/vogiaan1904/ticketbottle-order/pkg/logger"
+)
+
+// Moves dead-lettered payment events back onto their source topic once the cause is fixed.
+// Progress is tracked by a dedicated consumer group, so a message is replayed only once.
+func main() {
+	topic := flag.String("topic", "", "dead-letter topic to replay, one of: "+strings.Join(oCons.DLQTopics(), ", "))
+	group := flag.String("group", "", "consumer group tracking the replay (default <KAFKA_CONSUMER_GROUP_ID>-dlq-replay)")
+	flag.Parse()
+
+	if *topic == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load config: %v\n", err)
+		os.Exit(1)
+	}
+
+	l := pkgLog.InitializeZapLogger(pkgLog.ZapConfig{
+		Level:    cfg.Log.Level,
+		Mode:     cfg.Log.Mode,
+		Encoding: cfg.Log.Encoding,
+	})
+
+	if *group == "" {
+		*group = cfg.Kafka.ConsumerGroupID + "-dlq-replay"
+	}
+
+	kProd, err := kafka.NewProducer(cfg.Kafka)
+	if err != nil {
+		l.Fatalf(ctx, "Failed to create Kafka producer: %v", err)
+		os.Exit(1)
+	}
+	defer kProd.Close()
+
+	kConsGr, err := kafka.NewReplayConsumerGroup(cfg.Kafka, *group)
+	if err != nil {
+		l.Fatalf(ctx, "Failed to create Kafka consumer group: %v", err)
+		os.Exit(1)
+	}
+	defer kConsGr.Close()
+
+	n, err := oCons.NewDLQReplayer(kConsGr, kProd, l).Replay(ctx, *topic)
+	if err != nil {
+		l.Errorf(ctx, "Replay of %s stopped after %d message(s): %v", *topic, n, err)
+		os.Exit(1)
+	}
+
+	l.Infof(ctx, "Replayed %d message(s) from %s", n, *topic)
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/vogiaan1904/ticketbottle-order/config"
+	"github.com/vogiaan1904/ticketbottle-order/internal/infra/mongo"
+	"github.com/vogiaan1904/ticketbottle-order/internal/migrations"
+	pkgLog "github.com/vogiaan1904/ticketbottle-order/pkg/logger"
+	"github.com/vogiaan1904/ticketbottle-order/pkg/mongo/migrate"
+)
+
+// Applies, reverts or lists the MongoDB schema migrations.
+//
+//	migrate [-to N] up    apply pending migrations, up to version N
+//	migrate [-to N] down  revert the latest migration, or every one above version N
+//	migrate status        list the migrations and when they were applied
+func main() {
+	to := flag.Int("to", -1, "target version, up defaults to the latest and down to one step back")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [-to N] up|down|status\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load config: %v\n", err)
+		os.Exit(1)
+	}
+
+	l := pkgLog.InitializeZapLogger(pkgLog.ZapConfig{
+		Level:    cfg.Log.Level,
+		Mode:     cfg.Log.Mode,
+		Encoding: cfg.Log.Encoding,
+	})
+
+	mCli, err := mongo.Connect(cfg.Mongo)
+	if err != nil {
+		l.Fatalf(ctx, "Failed to connect to MongoDB: %v", err)
+		os.Exit(1)
+	}
+	defer mongo.Disconnect(mCli)
+
+	m, err := migrations.NewMigrator(l, mCli.Database(cfg.Mongo.Database))
+	if err != nil {
+		l.Fatalf(ctx, "Failed to load migrations: %v", err)
+		os.Exit(1)
+	}
+
+	switch cmd := flag.Arg(0); cmd {
+	case "up":
+		n, err := m.Up(ctx, max(*to, 0))
+		if err != nil {
+			l.Errorf(ctx, "Migration stopped after %d applied: %v", n, err)
+			os.Exit(1)
+		}
+		l.Infof(ctx, "Applied %d migration(s)", n)
+
+	case "down":
+		if *to < 0 {
+			if *to, err = stepBack(ctx, m); err != nil {
+				l.Errorf(ctx, "Failed to read migration status: %v", err)
+				os.Exit(1)
+			}
+		}
+		n, err := m.Down(ctx, *to)
+		if err != nil {
+			l.Errorf(ctx, "Migration stopped after %d reverted: %v", n, err)
+			os.Exit(1)
+		}
+		l.Infof(ctx, "Reverted %d migration(s)", n)
+
+	case "status":
+		sts, err := m.Status(ctx)
+		if err != nil {
+			l.Errorf(ctx, "Failed to read migration status: %v", err)
+			os.Exit(1)
+		}
+		for _, st := range sts {
+			applied := "pending"
+			if st.AppliedAt != nil {
+				applied = st.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Printf("%4d  %-25s  %s\n", st.Version, applied, st.Description)
+		}
+
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown command %q\n", cmd)
+		flag.Usage()
+		os.Exit(2)
+	}
+}
+
+// stepBack is the version right below the latest applied migration, so down reverts only that one
+func stepBack(ctx context.Context, m *migrate.Migrator) (int, error) {
+	sts, err := m.Status(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := len(sts) - 1; i >= 0; i-- {
+		if sts[i].AppliedAt == nil {
+			continue
+		}
+		if i == 0 {
+			return 0, nil
+		}
+		return sts[i-1].Version, nil
+	}
+
+	return 0, nil
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/vogiaan1904/ticketbottle-order/internal/workflows"
+	"go.temporal.io/sdk/worker"
+)
+
+// Replays exported workflow histories against the current workflow code, so changes that break
+// running executions are caught before they are deployed.
+//
+// Export a history with: temporal workflow show --workflow-id <id> --output json > histories/<id>.json
+func main() {
+	path := flag.String("histories", "histories", "history JSON file, or directory of history JSON files")
+	flag.Parse()
+
+	files, err := historyFiles(*path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to read histories: %v\n", err)
+		os.Exit(1)
+	}
+	if len(files) == 0 {
+		fmt.Fprintf(os.Stderr, "No histories found in %s\n", *path)
+		os.Exit(1)
+	}
+
+	r := worker.NewWorkflowReplayer()
+	r.RegisterWorkflow(workflows.CreateOrder)
+	r.RegisterWorkflow(workflows.ConfirmOrder)
+	r.RegisterWorkflow(workflows.FailOrder)
+	r.RegisterWorkflow(workflows.RefundOrder)
+	r.RegisterWorkflow(workflows.CancelOrder)
+	r.RegisterWorkflow(workflows.CancelEventOrders)
+
+	failed := 0
+	for _, f := range files {
+		if err := r.ReplayWorkflowHistoryFromJSONFile(nil, f); err != nil {
+			fmt.Fprintf(os.Stderr, "FAIL %s: %v\n", f, err)
+			failed++
+			continue
+		}
+		fmt.Printf("ok   %s\n", f)
+	}
+
+	if failed > 0 {
+		fmt.Fprintf(os.Stderr, "%d of %d histories failed to replay\n", failed, len(files))
+		os.Exit(1)
+	}
+}
+
+func historyFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		files = append(files, filepath.Join(path, e.Name()))
+	}
+
+	return files, nil
+}
@@ -3,20 +3,44 @@ package activities
 import (
 	"context"
 
+	"github.com/vogiaan1904/ticketbottle-order/internal/models"
 	"github.com/vogiaan1904/ticketbottle-order/internal/order/delivery/kafka"
 	"github.com/vogiaan1904/ticketbottle-order/internal/order/delivery/kafka/producer"
+	repo "github.com/vogiaan1904/ticketbottle-order/internal/order/repository"
 )
 
 type EventPublishingActivities struct {
 	Prod producer.Producer
+	Repo repo.Repository
 }
 
-func NewEventPublishingActivities(prod producer.Producer) *EventPublishingActivities {
+func NewEventPublishingActivities(prod producer.Producer, repo repo.Repository) *EventPublishingActivities {
 	return &EventPublishingActivities{
 		Prod: prod,
+		Repo: repo,
 	}
 }
 
+// PublishOrderCreated publishes order.created with the order and its items as they are stored
+func (a *EventPublishingActivities) PublishOrderCreated(ctx context.Context, orderID string) error {
+	o, err := a.Repo.GetByID(ctx, orderID)
+	if err != nil {
+		return err
+	}
+
+	itms, err := a.Repo.ListItemByOrderID(ctx, orderID)
+	if err != nil {
+		return err
+	}
+
+	return a.Prod.PublishOrderEvent(ctx, kafka.OrderEvent{
+		Order: kafka.NewOrderPayload(o, itms),
+		Transition: kafka.OrderTransition{
+			To: string(models.OrderStatusPending),
+		},
+	})
+}
+
 type PublishCheckoutCompletedInput struct {
 	SessionID string
 	UserID    string
@@ -36,3 +60,84 @@ func (a *EventPublishingActivities) PublishCheckoutCompleted(ctx context.Context
 
 	return a.Prod.PublishCheckoutCompleted(ctx, event)
 }
+
+type PublishCheckoutFailedInput struct {
+	SessionID string
+	UserID    string
+	EventID   string
+}
+
+func (a *EventPublishingActivities) PublishCheckoutFailed(ctx context.Context, in PublishCheckoutFailedInput) error {
+	if in.SessionID == "" {
+		return nil
+	}
+
+	event := kafka.CheckoutFailedEvent{
+		SessionID: in.SessionID,
+		UserID:    in.UserID,
+		EventID:   in.EventID,
+	}
+
+	return a.Prod.PublishCheckoutFailed(ctx, event)
+}
+
+type PublishOrderRefundedInput struct {
+	// PreviousStatus is the status of the order before the refund, the order and its transition are
+	// only attached when it is set
+	PreviousStatus string
+	OrderID        string
+	OrderCode      string
+	RefundID       string
+	UserID         string
+	EventID        string
+	Status         string
+	AmountCents    int64
+	Currency       string
+	Reason         string
+	Items          []RefundItemInput
+}
+
+func (a *EventPublishingActivities) PublishOrderRefunded(ctx context.Context, in PublishOrderRefundedInput) error {
+	itms := make([]kafka.OrderRefundedItem, len(in.Items))
+	for i, itm := range in.Items {
+		itms[i] = kafka.OrderRefundedItem{
+			TicketClassID: itm.TicketClassID,
+			Quantity:      itm.Quantity,
+		}
+	}
+
+	event := kafka.OrderRefundedEvent{
+		OrderID:     in.OrderID,
+		OrderCode:   in.OrderCode,
+		RefundID:    in.RefundID,
+		UserID:      in.UserID,
+		EventID:     in.EventID,
+		Status:      in.Status,
+		AmountCents: in.AmountCents,
+		Currency:    in.Currency,
+		Reason:      in.Reason,
+		Items:       itms,
+	}
+
+	if in.PreviousStatus != "" {
+		o, err := a.Repo.GetByID(ctx, in.OrderID)
+		if err != nil {
+			return err
+		}
+
+		oItms, err := a.Repo.ListItemByOrderID(ctx, in.OrderID)
+		if err != nil {
+			return err
+		}
+
+		p := kafka.NewOrderPayload(o, oItms)
+		event.Order = &p
+		event.Transition = &kafka.OrderTransition{
+			From:   in.PreviousStatus,
+			To:     in.Status,
+			Reason: in.Reason,
+		}
+	}
+
+	return a.Prod.PublishOrderRefunded(ctx, event)
+}
@@ -0,0 +1,60 @@
+package activities
+
+import (
+	"context"
+
+	"github.com/vogiaan1904/ticketbottle-order/internal/intervention"
+	repo "github.com/vogiaan1904/ticketbottle-order/internal/intervention/repository"
+	"github.com/vogiaan1904/ticketbottle-order/internal/models"
+)
+
+type InterventionActivities struct {
+	Repo repo.Repository
+}
+
+func NewInterventionActivities(repo repo.Repository) *InterventionActivities {
+	return &InterventionActivities{
+		Repo: repo,
+	}
+}
+
+type CreateInterventionInput struct {
+	OrderCode  string
+	Step       models.InterventionStep
+	Error      string
+	WorkflowID string
+	RunID      string
+}
+
+// CreateIntervention opens a task for an operator. A workflow run has at most one
+// unresolved task per step, so a retried activity returns the task it already created.
+func (a *InterventionActivities) CreateIntervention(ctx context.Context, in CreateInterventionInput) (*models.Intervention, error) {
+	itvs, err := a.Repo.List(ctx, repo.ListInterventionOption{
+		FilterIntervention: intervention.FilterIntervention{
+			RunID: in.RunID,
+			Step:  in.Step,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, itv := range itvs {
+		if itv.Status != models.InterventionStatusResolved {
+			return &itv, nil
+		}
+	}
+
+	itv, err := a.Repo.Create(ctx, repo.CreateInterventionOption{
+		OrderCode:  in.OrderCode,
+		Step:       in.Step,
+		Error:      in.Error,
+		WorkflowID: in.WorkflowID,
+		RunID:      in.RunID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &itv, nil
+}
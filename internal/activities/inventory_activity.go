@@ -4,15 +4,18 @@ import (
 	"context"
 
 	"github.com/vogiaan1904/ticketbottle-order/pkg/grpc/inventory"
+	"go.temporal.io/sdk/temporal"
 )
 
 type InventoryActivities struct {
-	Client inventory.InventoryServiceClient
+	Client         inventory.InventoryServiceClient
+	RefundsEnabled bool
 }
 
-func NewInventoryActivities(client inventory.InventoryServiceClient) *InventoryActivities {
+func NewInventoryActivities(client inventory.InventoryServiceClient, refundsEnabled bool) *InventoryActivities {
 	return &InventoryActivities{
-		Client: client,
+		Client:         client,
+		RefundsEnabled: refundsEnabled,
 	}
 }
 
@@ -51,6 +54,25 @@ func (a *InventoryActivities) ConfirmInventory(ctx context.Context, orderCode st
 	return nil
 }
 
+// ReturnInventory gives confirmed (sold) tickets of an order back to the available pool.
+// It fails with the non-retryable REFUNDS_DISABLED error while refunds are turned off.
+func (a *InventoryActivities) ReturnInventory(ctx context.Context, orderCode string, items []*inventory.ReturnItem, idempotencyKey string) error {
+	if !a.RefundsEnabled {
+		return temporal.NewNonRetryableApplicationError("refunds are disabled", ErrTypeRefundsDisabled, nil, orderCode)
+	}
+
+	_, err := a.Client.Return(ctx, &inventory.ReturnRequest{
+		OrderCode:      orderCode,
+		Items:          items,
+		IdempotencyKey: idempotencyKey,
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (a *InventoryActivities) CheckAvailability(ctx context.Context, items []*inventory.CheckAvailabilityItem) (bool, error) {
 	resp, err := a.Client.CheckAvailability(ctx, &inventory.CheckAvailabilityRequest{
 		Items: items,
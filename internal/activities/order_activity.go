@@ -2,23 +2,43 @@ package activities
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/vogiaan1904/ticketbottle-order/internal/models"
 	"github.com/vogiaan1904/ticketbottle-order/internal/order"
+	"github.com/vogiaan1904/ticketbottle-order/internal/order/delivery/kafka"
+	"github.com/vogiaan1904/ticketbottle-order/internal/order/delivery/kafka/producer"
 	repo "github.com/vogiaan1904/ticketbottle-order/internal/order/repository"
+	"github.com/vogiaan1904/ticketbottle-order/pkg/mongo"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/temporal"
+)
+
+const (
+	// ErrTypeOrderConflict is the application error type of an order update that lost a race, the
+	// activity is retried and reads the order again
+	ErrTypeOrderConflict = "ORDER_CONFLICT"
+	// ErrTypeInvalidOrderTransition is the application error type of an update to a status the order
+	// cannot move to
+	ErrTypeInvalidOrderTransition = "INVALID_ORDER_TRANSITION"
 )
 
 type OrderActivities struct {
 	Repo repo.Repository
+	Prod producer.Producer
 }
 
-func NewOrderActivities(repo repo.Repository) *OrderActivities {
+func NewOrderActivities(repo repo.Repository, prod producer.Producer) *OrderActivities {
 	return &OrderActivities{
 		Repo: repo,
+		Prod: prod,
 	}
 }
 
 func (a *OrderActivities) CreateOrder(ctx context.Context, opt repo.CreateOrderOption) (*models.Order, error) {
+	opt.WorkflowID = workflowID(ctx)
+
 	o, err := a.Repo.Create(ctx, opt)
 	if err != nil {
 		return nil, err
@@ -36,6 +56,79 @@ func (a *OrderActivities) CreateOrderItems(ctx context.Context, orderID string,
 	return itms, nil
 }
 
+type CreateOrderWithItemsInput struct {
+	Order repo.CreateOrderOption
+	Items []repo.CreateOrderItemOption
+}
+
+type CreateOrderWithItemsResult struct {
+	Order *models.Order
+	Items []models.OrderItem
+}
+
+// CreateOrderWithItems persists the order together with its items, so no order is left without them.
+// In outbox mode both are written in one transaction, which also holds the outbox rows written with
+// its ctx. Otherwise a retry after a partial write finds the order by its code and writes the items again.
+func (a *OrderActivities) CreateOrderWithItems(ctx context.Context, in CreateOrderWithItemsInput) (*CreateOrderWithItemsResult, error) {
+	in.Order.WorkflowID = workflowID(ctx)
+
+	if a.Prod.Mode() != producer.ModeOutbox {
+		return a.createOrderWithItems(ctx, in)
+	}
+
+	var res *CreateOrderWithItemsResult
+	err := a.Repo.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		res, err = a.createOrderWithItems(ctx, in)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (a *OrderActivities) createOrderWithItems(ctx context.Context, in CreateOrderWithItemsInput) (*CreateOrderWithItemsResult, error) {
+	var o models.Order
+	var err error
+
+	// Only a retry can find the order, a transaction that failed left nothing behind
+	if activity.GetInfo(ctx).Attempt > 1 {
+		o, err = a.Repo.GetOne(ctx, repo.GetOneOrderOption{
+			FilterOrder: order.FilterOrder{Code: in.Order.Code},
+		})
+		if err != nil && err != mongo.ErrNoDocuments {
+			return nil, err
+		}
+	}
+
+	if o.ID.IsZero() {
+		if o, err = a.Repo.Create(ctx, in.Order); err != nil {
+			return nil, err
+		}
+	}
+
+	itms, err := a.Repo.ListItemByOrderID(ctx, o.ID.Hex())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(itms) != len(in.Items) {
+		// Items of an interrupted insert are written again as a whole
+		if len(itms) > 0 {
+			if err := a.Repo.DeleteItemByOrderID(ctx, o.ID.Hex()); err != nil {
+				return nil, err
+			}
+		}
+		if itms, err = a.Repo.CreateManyItems(ctx, o.ID.Hex(), in.Items); err != nil {
+			return nil, err
+		}
+	}
+
+	return &CreateOrderWithItemsResult{Order: &o, Items: itms}, nil
+}
+
 func (a *OrderActivities) GetOrder(ctx context.Context, code string) (*models.Order, error) {
 	o, err := a.Repo.GetOne(ctx, repo.GetOneOrderOption{
 		FilterOrder: order.FilterOrder{
@@ -52,6 +145,176 @@ func (a *OrderActivities) GetOrder(ctx context.Context, code string) (*models.Or
 func (a *OrderActivities) UpdateOrderStatus(ctx context.Context, ID string, status models.OrderStatus) error {
 	_, err := a.Repo.Update(ctx, ID, repo.UpdateOrderOption{
 		Status: status,
+		Change: repo.StatusChangeOption{WorkflowID: workflowID(ctx)},
+	})
+	if err != nil {
+		return orderUpdateError(err)
+	}
+
+	return nil
+}
+
+type FailPendingOrderInput struct {
+	OrderID string
+	Status  models.OrderStatus
+	Reason  string
+	Actor   models.OrderActor
+}
+
+// FailPendingOrder moves a PENDING order to a failure status and records why. It reports false when
+// the order already left PENDING, so a late payment failure never overwrites a completed order.
+func (a *OrderActivities) FailPendingOrder(ctx context.Context, in FailPendingOrderInput) (bool, error) {
+	o, err := a.Repo.GetByID(ctx, in.OrderID)
+	if err != nil {
+		return false, err
+	}
+	if o.Status != models.OrderStatusPending {
+		return false, nil
+	}
+
+	_, err = a.Repo.Update(ctx, in.OrderID, repo.UpdateOrderOption{
+		Model:         o,
+		Status:        in.Status,
+		FailureReason: in.Reason,
+		Change: repo.StatusChangeOption{
+			Actor:      in.Actor,
+			WorkflowID: workflowID(ctx),
+			Reason:     in.Reason,
+		},
+	})
+	if err != nil {
+		return false, orderUpdateError(err)
+	}
+
+	return true, nil
+}
+
+type TransitionOrderInput struct {
+	OrderID       string
+	From          models.OrderStatus
+	To            models.OrderStatus
+	FailureReason string
+	// Actor is recorded with the transition in the status history
+	Actor models.OrderActor
+}
+
+// TransitionOrder moves an order from one status to another and publishes the lifecycle and checkout
+// events of the new status. In outbox mode all are written in one transaction, so the events are
+// published exactly when the transition commits. It reports false when the order is in neither status.
+func (a *OrderActivities) TransitionOrder(ctx context.Context, in TransitionOrderInput) (bool, error) {
+	if a.Prod.Mode() != producer.ModeOutbox {
+		return a.transitionOrder(ctx, in)
+	}
+
+	var applied bool
+	err := a.Repo.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		applied, err = a.transitionOrder(ctx, in)
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return applied, nil
+}
+
+func (a *OrderActivities) transitionOrder(ctx context.Context, in TransitionOrderInput) (bool, error) {
+	o, err := a.Repo.GetByID(ctx, in.OrderID)
+	if err != nil {
+		return false, err
+	}
+
+	switch o.Status {
+	case in.To:
+		// A retry after the transition committed. The outbox row committed with it, but a direct
+		// publish may have been lost, so only direct mode publishes again.
+		if a.Prod.Mode() == producer.ModeOutbox {
+			return true, nil
+		}
+	case in.From:
+		// A conflict means the order changed since it was read, the retry reads it again and finds
+		// it either transitioned or in another status
+		if o, err = a.Repo.Update(ctx, in.OrderID, repo.UpdateOrderOption{
+			Model:         o,
+			Status:        in.To,
+			FailureReason: in.FailureReason,
+			Change: repo.StatusChangeOption{
+				Actor:      in.Actor,
+				WorkflowID: workflowID(ctx),
+				Reason:     in.FailureReason,
+			},
+		}); err != nil {
+			return false, orderUpdateError(err)
+		}
+	default:
+		return false, nil
+	}
+
+	if err := a.publishTransition(ctx, o, in); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// publishTransition publishes the lifecycle event of the transition, and frees the waitroom slot of
+// a checkout session that reached a final status
+func (a *OrderActivities) publishTransition(ctx context.Context, o models.Order, in TransitionOrderInput) error {
+	itms, err := a.Repo.ListItemByOrderID(ctx, in.OrderID)
+	if err != nil {
+		return err
+	}
+
+	// Statuses without a lifecycle topic, such as PAYMENT_REVIEW, are not announced
+	if _, _, ok := kafka.OrderEventRoute(in.To); ok {
+		if err := a.Prod.PublishOrderEvent(ctx, kafka.OrderEvent{
+			Order: kafka.NewOrderPayload(o, itms),
+			Transition: kafka.OrderTransition{
+				From:   string(in.From),
+				To:     string(in.To),
+				Reason: in.FailureReason,
+			},
+		}); err != nil {
+			return err
+		}
+	}
+
+	if o.SessionID == "" {
+		return nil
+	}
+
+	switch in.To {
+	case models.OrderStatusCompleted:
+		return a.Prod.PublishCheckoutCompleted(ctx, kafka.CheckoutCompletedEvent{
+			SessionID: o.SessionID,
+			UserID:    o.UserID,
+			EventID:   o.EventID,
+		})
+	case models.OrderStatusCancelled, models.OrderStatusPaymentFailed, models.OrderStatusTimeout:
+		return a.Prod.PublishCheckoutFailed(ctx, kafka.CheckoutFailedEvent{
+			SessionID: o.SessionID,
+			UserID:    o.UserID,
+			EventID:   o.EventID,
+		})
+	default:
+		return nil
+	}
+}
+
+type RecordOrderPaymentInput struct {
+	OrderID       string
+	PaymentID     string
+	TransactionID string
+	PaidAt        time.Time
+}
+
+// RecordOrderPayment stores the payment that paid the order, whether or not it matches the order
+func (a *OrderActivities) RecordOrderPayment(ctx context.Context, in RecordOrderPaymentInput) error {
+	err := a.Repo.UpdatePayment(ctx, in.OrderID, repo.UpdateOrderPaymentOption{
+		PaymentID:     in.PaymentID,
+		TransactionID: in.TransactionID,
+		PaidAt:        in.PaidAt,
 	})
 	if err != nil {
 		return err
@@ -60,6 +323,15 @@ func (a *OrderActivities) UpdateOrderStatus(ctx context.Context, ID string, stat
 	return nil
 }
 
+func (a *OrderActivities) UpdateOrderPaymentID(ctx context.Context, ID string, paymentID string) error {
+	err := a.Repo.UpdatePaymentID(ctx, ID, paymentID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (a *OrderActivities) DeleteOrder(ctx context.Context, ID string) error {
 	err := a.Repo.Delete(ctx, ID)
 	if err != nil {
@@ -77,3 +349,64 @@ func (a *OrderActivities) DeleteOrderItems(ctx context.Context, ID string) error
 
 	return nil
 }
+
+type ListEventOrdersInput struct {
+	EventID string
+	After   repo.ListOrderCursor
+	Limit   int64
+}
+
+type EventOrder struct {
+	Code   string
+	Status models.OrderStatus
+}
+
+type ListEventOrdersResult struct {
+	Orders []EventOrder
+	// Next continues the listing, it is zero after the last page
+	Next repo.ListOrderCursor
+}
+
+// ListEventOrders pages through the orders of an event, newest first
+func (a *OrderActivities) ListEventOrders(ctx context.Context, in ListEventOrdersInput) (*ListEventOrdersResult, error) {
+	os, err := a.Repo.List(ctx, repo.ListOrderOption{
+		FilterOrder: order.FilterOrder{EventID: in.EventID},
+		After:       in.After,
+		Limit:       in.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &ListEventOrdersResult{Orders: make([]EventOrder, len(os))}
+	for i, o := range os {
+		res.Orders[i] = EventOrder{Code: o.Code, Status: o.Status}
+	}
+
+	if int64(len(os)) == in.Limit {
+		last := os[len(os)-1]
+		res.Next = repo.ListOrderCursor{CreatedAt: last.CreatedAt, ID: last.ID.Hex()}
+	}
+
+	return res, nil
+}
+
+// workflowID is the workflow the activity runs for, recorded with the status changes it makes
+func workflowID(ctx context.Context) string {
+	return activity.GetInfo(ctx).WorkflowExecution.ID
+}
+
+// orderUpdateError turns the typed errors of an order update into application errors: a conflict is
+// retried, an invalid transition is not
+func orderUpdateError(err error) error {
+	var cfErr *order.ConflictError
+	var trErr *order.TransitionError
+	switch {
+	case errors.As(err, &cfErr):
+		return temporal.NewApplicationErrorWithCause(err.Error(), ErrTypeOrderConflict, err)
+	case errors.As(err, &trErr):
+		return temporal.NewNonRetryableApplicationError(err.Error(), ErrTypeInvalidOrderTransition, err)
+	default:
+		return err
+	}
+}
@@ -0,0 +1,223 @@
+package activities
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vogiaan1904/ticketbottle-order/internal/models"
+	repo "github.com/vogiaan1904/ticketbottle-order/internal/order/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.temporal.io/sdk/temporal"
+)
+
+// ErrTypeInvalidRefund is the application error type returned when a refund request does not fit the order
+const ErrTypeInvalidRefund = "INVALID_REFUND"
+
+type RefundItemInput struct {
+	TicketClassID string
+	Quantity      int32
+}
+
+type CreateOrderRefundInput struct {
+	OrderID        string
+	OrderCode      string
+	Reason         string
+	Items          []RefundItemInput
+	IdempotencyKey string
+}
+
+// CreateOrderRefund validates the requested quantities against what is still refundable and
+// writes a PENDING refund ledger entry. An empty item list refunds everything left on the order.
+func (a *OrderActivities) CreateOrderRefund(ctx context.Context, in CreateOrderRefundInput) (*models.OrderRefund, error) {
+	rfs, err := a.Repo.ListRefundByOrderID(ctx, in.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rf := range rfs {
+		if rf.IdempotencyKey == in.IdempotencyKey {
+			return &rf, nil
+		}
+	}
+
+	itms, err := a.Repo.ListItemByOrderID(ctx, in.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	rfItms, amt, err := buildRefundItems(itms, refundedQuantities(rfs), in.Items)
+	if err != nil {
+		return nil, temporal.NewNonRetryableApplicationError(err.Error(), ErrTypeInvalidRefund, err)
+	}
+
+	rf, err := a.Repo.CreateRefund(ctx, repo.CreateOrderRefundOption{
+		OrderID:        in.OrderID,
+		OrderCode:      in.OrderCode,
+		Items:          rfItms,
+		Amount:         amt,
+		Reason:         in.Reason,
+		IdempotencyKey: in.IdempotencyKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &rf, nil
+}
+
+func (a *OrderActivities) CompleteOrderRefund(ctx context.Context, refundID string, paymentRefundID string) error {
+	_, err := a.Repo.UpdateRefund(ctx, refundID, repo.UpdateOrderRefundOption{
+		Status:          models.OrderRefundStatusSucceeded,
+		PaymentRefundID: paymentRefundID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// FailOrderRefund voids a refund ledger entry (compensation)
+func (a *OrderActivities) FailOrderRefund(ctx context.Context, refundID string) error {
+	_, err := a.Repo.UpdateRefund(ctx, refundID, repo.UpdateOrderRefundOption{
+		Status: models.OrderRefundStatusFailed,
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SyncOrderRefunds recomputes the refunded quantity of every item, the refunded amount and the
+// refund status of the order from the refund ledger. It is idempotent, and runs once the payment
+// refund succeeded.
+// A status change is recorded with actor and reason in the status history.
+func (a *OrderActivities) SyncOrderRefunds(ctx context.Context, orderID string, actor models.OrderActor, reason string) (*models.Order, error) {
+	o, err := a.Repo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	rfs, err := a.Repo.ListRefundByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	itms, err := a.Repo.ListItemByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	qtys := refundedQuantities(rfs)
+	for i, itm := range itms {
+		if itm.RefundedQuantity == qtys[itm.ID] {
+			continue
+		}
+		if err := a.Repo.UpdateItemRefundedQuantity(ctx, itm.ID.Hex(), qtys[itm.ID]); err != nil {
+			return nil, err
+		}
+		itms[i].RefundedQuantity = qtys[itm.ID]
+	}
+
+	opt := repo.UpdateOrderOption{
+		Model:  o,
+		Status: models.RefundStatus(itms),
+		Change: repo.StatusChangeOption{
+			Actor:      actor,
+			WorkflowID: workflowID(ctx),
+			Reason:     reason,
+		},
+	}
+
+	var last *models.OrderRefund
+	var amt int64
+	for i, rf := range rfs {
+		if rf.Status == models.OrderRefundStatusFailed {
+			continue
+		}
+		amt += rf.Amount
+		last = &rfs[i]
+	}
+
+	if last != nil {
+		opt.Refund = &repo.RefundOrderOption{
+			Amount:     amt,
+			Reason:     last.Reason,
+			RefundedAt: last.CreatedAt,
+		}
+	} else {
+		opt.ClearRefund = true
+	}
+
+	uo, err := a.Repo.Update(ctx, orderID, opt)
+	if err != nil {
+		return nil, orderUpdateError(err)
+	}
+
+	return &uo, nil
+}
+
+func refundedQuantities(rfs []models.OrderRefund) map[primitive.ObjectID]int32 {
+	qtys := make(map[primitive.ObjectID]int32)
+	for _, rf := range rfs {
+		if rf.Status == models.OrderRefundStatusFailed {
+			continue
+		}
+		for _, itm := range rf.Items {
+			qtys[itm.OrderItemID] += itm.Quantity
+		}
+	}
+
+	return qtys
+}
+
+func buildRefundItems(itms []models.OrderItem, refunded map[primitive.ObjectID]int32, ins []RefundItemInput) ([]models.OrderRefundItem, int64, error) {
+	var rfItms []models.OrderRefundItem
+	var amt int64
+
+	add := func(itm models.OrderItem, qty int32) {
+		lineAmt := itm.PriceAtPurchase * int64(qty)
+		rfItms = append(rfItms, models.OrderRefundItem{
+			OrderItemID:   itm.ID,
+			TicketClassID: itm.TicketClassID,
+			Quantity:      qty,
+			Amount:        lineAmt,
+		})
+		amt += lineAmt
+		refunded[itm.ID] += qty
+	}
+
+	if len(ins) == 0 {
+		for _, itm := range itms {
+			if left := itm.Quantity - refunded[itm.ID]; left > 0 {
+				add(itm, left)
+			}
+		}
+	}
+
+	for _, in := range ins {
+		var found bool
+		for _, itm := range itms {
+			if itm.TicketClassID != in.TicketClassID {
+				continue
+			}
+			found = true
+			left := itm.Quantity - refunded[itm.ID]
+			if in.Quantity <= 0 || in.Quantity > left {
+				return nil, 0, fmt.Errorf("refund quantity %d exceeds refundable quantity %d of ticket class %s", in.Quantity, left, in.TicketClassID)
+			}
+			add(itm, in.Quantity)
+			break
+		}
+		if !found {
+			return nil, 0, fmt.Errorf("ticket class %s is not part of the order", in.TicketClassID)
+		}
+	}
+
+	if len(rfItms) == 0 {
+		return nil, 0, fmt.Errorf("nothing left to refund")
+	}
+
+	return rfItms, amt, nil
+}
@@ -2,12 +2,21 @@ package activities
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/vogiaan1904/ticketbottle-order/pkg/grpc/payment"
+	"go.temporal.io/sdk/temporal"
 )
 
+// ErrTypePaymentAlreadyCompleted is the application error type returned when cancelling a paid payment intent
+const ErrTypePaymentAlreadyCompleted = "PAYMENT_ALREADY_COMPLETED"
+
+// ErrTypeRefundsDisabled is the application error type returned by the refund activities while refunds are turned off
+const ErrTypeRefundsDisabled = "REFUNDS_DISABLED"
+
 type PaymentActivities struct {
-	Client payment.PaymentServiceClient
+	Client         payment.PaymentServiceClient
+	RefundsEnabled bool
 }
 
 type CreatePaymentIntentInput struct {
@@ -20,9 +29,17 @@ type CreatePaymentIntentInput struct {
 	TimeoutSeconds int32
 }
 
-func NewPaymentActivities(client payment.PaymentServiceClient) *PaymentActivities {
+type RefundPaymentInput struct {
+	OrderCode      string
+	AmountCents    int64
+	Reason         string
+	IdempotencyKey string
+}
+
+func NewPaymentActivities(client payment.PaymentServiceClient, refundsEnabled bool) *PaymentActivities {
 	return &PaymentActivities{
-		Client: client,
+		Client:         client,
+		RefundsEnabled: refundsEnabled,
 	}
 }
 
@@ -43,10 +60,52 @@ func (a *PaymentActivities) CreatePaymentIntent(ctx context.Context, in *CreateP
 	return resp, nil
 }
 
-// CancelPayment cancels a payment (if supported by payment service)
-// Note: This method may need to be implemented based on your payment service interface
-func (a *PaymentActivities) CancelPayment(ctx context.Context, orderCode string, reason string) error {
-	// TODO: Implement if payment service supports cancellation
-	// For now, we'll just log the cancellation request
-	return nil
+// CancelPayment cancels a payment intent so its payment URL can no longer be paid.
+// An intent that was already paid is reported as a non-retryable PAYMENT_ALREADY_COMPLETED error.
+func (a *PaymentActivities) CancelPayment(ctx context.Context, paymentID string) error {
+	// Orders placed before payment IDs were stored have nothing to cancel
+	if paymentID == "" {
+		return nil
+	}
+
+	resp, err := a.Client.CancelPaymentIntent(ctx, &payment.CancelPaymentIntentRequest{
+		PaymentId: paymentID,
+	})
+	if err != nil {
+		return err
+	}
+
+	switch resp.Status {
+	case payment.PaymentStatus_CANCELED, payment.PaymentStatus_FAILED, payment.PaymentStatus_REFUNDED:
+		return nil
+	case payment.PaymentStatus_COMPLETED:
+		return temporal.NewNonRetryableApplicationError("payment intent was already paid", ErrTypePaymentAlreadyCompleted, nil, paymentID)
+	default:
+		return fmt.Errorf("payment intent %s is still %s", paymentID, resp.Status)
+	}
+}
+
+// RefundPayment asks the payment service to refund an order.
+// A FAILED refund is reported as a non-retryable error so the caller can compensate, and so is
+// every refund while refunds are turned off.
+func (a *PaymentActivities) RefundPayment(ctx context.Context, in *RefundPaymentInput) (*payment.RefundPaymentResponse, error) {
+	if !a.RefundsEnabled {
+		return nil, temporal.NewNonRetryableApplicationError("refunds are disabled", ErrTypeRefundsDisabled, nil, in.OrderCode)
+	}
+
+	resp, err := a.Client.RefundPayment(ctx, &payment.RefundPaymentRequest{
+		OrderCode:      in.OrderCode,
+		AmountCents:    in.AmountCents,
+		Reason:         in.Reason,
+		IdempotencyKey: in.IdempotencyKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Status == payment.PaymentStatus_FAILED {
+		return nil, temporal.NewNonRetryableApplicationError("payment refund was declined", "REFUND_FAILED", nil, resp.RefundId)
+	}
+
+	return resp, nil
 }
@@ -17,3 +17,18 @@ func NewConsumerGroup(cfg config.KafkaConfig) (sarama.ConsumerGroup, error) {
 
 	return consGr, nil
 }
+
+// NewReplayConsumerGroup creates a consumer group that starts from the oldest message,
+// used to drain dead-letter topics
+func NewReplayConsumerGroup(cfg config.KafkaConfig, groupID string) (sarama.ConsumerGroup, error) {
+	consGr, err := pkgKafka.NewConsumer(pkgKafka.ConsumerConfig{
+		Brokers:       cfg.Brokers,
+		GroupID:       groupID,
+		InitialOffset: sarama.OffsetOldest,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return consGr, nil
+}
@@ -8,4 +8,16 @@ const (
 
 	// ConfirmOrderTaskQueue is for Consumer process - handles payment confirmations
 	ConfirmOrderTaskQueue = "confirm-order-tasks"
+
+	// FailOrderTaskQueue is for Consumer process - handles payment failures and timeouts
+	FailOrderTaskQueue = "fail-order-tasks"
+
+	// RefundOrderTaskQueue is for API process - handles order refunds
+	RefundOrderTaskQueue = "refund-order-tasks"
+
+	// CancelOrderTaskQueue is for API process - handles order cancellations
+	CancelOrderTaskQueue = "cancel-order-tasks"
+
+	// CancelEventOrdersTaskQueue is for Consumer process - handles the orders of cancelled events
+	CancelEventOrdersTaskQueue = "cancel-event-orders-tasks"
 )
@@ -0,0 +1,39 @@
+package grpc
+
+import (
+	"github.com/vogiaan1904/ticketbottle-order/internal/intervention"
+	pkgErrors "github.com/vogiaan1904/ticketbottle-order/pkg/errors"
+)
+
+var (
+	ErrValidationFailed = pkgErrors.NewGRPCError("ITV400", "Validation failed")
+	// Intervention errors
+	ErrGRPCInterventionNotFound       = pkgErrors.NewGRPCError("ITV001", "Intervention not found")
+	ErrGRPCInterventionNotOpen        = pkgErrors.NewGRPCError("ITV002", "Intervention is not open")
+	ErrGRPCInterventionNotClaimed     = pkgErrors.NewGRPCError("ITV003", "Intervention is not claimed")
+	ErrGRPCInterventionClaimedByOther = pkgErrors.NewGRPCError("ITV004", "Intervention is claimed by another operator")
+	ErrGRPCInterventionConflict       = pkgErrors.NewGRPCError("ITV005", "Intervention was changed concurrently")
+	ErrGRPCInterventionSignalFailed   = pkgErrors.NewGRPCError("ITV006", "Failed to deliver intervention decision")
+	ErrGRPCInvalidCursor              = pkgErrors.NewGRPCError("ITV007", "Invalid pagination cursor")
+)
+
+func (s *grpcService) mapError(err error) error {
+	switch err {
+	case intervention.ErrInterventionNotFound:
+		return ErrGRPCInterventionNotFound
+	case intervention.ErrInterventionNotOpen:
+		return ErrGRPCInterventionNotOpen
+	case intervention.ErrInterventionNotClaimed:
+		return ErrGRPCInterventionNotClaimed
+	case intervention.ErrInterventionClaimedByOther:
+		return ErrGRPCInterventionClaimedByOther
+	case intervention.ErrInterventionConflict:
+		return ErrGRPCInterventionConflict
+	case intervention.ErrInterventionSignalFailed:
+		return ErrGRPCInterventionSignalFailed
+	case intervention.ErrInvalidCursor:
+		return ErrGRPCInvalidCursor
+	default:
+		return err
+	}
+}
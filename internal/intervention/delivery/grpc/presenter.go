@@ -0,0 +1,70 @@
+package grpc
+
+import (
+	"github.com/vogiaan1904/ticketbottle-order/internal/intervention"
+	"github.com/vogiaan1904/ticketbottle-order/internal/models"
+	interventionpb "github.com/vogiaan1904/ticketbottle-order/pkg/grpc/intervention"
+	"github.com/vogiaan1904/ticketbottle-order/pkg/util"
+)
+
+var GrpcInterventionStatusValue = map[models.InterventionStatus]interventionpb.InterventionStatus{
+	models.InterventionStatusOpen:     interventionpb.InterventionStatus_INTERVENTION_STATUS_OPEN,
+	models.InterventionStatusClaimed:  interventionpb.InterventionStatus_INTERVENTION_STATUS_CLAIMED,
+	models.InterventionStatusResolved: interventionpb.InterventionStatus_INTERVENTION_STATUS_RESOLVED,
+}
+
+var InterventionStatus = map[interventionpb.InterventionStatus]models.InterventionStatus{
+	interventionpb.InterventionStatus_INTERVENTION_STATUS_OPEN:     models.InterventionStatusOpen,
+	interventionpb.InterventionStatus_INTERVENTION_STATUS_CLAIMED:  models.InterventionStatusClaimed,
+	interventionpb.InterventionStatus_INTERVENTION_STATUS_RESOLVED: models.InterventionStatusResolved,
+}
+
+var GrpcInterventionActionValue = map[models.InterventionAction]interventionpb.InterventionAction{
+	models.InterventionActionRetry:  interventionpb.InterventionAction_INTERVENTION_ACTION_RETRY,
+	models.InterventionActionRefund: interventionpb.InterventionAction_INTERVENTION_ACTION_REFUND,
+}
+
+var InterventionAction = map[interventionpb.InterventionAction]models.InterventionAction{
+	interventionpb.InterventionAction_INTERVENTION_ACTION_RETRY:  models.InterventionActionRetry,
+	interventionpb.InterventionAction_INTERVENTION_ACTION_REFUND: models.InterventionActionRefund,
+}
+
+func (s *grpcService) newInterventionResponse(itv models.Intervention) *interventionpb.Intervention {
+	pbItv := &interventionpb.Intervention{
+		Id:         itv.ID.Hex(),
+		OrderCode:  itv.OrderCode,
+		Step:       string(itv.Step),
+		Error:      itv.Error,
+		WorkflowId: itv.WorkflowID,
+		RunId:      itv.RunID,
+		Status:     GrpcInterventionStatusValue[itv.Status],
+		ClaimedBy:  itv.ClaimedBy,
+		Action:     GrpcInterventionActionValue[itv.Action],
+		ResolvedBy: itv.ResolvedBy,
+		Note:       itv.Note,
+		CreatedAt:  util.TimeToISO8601Str(itv.CreatedAt),
+		UpdatedAt:  util.TimeToISO8601Str(itv.UpdatedAt),
+	}
+
+	if itv.ClaimedAt != nil {
+		pbItv.ClaimedAt = util.TimeToISO8601Str(*itv.ClaimedAt)
+	}
+
+	if itv.ResolvedAt != nil {
+		pbItv.ResolvedAt = util.TimeToISO8601Str(*itv.ResolvedAt)
+	}
+
+	return pbItv
+}
+
+func (s *grpcService) newListInterventionsResponse(out intervention.ListInterventionOutput) *interventionpb.ListInterventionsResponse {
+	pbItvs := make([]*interventionpb.Intervention, len(out.Interventions))
+	for i, itv := range out.Interventions {
+		pbItvs[i] = s.newInterventionResponse(itv)
+	}
+
+	return &interventionpb.ListInterventionsResponse{
+		Interventions: pbItvs,
+		NextCursor:    out.Next,
+	}
+}
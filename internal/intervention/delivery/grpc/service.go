@@ -0,0 +1,95 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/vogiaan1904/ticketbottle-order/internal/intervention"
+	interventionpb "github.com/vogiaan1904/ticketbottle-order/pkg/grpc/intervention"
+	"github.com/vogiaan1904/ticketbottle-order/pkg/logger"
+	"github.com/vogiaan1904/ticketbottle-order/pkg/response"
+)
+
+type grpcService struct {
+	svc intervention.Service
+	l   logger.Logger
+	interventionpb.UnimplementedInterventionServiceServer
+}
+
+func NewGrpcService(svc intervention.Service, l logger.Logger) interventionpb.InterventionServiceServer {
+	return &grpcService{
+		svc: svc,
+		l:   l,
+	}
+}
+
+func (s *grpcService) ListInterventions(ctx context.Context, req *interventionpb.ListInterventionsRequest) (*interventionpb.ListInterventionsResponse, error) {
+	if err := s.validateListInterventionsRequest(req); err != nil {
+		s.l.Errorf(ctx, "internal.intervention.delivery.grpc.service.ListInterventions.validateListInterventionsRequest: %v", err)
+		return nil, response.GrpcError(err)
+	}
+
+	in := intervention.ListInterventionInput{
+		FilterIntervention: intervention.FilterIntervention{
+			OrderCode: req.GetOrderCode(),
+		},
+		Cursor: req.GetCursor(),
+		Limit:  req.GetLimit(),
+	}
+	if req.Status != nil {
+		stt := InterventionStatus[req.GetStatus()]
+		in.Status = &stt
+	}
+
+	out, err := s.svc.List(ctx, in)
+	if err != nil {
+		err := s.mapError(err)
+		s.l.Errorf(ctx, "internal.intervention.delivery.grpc.service.ListInterventions: %v", err)
+		return nil, response.GrpcError(err)
+	}
+
+	return s.newListInterventionsResponse(out), nil
+}
+
+func (s *grpcService) ClaimIntervention(ctx context.Context, req *interventionpb.ClaimInterventionRequest) (*interventionpb.ClaimInterventionResponse, error) {
+	if err := s.validateClaimInterventionRequest(req); err != nil {
+		s.l.Errorf(ctx, "internal.intervention.delivery.grpc.service.ClaimIntervention.validateClaimInterventionRequest: %v", err)
+		return nil, response.GrpcError(err)
+	}
+
+	itv, err := s.svc.Claim(ctx, intervention.ClaimInterventionInput{
+		ID:       req.GetId(),
+		Operator: req.GetOperator(),
+	})
+	if err != nil {
+		err := s.mapError(err)
+		s.l.Errorf(ctx, "internal.intervention.delivery.grpc.service.ClaimIntervention: %v", err)
+		return nil, response.GrpcError(err)
+	}
+
+	return &interventionpb.ClaimInterventionResponse{
+		Intervention: s.newInterventionResponse(itv),
+	}, nil
+}
+
+func (s *grpcService) ResolveIntervention(ctx context.Context, req *interventionpb.ResolveInterventionRequest) (*interventionpb.ResolveInterventionResponse, error) {
+	if err := s.validateResolveInterventionRequest(req); err != nil {
+		s.l.Errorf(ctx, "internal.intervention.delivery.grpc.service.ResolveIntervention.validateResolveInterventionRequest: %v", err)
+		return nil, response.GrpcError(err)
+	}
+
+	itv, err := s.svc.Resolve(ctx, intervention.ResolveInterventionInput{
+		ID:       req.GetId(),
+		Operator: req.GetOperator(),
+		Action:   InterventionAction[req.GetAction()],
+		Note:     req.GetNote(),
+	})
+	if err != nil {
+		err := s.mapError(err)
+		s.l.Errorf(ctx, "internal.intervention.delivery.grpc.service.ResolveIntervention: %v", err)
+		return nil, response.GrpcError(err)
+	}
+
+	return &interventionpb.ResolveInterventionResponse{
+		Intervention: s.newInterventionResponse(itv),
+	}, nil
+}
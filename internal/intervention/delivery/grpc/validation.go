@@ -0,0 +1,40 @@
+package grpc
+
+import (
+	interventionpb "github.com/vogiaan1904/ticketbottle-order/pkg/grpc/intervention"
+)
+
+func (s *grpcService) validateListInterventionsRequest(req *interventionpb.ListInterventionsRequest) error {
+	if req.GetLimit() < 0 {
+		return ErrValidationFailed
+	}
+	if req.Status != nil {
+		if _, ok := InterventionStatus[req.GetStatus()]; !ok {
+			return ErrValidationFailed
+		}
+	}
+	return nil
+}
+
+func (s *grpcService) validateClaimInterventionRequest(req *interventionpb.ClaimInterventionRequest) error {
+	if req.GetId() == "" {
+		return ErrValidationFailed
+	}
+	if req.GetOperator() == "" {
+		return ErrValidationFailed
+	}
+	return nil
+}
+
+func (s *grpcService) validateResolveInterventionRequest(req *interventionpb.ResolveInterventionRequest) error {
+	if req.GetId() == "" {
+		return ErrValidationFailed
+	}
+	if req.GetOperator() == "" {
+		return ErrValidationFailed
+	}
+	if _, ok := InterventionAction[req.GetAction()]; !ok {
+		return ErrValidationFailed
+	}
+	return nil
+}
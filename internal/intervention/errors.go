@@ -0,0 +1,17 @@
+package intervention
+
+import (
+	"errors"
+
+	"github.com/vogiaan1904/ticketbottle-order/pkg/paginator"
+)
+
+var (
+	ErrInterventionNotFound       = errors.New("intervention not found")
+	ErrInterventionNotOpen        = errors.New("intervention is not open")
+	ErrInterventionNotClaimed     = errors.New("intervention is not claimed")
+	ErrInterventionClaimedByOther = errors.New("intervention is claimed by another operator")
+	ErrInterventionConflict       = errors.New("intervention was changed concurrently")
+	ErrInterventionSignalFailed   = errors.New("failed to deliver intervention decision")
+	ErrInvalidCursor              = paginator.ErrInvalidCursor
+)
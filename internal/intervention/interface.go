@@ -0,0 +1,13 @@
+package intervention
+
+import (
+	"context"
+
+	"github.com/vogiaan1904/ticketbottle-order/internal/models"
+)
+
+type Service interface {
+	List(ctx context.Context, in ListInterventionInput) (ListInterventionOutput, error)
+	Claim(ctx context.Context, in ClaimInterventionInput) (models.Intervention, error)
+	Resolve(ctx context.Context, in ResolveInterventionInput) (models.Intervention, error)
+}
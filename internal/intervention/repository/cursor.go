@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/vogiaan1904/ticketbottle-order/internal/intervention"
+	"github.com/vogiaan1904/ticketbottle-order/internal/models"
+	"github.com/vogiaan1904/ticketbottle-order/pkg/paginator"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// listCursor is the content of the opaque cursors of List
+type listCursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        string    `json:"i"`
+}
+
+// EncodeListCursor is the opaque cursor continuing a List behind itv
+func EncodeListCursor(itv models.Intervention) (string, error) {
+	return paginator.EncodeCursor(listCursor{
+		CreatedAt: itv.CreatedAt,
+		ID:        itv.ID.Hex(),
+	})
+}
+
+// DecodeListCursor reads a cursor made by EncodeListCursor and returns
+// intervention.ErrInvalidCursor for anything else
+func DecodeListCursor(s string) (ListInterventionCursor, error) {
+	var c listCursor
+	if err := paginator.DecodeCursor(s, &c); err != nil {
+		return ListInterventionCursor{}, intervention.ErrInvalidCursor
+	}
+
+	if c.CreatedAt.IsZero() || !primitive.IsValidObjectID(c.ID) {
+		return ListInterventionCursor{}, intervention.ErrInvalidCursor
+	}
+
+	return ListInterventionCursor{CreatedAt: c.CreatedAt, ID: c.ID}, nil
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/vogiaan1904/ticketbottle-order/internal/models"
+)
+
+type Repository interface {
+	Create(ctx context.Context, opt CreateInterventionOption) (models.Intervention, error)
+	GetByID(ctx context.Context, ID string) (models.Intervention, error)
+	List(ctx context.Context, opt ListInterventionOption) ([]models.Intervention, error)
+	// Update only applies when the intervention is still in opt.ExpectedStatus,
+	// mongo.ErrNoDocuments is returned otherwise
+	Update(ctx context.Context, ID string, opt UpdateInterventionOption) (models.Intervention, error)
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/vogiaan1904/ticketbottle-order/internal/models"
+	"github.com/vogiaan1904/ticketbottle-order/pkg/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	interventionCollection = "interventions"
+)
+
+func (r *implRepository) getInterventionCollection() mongo.Collection {
+	return r.db.Collection(interventionCollection)
+}
+
+func (r *implRepository) Create(ctx context.Context, opt CreateInterventionOption) (models.Intervention, error) {
+	col := r.getInterventionCollection()
+
+	itv := r.buildInterventionModel(opt)
+	if _, err := col.InsertOne(ctx, itv); err != nil {
+		r.l.Errorf(ctx, "intervention.repository.InterventionRepository.Create: %v", err)
+		return models.Intervention{}, err
+	}
+
+	return itv, nil
+}
+
+func (r *implRepository) GetByID(ctx context.Context, ID string) (models.Intervention, error) {
+	col := r.getInterventionCollection()
+
+	fil, err := r.buildGetByIDQuery(ctx, ID)
+	if err != nil {
+		r.l.Errorf(ctx, "intervention.repository.InterventionRepository.GetByID: %v", err)
+		return models.Intervention{}, err
+	}
+
+	var itv models.Intervention
+	if err := col.FindOne(ctx, fil).Decode(&itv); err != nil {
+		r.l.Errorf(ctx, "intervention.repository.InterventionRepository.GetByID: %v", err)
+		return models.Intervention{}, err
+	}
+
+	return itv, nil
+}
+
+func (r *implRepository) List(ctx context.Context, opt ListInterventionOption) ([]models.Intervention, error) {
+	col := r.getInterventionCollection()
+
+	q := r.buildFilterQuery(opt.FilterIntervention)
+	if !opt.After.IsZero() {
+		var err error
+		if q, err = r.buildListAfterQuery(ctx, q, opt.After); err != nil {
+			return nil, err
+		}
+	}
+
+	findOpts := options.Find().SetSort(bson.D{
+		{Key: "created_at", Value: 1},
+		{Key: "_id", Value: 1},
+	})
+	if opt.Limit > 0 {
+		findOpts.SetLimit(opt.Limit)
+	}
+
+	cur, err := col.Find(ctx, q, findOpts)
+	if err != nil {
+		r.l.Errorf(ctx, "intervention.repository.InterventionRepository.List: %v", err)
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var itvs []models.Intervention
+	if err := cur.All(ctx, &itvs); err != nil {
+		r.l.Errorf(ctx, "intervention.repository.InterventionRepository.List: %v", err)
+		return nil, err
+	}
+
+	return itvs, nil
+}
+
+func (r *implRepository) Update(ctx context.Context, ID string, opt UpdateInterventionOption) (models.Intervention, error) {
+	col := r.getInterventionCollection()
+
+	itv, upDoc := r.buildUpdateInterventionModel(opt)
+
+	fil, err := r.buildGetByIDQuery(ctx, ID)
+	if err != nil {
+		r.l.Errorf(ctx, "intervention.repository.InterventionRepository.Update: %v", err)
+		return models.Intervention{}, err
+	}
+	fil["status"] = opt.ExpectedStatus
+
+	res, err := col.UpdateOne(ctx, fil, upDoc)
+	if err != nil {
+		r.l.Errorf(ctx, "intervention.repository.InterventionRepository.Update: %v", err)
+		return models.Intervention{}, err
+	}
+
+	if res.MatchedCount == 0 {
+		return models.Intervention{}, mongo.ErrNoDocuments
+	}
+
+	return itv, nil
+}
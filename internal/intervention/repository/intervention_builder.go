@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"github.com/vogiaan1904/ticketbottle-order/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func (r *implRepository) buildInterventionModel(opt CreateInterventionOption) models.Intervention {
+	now := r.clock()
+	m := models.Intervention{
+		ID:         r.db.NewObjectID(),
+		OrderCode:  opt.OrderCode,
+		Step:       opt.Step,
+		Error:      opt.Error,
+		WorkflowID: opt.WorkflowID,
+		RunID:      opt.RunID,
+		Status:     models.InterventionStatusOpen,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	return m
+}
+
+func (r *implRepository) buildUpdateInterventionModel(opt UpdateInterventionOption) (models.Intervention, bson.M) {
+	now := r.clock()
+
+	m := opt.Model
+	set := bson.M{
+		"status": opt.Status,
+	}
+	m.Status = opt.Status
+
+	if opt.ClaimedBy != "" {
+		set["claimed_by"] = opt.ClaimedBy
+		m.ClaimedBy = opt.ClaimedBy
+	}
+
+	if opt.ClaimedAt != nil {
+		set["claimed_at"] = *opt.ClaimedAt
+		m.ClaimedAt = opt.ClaimedAt
+	}
+
+	if opt.Resolution != nil {
+		set["action"] = opt.Resolution.Action
+		set["resolved_by"] = opt.Resolution.ResolvedBy
+		set["resolved_at"] = opt.Resolution.ResolvedAt
+		set["note"] = opt.Resolution.Note
+		m.Action = opt.Resolution.Action
+		m.ResolvedBy = opt.Resolution.ResolvedBy
+		m.ResolvedAt = &opt.Resolution.ResolvedAt
+		m.Note = opt.Resolution.Note
+	}
+
+	m.UpdatedAt = now
+	set["updated_at"] = now
+
+	upDoc := bson.M{"$set": set}
+
+	if opt.ClearResolution {
+		upDoc["$unset"] = bson.M{
+			"action":      "",
+			"resolved_by": "",
+			"resolved_at": "",
+			"note":        "",
+		}
+		m.Action = ""
+		m.ResolvedBy = ""
+		m.ResolvedAt = nil
+		m.Note = ""
+	}
+
+	return m, upDoc
+}
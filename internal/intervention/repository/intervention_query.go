@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/vogiaan1904/ticketbottle-order/internal/intervention"
+	"github.com/vogiaan1904/ticketbottle-order/pkg/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func (r *implRepository) buildGetByIDQuery(ctx context.Context, ID string) (bson.M, error) {
+	q := bson.M{}
+	q = mongo.BuildQueryWithSoftDelete(q)
+
+	objID, err := primitive.ObjectIDFromHex(ID)
+	if err != nil {
+		r.l.Errorf(ctx, "intervention.repository.InterventionRepository.buildGetByIDQuery: %v", err)
+		return nil, err
+	}
+	q["_id"] = objID
+
+	return q, nil
+}
+
+// buildListAfterQuery narrows q to the interventions sorted after cur, oldest first
+func (r *implRepository) buildListAfterQuery(ctx context.Context, q bson.M, cur ListInterventionCursor) (bson.M, error) {
+	objID, err := primitive.ObjectIDFromHex(cur.ID)
+	if err != nil {
+		r.l.Errorf(ctx, "intervention.repository.InterventionRepository.buildListAfterQuery: %v", err)
+		return nil, err
+	}
+
+	q["$or"] = bson.A{
+		bson.M{"created_at": bson.M{"$gt": cur.CreatedAt}},
+		bson.M{"created_at": cur.CreatedAt, "_id": bson.M{"$gt": objID}},
+	}
+
+	return q, nil
+}
+
+func (r *implRepository) buildFilterQuery(fil intervention.FilterIntervention) bson.M {
+	q := bson.M{}
+	q = mongo.BuildQueryWithSoftDelete(q)
+
+	if fil.OrderCode != "" {
+		q["order_code"] = fil.OrderCode
+	}
+
+	if fil.RunID != "" {
+		q["run_id"] = fil.RunID
+	}
+
+	if fil.Step != "" {
+		q["step"] = fil.Step
+	}
+
+	if fil.Status != nil {
+		q["status"] = *fil.Status
+	}
+
+	return q
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/vogiaan1904/ticketbottle-order/internal/intervention"
+	"github.com/vogiaan1904/ticketbottle-order/internal/models"
+)
+
+type CreateInterventionOption struct {
+	OrderCode  string
+	Step       models.InterventionStep
+	Error      string
+	WorkflowID string
+	RunID      string
+}
+
+type ListInterventionOption struct {
+	intervention.FilterIntervention
+	// After continues the listing behind the given intervention, zero starts from the oldest one
+	After ListInterventionCursor
+	// Limit caps the number of interventions returned, zero returns all of them
+	Limit int64
+}
+
+// ListInterventionCursor names an intervention by the created_at, _id sort of List
+type ListInterventionCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+func (c ListInterventionCursor) IsZero() bool {
+	return c.ID == ""
+}
+
+type UpdateInterventionOption struct {
+	Model          models.Intervention
+	ExpectedStatus models.InterventionStatus
+	Status         models.InterventionStatus
+	ClaimedBy      string
+	ClaimedAt      *time.Time
+	Resolution     *ResolveInterventionOption
+	// ClearResolution removes a previously recorded resolution, used when the decision could not be delivered
+	ClearResolution bool
+}
+
+type ResolveInterventionOption struct {
+	Action     models.InterventionAction
+	ResolvedBy string
+	ResolvedAt time.Time
+	Note       string
+}
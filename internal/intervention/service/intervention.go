@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+
+	"github.com/vogiaan1904/ticketbottle-order/internal/intervention"
+	repo "github.com/vogiaan1904/ticketbottle-order/internal/intervention/repository"
+	"github.com/vogiaan1904/ticketbottle-order/internal/models"
+	"github.com/vogiaan1904/ticketbottle-order/internal/workflows"
+	"github.com/vogiaan1904/ticketbottle-order/pkg/mongo"
+	"github.com/vogiaan1904/ticketbottle-order/pkg/paginator"
+)
+
+func (s *implService) List(ctx context.Context, in intervention.ListInterventionInput) (intervention.ListInterventionOutput, error) {
+	if in.Limit < 1 || in.Limit > paginator.MaxLimit {
+		in.Limit = paginator.MaxLimit
+	}
+
+	var after repo.ListInterventionCursor
+	if in.Cursor != "" {
+		var err error
+		if after, err = repo.DecodeListCursor(in.Cursor); err != nil {
+			s.l.Warnf(ctx, "internal.intervention.service.List: %v", err)
+			return intervention.ListInterventionOutput{}, err
+		}
+	}
+
+	// One more intervention than the limit tells whether the listing goes on
+	itvs, err := s.repo.List(ctx, repo.ListInterventionOption{
+		FilterIntervention: in.FilterIntervention,
+		After:              after,
+		Limit:              in.Limit + 1,
+	})
+	if err != nil {
+		s.l.Errorf(ctx, "internal.intervention.service.List.repo.List: %v", err)
+		return intervention.ListInterventionOutput{}, err
+	}
+
+	out := intervention.ListInterventionOutput{Interventions: itvs}
+	if int64(len(itvs)) > in.Limit {
+		out.Interventions = itvs[:in.Limit]
+		if out.Next, err = repo.EncodeListCursor(out.Interventions[in.Limit-1]); err != nil {
+			s.l.Errorf(ctx, "internal.intervention.service.List.repo.EncodeListCursor: %v", err)
+			return intervention.ListInterventionOutput{}, err
+		}
+	}
+
+	return out, nil
+}
+
+func (s *implService) Claim(ctx context.Context, in intervention.ClaimInterventionInput) (models.Intervention, error) {
+	itv, err := s.getByID(ctx, in.ID)
+	if err != nil {
+		s.l.Errorf(ctx, "internal.intervention.service.Claim.getByID: %v", err)
+		return models.Intervention{}, err
+	}
+
+	if itv.Status == models.InterventionStatusClaimed && itv.ClaimedBy == in.Operator {
+		return itv, nil
+	}
+
+	if itv.Status != models.InterventionStatusOpen {
+		s.l.Warnf(ctx, "internal.intervention.service.Claim: %v", intervention.ErrInterventionNotOpen)
+		return models.Intervention{}, intervention.ErrInterventionNotOpen
+	}
+
+	now := s.clock()
+	itv, err = s.repo.Update(ctx, in.ID, repo.UpdateInterventionOption{
+		Model:          itv,
+		ExpectedStatus: models.InterventionStatusOpen,
+		Status:         models.InterventionStatusClaimed,
+		ClaimedBy:      in.Operator,
+		ClaimedAt:      &now,
+	})
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			s.l.Warnf(ctx, "internal.intervention.service.Claim.repo.Update: %v", intervention.ErrInterventionConflict)
+			return models.Intervention{}, intervention.ErrInterventionConflict
+		}
+		s.l.Errorf(ctx, "internal.intervention.service.Claim.repo.Update: %v", err)
+		return models.Intervention{}, err
+	}
+
+	return itv, nil
+}
+
+// Resolve records the operator's decision and hands it to the waiting workflow.
+// The intervention is marked RESOLVED first so the same decision cannot be delivered twice.
+func (s *implService) Resolve(ctx context.Context, in intervention.ResolveInterventionInput) (models.Intervention, error) {
+	itv, err := s.getByID(ctx, in.ID)
+	if err != nil {
+		s.l.Errorf(ctx, "internal.intervention.service.Resolve.getByID: %v", err)
+		return models.Intervention{}, err
+	}
+
+	if itv.Status != models.InterventionStatusClaimed {
+		s.l.Warnf(ctx, "internal.intervention.service.Resolve: %v", intervention.ErrInterventionNotClaimed)
+		return models.Intervention{}, intervention.ErrInterventionNotClaimed
+	}
+
+	if itv.ClaimedBy != in.Operator {
+		s.l.Warnf(ctx, "internal.intervention.service.Resolve: %v", intervention.ErrInterventionClaimedByOther)
+		return models.Intervention{}, intervention.ErrInterventionClaimedByOther
+	}
+
+	claimed := itv
+	itv, err = s.repo.Update(ctx, in.ID, repo.UpdateInterventionOption{
+		Model:          itv,
+		ExpectedStatus: models.InterventionStatusClaimed,
+		Status:         models.InterventionStatusResolved,
+		Resolution: &repo.ResolveInterventionOption{
+			Action:     in.Action,
+			ResolvedBy: in.Operator,
+			ResolvedAt: s.clock(),
+			Note:       in.Note,
+		},
+	})
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			s.l.Warnf(ctx, "internal.intervention.service.Resolve.repo.Update: %v", intervention.ErrInterventionConflict)
+			return models.Intervention{}, intervention.ErrInterventionConflict
+		}
+		s.l.Errorf(ctx, "internal.intervention.service.Resolve.repo.Update: %v", err)
+		return models.Intervention{}, err
+	}
+
+	err = s.temporal.SignalWorkflow(ctx, itv.WorkflowID, itv.RunID,
+		workflows.SignalNameInterventionResolved, workflows.InterventionResolvedSignal{
+			InterventionID: itv.ID.Hex(),
+			Action:         itv.Action,
+			Operator:       itv.ResolvedBy,
+			Note:           itv.Note,
+		})
+	if err != nil {
+		s.l.Errorf(ctx, "internal.intervention.service.Resolve.temporal.SignalWorkflow: %v", err)
+
+		// Hand the task back to the operator so the decision can be sent again
+		if _, rbErr := s.repo.Update(ctx, in.ID, repo.UpdateInterventionOption{
+			Model:           claimed,
+			ExpectedStatus:  models.InterventionStatusResolved,
+			Status:          models.InterventionStatusClaimed,
+			ClearResolution: true,
+		}); rbErr != nil {
+			s.l.Errorf(ctx, "internal.intervention.service.Resolve.repo.Update: %v", rbErr)
+		}
+
+		return models.Intervention{}, intervention.ErrInterventionSignalFailed
+	}
+
+	return itv, nil
+}
+
+func (s *implService) getByID(ctx context.Context, ID string) (models.Intervention, error) {
+	itv, err := s.repo.GetByID(ctx, ID)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return models.Intervention{}, intervention.ErrInterventionNotFound
+		}
+		return models.Intervention{}, err
+	}
+
+	return itv, nil
+}
@@ -0,0 +1,26 @@
+package service
+
+import (
+	"time"
+
+	"github.com/vogiaan1904/ticketbottle-order/internal/intervention"
+	repo "github.com/vogiaan1904/ticketbottle-order/internal/intervention/repository"
+	"github.com/vogiaan1904/ticketbottle-order/pkg/logger"
+	temporalCli "go.temporal.io/sdk/client"
+)
+
+type implService struct {
+	l        logger.Logger
+	repo     repo.Repository
+	temporal temporalCli.Client
+	clock    func() time.Time
+}
+
+func New(l logger.Logger, repo repo.Repository, tprCli temporalCli.Client) intervention.Service {
+	return &implService{
+		l:        l,
+		repo:     repo,
+		temporal: tprCli,
+		clock:    time.Now,
+	}
+}
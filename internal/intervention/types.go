@@ -0,0 +1,36 @@
+package intervention
+
+import "github.com/vogiaan1904/ticketbottle-order/internal/models"
+
+type FilterIntervention struct {
+	OrderCode string
+	RunID     string
+	Step      models.InterventionStep
+	Status    *models.InterventionStatus
+}
+
+type ListInterventionInput struct {
+	FilterIntervention
+	// Cursor continues a previous listing, empty starts from the oldest intervention
+	Cursor string
+	// Limit caps the page size, zero or anything above paginator.MaxLimit is paginator.MaxLimit
+	Limit int64
+}
+
+type ListInterventionOutput struct {
+	Interventions []models.Intervention
+	// Next is the cursor of the following page, empty on the last page
+	Next string
+}
+
+type ClaimInterventionInput struct {
+	ID       string
+	Operator string
+}
+
+type ResolveInterventionInput struct {
+	ID       string
+	Operator string
+	Action   models.InterventionAction
+	Note     string
+}
@@ -0,0 +1,17 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/vogiaan1904/ticketbottle-order/pkg/mongo"
+	"github.com/vogiaan1904/ticketbottle-order/pkg/mongo/migrate"
+)
+
+// Order updates filter on the version they read, orders written before it existed start at 0
+func upOrderVersion(ctx context.Context, db mongo.Database) error {
+	return migrate.BackfillField(ctx, db.Collection(orderCollection), "version", int64(0))
+}
+
+func downOrderVersion(ctx context.Context, db mongo.Database) error {
+	return migrate.UnsetField(ctx, db.Collection(orderCollection), "version")
+}
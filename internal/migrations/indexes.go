@@ -0,0 +1,190 @@
+package migrations
+
+import (
+	"context"
+	"time"
+
+	"github.com/vogiaan1904/ticketbottle-order/internal/models"
+	"github.com/vogiaan1904/ticketbottle-order/pkg/mongo"
+	"github.com/vogiaan1904/ticketbottle-order/pkg/mongo/migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	mongoDriver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Live documents have no deleted_at at all, which a partial filter cannot select. Queries match
+// deleted_at null, so the indexes they use carry deleted_at as an equality key right after the
+// filtered fields instead, and partial filters are kept for optional fields.
+
+// newestFirst is the order of order listings, see the order repository
+var newestFirst = bson.D{
+	{Key: "created_at", Value: -1},
+	{Key: "_id", Value: -1},
+}
+
+func keys(fields bson.D, sort bson.D) bson.D {
+	return append(append(bson.D{}, fields...), sort...)
+}
+
+func upOrderIndexes(ctx context.Context, db mongo.Database) error {
+	err := migrate.CreateIndexes(ctx, db.Collection(orderCollection),
+		// Order codes are never reused, soft-deleted orders keep theirs
+		mongoDriver.IndexModel{
+			Keys:    bson.D{{Key: "code", Value: 1}},
+			Options: options.Index().SetName("code_unique").SetUnique(true),
+		},
+		// Only orders of a waitroom session have one
+		mongoDriver.IndexModel{
+			Keys: bson.D{
+				{Key: "session_id", Value: 1},
+				{Key: "deleted_at", Value: 1},
+			},
+			Options: options.Index().SetName("session_id_live").
+				SetPartialFilterExpression(bson.M{"session_id": bson.M{"$exists": true}}),
+		},
+		mongoDriver.IndexModel{
+			Keys:    keys(bson.D{{Key: "user_id", Value: 1}, {Key: "deleted_at", Value: 1}}, newestFirst),
+			Options: options.Index().SetName("user_id_live_newest"),
+		},
+		mongoDriver.IndexModel{
+			Keys:    keys(bson.D{{Key: "event_id", Value: 1}, {Key: "deleted_at", Value: 1}}, newestFirst),
+			Options: options.Index().SetName("event_id_live_newest"),
+		},
+		mongoDriver.IndexModel{
+			Keys:    keys(bson.D{{Key: "status", Value: 1}, {Key: "deleted_at", Value: 1}}, newestFirst),
+			Options: options.Index().SetName("status_live_newest"),
+		},
+		mongoDriver.IndexModel{
+			Keys:    keys(bson.D{{Key: "deleted_at", Value: 1}}, newestFirst),
+			Options: options.Index().SetName("live_newest"),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	err = migrate.CreateIndexes(ctx, db.Collection(orderItemCollection),
+		mongoDriver.IndexModel{
+			Keys:    bson.D{{Key: "order_id", Value: 1}},
+			Options: options.Index().SetName("order_id"),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	return migrate.CreateIndexes(ctx, db.Collection(orderRefundCollection),
+		mongoDriver.IndexModel{
+			Keys: bson.D{
+				{Key: "order_id", Value: 1},
+				{Key: "deleted_at", Value: 1},
+				{Key: "created_at", Value: 1},
+			},
+			Options: options.Index().SetName("order_id_live_oldest"),
+		},
+	)
+}
+
+func downOrderIndexes(ctx context.Context, db mongo.Database) error {
+	err := migrate.DropIndexes(ctx, db.Collection(orderCollection),
+		"code_unique", "session_id_live", "user_id_live_newest", "event_id_live_newest", "status_live_newest", "live_newest")
+	if err != nil {
+		return err
+	}
+
+	if err := migrate.DropIndexes(ctx, db.Collection(orderItemCollection), "order_id"); err != nil {
+		return err
+	}
+
+	return migrate.DropIndexes(ctx, db.Collection(orderRefundCollection), "order_id_live_oldest")
+}
+
+func upInterventionIndexes(ctx context.Context, db mongo.Database) error {
+	return migrate.CreateIndexes(ctx, db.Collection(interventionCollection),
+		mongoDriver.IndexModel{
+			Keys: bson.D{
+				{Key: "order_code", Value: 1},
+				{Key: "deleted_at", Value: 1},
+				{Key: "created_at", Value: 1},
+				{Key: "_id", Value: 1},
+			},
+			Options: options.Index().SetName("order_code_live_oldest"),
+		},
+		mongoDriver.IndexModel{
+			Keys: bson.D{
+				{Key: "status", Value: 1},
+				{Key: "deleted_at", Value: 1},
+				{Key: "created_at", Value: 1},
+				{Key: "_id", Value: 1},
+			},
+			Options: options.Index().SetName("status_live_oldest"),
+		},
+	)
+}
+
+func downInterventionIndexes(ctx context.Context, db mongo.Database) error {
+	return migrate.DropIndexes(ctx, db.Collection(interventionCollection),
+		"order_code_live_oldest", "status_live_oldest")
+}
+
+func upMessagingIndexes(ctx context.Context, db mongo.Database) error {
+	// The relay only reads pending messages, sent ones stay out of the index
+	err := migrate.CreateIndexes(ctx, db.Collection(outboxCollection),
+		mongoDriver.IndexModel{
+			Keys: bson.D{
+				{Key: "created_at", Value: 1},
+				{Key: "_id", Value: 1},
+			},
+			Options: options.Index().SetName("pending_oldest").
+				SetPartialFilterExpression(bson.M{"status": models.OutboxStatusPending}),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	// Expired records are removed by the TTL monitor. The index keeps the default name it had when
+	// the consumer created it on start, so existing deployments find it in place.
+	return migrate.CreateIndexes(ctx, db.Collection(processedEventCollection),
+		mongoDriver.IndexModel{
+			Keys:    bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().SetName("expires_at_1").SetExpireAfterSeconds(0),
+		},
+	)
+}
+
+func downMessagingIndexes(ctx context.Context, db mongo.Database) error {
+	if err := migrate.DropIndexes(ctx, db.Collection(outboxCollection), "pending_oldest"); err != nil {
+		return err
+	}
+
+	return migrate.DropIndexes(ctx, db.Collection(processedEventCollection), "expires_at_1")
+}
+
+// outboxSentRetention is how long sent outbox messages are kept for inspection
+const outboxSentRetention = 7 * 24 * time.Hour
+
+func upOutboxRetryIndexes(ctx context.Context, db mongo.Database) error {
+	return migrate.CreateIndexes(ctx, db.Collection(outboxCollection),
+		// The relay looks up the keys whose oldest message waits for a retry
+		mongoDriver.IndexModel{
+			Keys: bson.D{
+				{Key: "next_attempt_at", Value: 1},
+				{Key: "key", Value: 1},
+			},
+			Options: options.Index().SetName("pending_next_attempt").
+				SetPartialFilterExpression(bson.M{"status": models.OutboxStatusPending}),
+		},
+		// Sent messages are removed by the TTL monitor, dead ones stay until an operator handles them
+		mongoDriver.IndexModel{
+			Keys: bson.D{{Key: "sent_at", Value: 1}},
+			Options: options.Index().SetName("sent_ttl").
+				SetExpireAfterSeconds(int32(outboxSentRetention.Seconds())).
+				SetPartialFilterExpression(bson.M{"status": models.OutboxStatusSent}),
+		},
+	)
+}
+
+func downOutboxRetryIndexes(ctx context.Context, db mongo.Database) error {
+	return migrate.DropIndexes(ctx, db.Collection(outboxCollection), "pending_next_attempt", "sent_ttl")
+}
@@ -0,0 +1,78 @@
+// Package migrations lists the schema migrations of the order service. Add a migration with the next
+// version to All, never change or renumber one that was released.
+package migrations
+
+import (
+	"context"
+
+	"github.com/vogiaan1904/ticketbottle-order/pkg/logger"
+	"github.com/vogiaan1904/ticketbottle-order/pkg/mongo"
+	"github.com/vogiaan1904/ticketbottle-order/pkg/mongo/migrate"
+)
+
+// Collections of the service, they mirror the collection names of the repositories
+const (
+	orderCollection          = "orders"
+	orderItemCollection      = "order_items"
+	orderRefundCollection    = "order_refunds"
+	interventionCollection   = "interventions"
+	outboxCollection         = "outbox"
+	processedEventCollection = "processed_events"
+)
+
+func All() []migrate.Migration {
+	return []migrate.Migration{
+		{
+			Version:     1,
+			Description: "Create order, order item and order refund indexes",
+			Up:          upOrderIndexes,
+			Down:        downOrderIndexes,
+		},
+		{
+			Version:     2,
+			Description: "Create intervention indexes",
+			Up:          upInterventionIndexes,
+			Down:        downInterventionIndexes,
+		},
+		{
+			Version:     3,
+			Description: "Create outbox and processed event indexes",
+			Up:          upMessagingIndexes,
+			Down:        downMessagingIndexes,
+		},
+		{
+			Version:     4,
+			Description: "Backfill the order version used by optimistic concurrency",
+			Up:          upOrderVersion,
+			Down:        downOrderVersion,
+		},
+		{
+			Version:     5,
+			Description: "Create outbox retry and sent message retention indexes",
+			Up:          upOutboxRetryIndexes,
+			Down:        downOutboxRetryIndexes,
+		},
+	}
+}
+
+func NewMigrator(l logger.Logger, db mongo.Database) (*migrate.Migrator, error) {
+	return migrate.New(l, db, All())
+}
+
+// Up applies every pending migration, the services run it on start
+func Up(ctx context.Context, l logger.Logger, db mongo.Database) error {
+	m, err := NewMigrator(l, db)
+	if err != nil {
+		return err
+	}
+
+	n, err := m.Up(ctx, 0)
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		l.Infof(ctx, "Applied %d migration(s), schema at version %d", n, m.Latest())
+	}
+
+	return nil
+}
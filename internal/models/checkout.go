@@ -0,0 +1,31 @@
+package models
+
+type CheckoutState string
+
+const (
+	CheckoutStateCreating          CheckoutState = "CREATING"
+	CheckoutStateAwaitingPayment   CheckoutState = "AWAITING_PAYMENT"
+	CheckoutStateProcessingPayment CheckoutState = "PROCESSING_PAYMENT"
+	CheckoutStateCompleted         CheckoutState = "COMPLETED"
+	CheckoutStateFailed            CheckoutState = "FAILED"
+)
+
+// CheckoutStatus is the progress of a checkout, as reported by its CreateOrder workflow
+type CheckoutStatus struct {
+	OrderCode           string
+	State               CheckoutState
+	AvailabilityChecked bool
+	OrderPersisted      bool
+	Reserved            bool
+	PaymentUrlReady     bool
+	PaymentUrl          string
+	Order               *Order
+	OrderItems          []OrderItem
+	// Error explains why the checkout failed
+	Error string
+}
+
+// IsFinal reports whether the checkout will not make any more progress
+func (s CheckoutStatus) IsFinal() bool {
+	return s.State == CheckoutStateCompleted || s.State == CheckoutStateFailed
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Intervention is a task for an operator, created when a workflow cannot make progress on its own
+type Intervention struct {
+	ID         primitive.ObjectID `bson:"_id"`
+	OrderCode  string             `bson:"order_code"`
+	Step       InterventionStep   `bson:"step"`
+	Error      string             `bson:"error"`
+	WorkflowID string             `bson:"workflow_id"`
+	RunID      string             `bson:"run_id"`
+	Status     InterventionStatus `bson:"status"`
+	ClaimedBy  string             `bson:"claimed_by,omitempty"`
+	ClaimedAt  *time.Time         `bson:"claimed_at,omitempty"`
+	Action     InterventionAction `bson:"action,omitempty"`
+	ResolvedBy string             `bson:"resolved_by,omitempty"`
+	ResolvedAt *time.Time         `bson:"resolved_at,omitempty"`
+	Note       string             `bson:"note,omitempty"`
+	CreatedAt  time.Time          `bson:"created_at"`
+	UpdatedAt  time.Time          `bson:"updated_at"`
+	DeletedAt  *time.Time         `bson:"deleted_at,omitempty"`
+}
+
+type InterventionStep string
+
+const (
+	InterventionStepConfirmInventory InterventionStep = "CONFIRM_INVENTORY"
+	InterventionStepCompleteOrder    InterventionStep = "COMPLETE_ORDER"
+	// InterventionStepVerifyPayment is raised when the payment does not match the order, retrying
+	// accepts the payment as it is
+	InterventionStepVerifyPayment InterventionStep = "VERIFY_PAYMENT"
+)
+
+type InterventionStatus string
+
+const (
+	InterventionStatusOpen     InterventionStatus = "OPEN"
+	InterventionStatusClaimed  InterventionStatus = "CLAIMED"
+	InterventionStatusResolved InterventionStatus = "RESOLVED"
+)
+
+type InterventionAction string
+
+const (
+	// InterventionActionRetry runs the failed step again
+	InterventionActionRetry InterventionAction = "RETRY"
+	// InterventionActionRefund gives the money back and fails the order
+	InterventionActionRefund InterventionAction = "REFUND"
+)
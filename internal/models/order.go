@@ -1,28 +1,52 @@
 package models
 
 import (
+	"slices"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type Order struct {
-	ID            primitive.ObjectID `bson:"_id"`
-	SessionID     string             `bson:"session_id,omitempty"`
-	Code          string             `bson:"code"`
-	UserID        string             `bson:"user_id"`
-	UserFullName  string             `bson:"user_full_name"`
-	Email         string             `bson:"email"`
-	Phone         string             `bson:"phone"`
-	EventID       string             `bson:"event_id"`
-	TotalAmount   int64              `bson:"total_amount"`
-	Currency      string             `bson:"currency"`
-	PaymentMethod PaymentMethod      `bson:"payment_method"`
-	Status        OrderStatus        `bson:"status"`
-	PaidAt        *time.Time         `bson:"paid_at,omitempty"`
-	CreatedAt     time.Time          `bson:"created_at"`
-	UpdatedAt     time.Time          `bson:"updated_at"`
-	DeletedAt     *time.Time         `bson:"deleted_at,omitempty"`
+	ID             primitive.ObjectID  `bson:"_id"`
+	SessionID      string              `bson:"session_id,omitempty"`
+	Code           string              `bson:"code"`
+	UserID         string              `bson:"user_id"`
+	UserFullName   string              `bson:"user_full_name"`
+	Email          string              `bson:"email"`
+	Phone          string              `bson:"phone"`
+	EventID        string              `bson:"event_id"`
+	TotalAmount    int64               `bson:"total_amount"`
+	Currency       string              `bson:"currency"`
+	PaymentMethod  PaymentMethod       `bson:"payment_method"`
+	PaymentID      string              `bson:"payment_id,omitempty"`
+	TransactionID  string              `bson:"transaction_id,omitempty"`
+	Status         OrderStatus         `bson:"status"`
+	PaidAt         *time.Time          `bson:"paid_at,omitempty"`
+	RefundedAmount int64               `bson:"refunded_amount,omitempty"`
+	RefundReason   string              `bson:"refund_reason,omitempty"`
+	RefundedAt     *time.Time          `bson:"refunded_at,omitempty"`
+	FailureReason  string              `bson:"failure_reason,omitempty"`
+	Version        int64               `bson:"version"`
+	StatusHistory  []OrderStatusChange `bson:"status_history,omitempty"`
+	CreatedAt      time.Time           `bson:"created_at"`
+	UpdatedAt      time.Time           `bson:"updated_at"`
+	DeletedAt      *time.Time          `bson:"deleted_at,omitempty"`
+}
+
+// NetAmount is the amount the customer paid minus everything refunded so far
+func (o Order) NetAmount() int64 {
+	return o.TotalAmount - o.RefundedAmount
+}
+
+// IsClosedUnpaid tells whether the order was closed before it was paid, its tickets are back in inventory
+func (o Order) IsClosedUnpaid() bool {
+	switch o.Status {
+	case OrderStatusCancelled, OrderStatusPaymentFailed, OrderStatusTimeout:
+		return true
+	default:
+		return false
+	}
 }
 
 type OrderStatus string
@@ -34,8 +58,42 @@ const (
 	OrderStatusCancelled     OrderStatus = "CANCELLED"
 	OrderStatusPaymentFailed OrderStatus = "PAYMENT_FAILED"
 	OrderStatusRefunded      OrderStatus = "REFUNDED"
+	// OrderStatusPaymentReview holds a paid order whose payment does not match it until an operator decides
+	OrderStatusPaymentReview     OrderStatus = "PAYMENT_REVIEW"
+	OrderStatusPartiallyRefunded OrderStatus = "PARTIALLY_REFUNDED"
 )
 
+// orderTransitions lists the statuses an order may move to from each status, statuses without an
+// entry are final. A paid order that cannot be confirmed is refunded from PENDING or PAYMENT_REVIEW.
+// Refunds only move forward, an order is refunded once the payment refund succeeded.
+var orderTransitions = map[OrderStatus][]OrderStatus{
+	OrderStatusPending: {
+		OrderStatusCompleted,
+		OrderStatusCancelled,
+		OrderStatusPaymentFailed,
+		OrderStatusTimeout,
+		OrderStatusPaymentReview,
+		OrderStatusRefunded,
+	},
+	OrderStatusPaymentReview:     {OrderStatusCompleted, OrderStatusRefunded},
+	OrderStatusCompleted:         {OrderStatusPartiallyRefunded, OrderStatusRefunded},
+	OrderStatusPartiallyRefunded: {OrderStatusRefunded},
+}
+
+// CanTransitionTo tells whether an order may move from s to to. Staying in the same status is always
+// allowed, that is how updates of other fields and retries of a committed transition go through.
+func (s OrderStatus) CanTransitionTo(to OrderStatus) bool {
+	if s == to {
+		return true
+	}
+	return slices.Contains(orderTransitions[s], to)
+}
+
+// IsFinal tells whether an order in s can no longer change status
+func (s OrderStatus) IsFinal() bool {
+	return len(orderTransitions[s]) == 0
+}
+
 type PaymentMethod string
 
 const (
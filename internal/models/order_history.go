@@ -0,0 +1,56 @@
+package models
+
+import "time"
+
+// OrderStatusChange is one entry of the status history of an order, written together with the
+// status it records
+type OrderStatusChange struct {
+	// From is empty for the status the order was created in
+	From       OrderStatus `bson:"from,omitempty"`
+	To         OrderStatus `bson:"to"`
+	Actor      OrderActor  `bson:"actor"`
+	WorkflowID string      `bson:"workflow_id,omitempty"`
+	Reason     string      `bson:"reason,omitempty"`
+	ChangedAt  time.Time   `bson:"changed_at"`
+}
+
+// OrderActor is who asked for a status change and through which entry point the request came in
+type OrderActor struct {
+	Type OrderActorType `bson:"type"`
+	// ID is the user or operator, empty for the system
+	ID     string            `bson:"id,omitempty"`
+	Source OrderChangeSource `bson:"source,omitempty"`
+}
+
+type OrderActorType string
+
+const (
+	OrderActorTypeUser     OrderActorType = "USER"
+	OrderActorTypeOperator OrderActorType = "OPERATOR"
+	OrderActorTypeSystem   OrderActorType = "SYSTEM"
+)
+
+type OrderChangeSource string
+
+const (
+	OrderChangeSourceGRPC  OrderChangeSource = "GRPC"
+	OrderChangeSourceKafka OrderChangeSource = "KAFKA"
+	// OrderChangeSourceWorkflow is a change a workflow makes on its own, such as a payment timeout
+	OrderChangeSourceWorkflow OrderChangeSource = "WORKFLOW"
+)
+
+func UserActor(ID string, src OrderChangeSource) OrderActor {
+	return OrderActor{Type: OrderActorTypeUser, ID: ID, Source: src}
+}
+
+func OperatorActor(ID string, src OrderChangeSource) OrderActor {
+	return OrderActor{Type: OrderActorTypeOperator, ID: ID, Source: src}
+}
+
+func SystemActor(src OrderChangeSource) OrderActor {
+	return OrderActor{Type: OrderActorTypeSystem, Source: src}
+}
+
+func (a OrderActor) IsZero() bool {
+	return a.Type == ""
+}
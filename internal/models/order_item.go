@@ -7,14 +7,42 @@ import (
 )
 
 type OrderItem struct {
-	ID              primitive.ObjectID `bson:"_id"`
-	OrderID         primitive.ObjectID `bson:"order_id"`
-	TicketClassID   string             `bson:"ticket_class_id"`
-	TicketClassName string             `bson:"ticket_class_name"`
-	PriceAtPurchase int64              `bson:"price_at_purchase"`
-	Quantity        int32              `bson:"quantity"`
-	TotalAmount     int64              `bson:"total_amount"`
-	CreatedAt       time.Time          `bson:"created_at"`
-	UpdatedAt       time.Time          `bson:"updated_at"`
-	DeletedAt       *time.Time         `bson:"deleted_at,omitempty"`
+	ID               primitive.ObjectID `bson:"_id"`
+	OrderID          primitive.ObjectID `bson:"order_id"`
+	TicketClassID    string             `bson:"ticket_class_id"`
+	TicketClassName  string             `bson:"ticket_class_name"`
+	PriceAtPurchase  int64              `bson:"price_at_purchase"`
+	Quantity         int32              `bson:"quantity"`
+	TotalAmount      int64              `bson:"total_amount"`
+	RefundedQuantity int32              `bson:"refunded_quantity"`
+	CreatedAt        time.Time          `bson:"created_at"`
+	UpdatedAt        time.Time          `bson:"updated_at"`
+	DeletedAt        *time.Time         `bson:"deleted_at,omitempty"`
+}
+
+// RefundableQuantity is the quantity of the item that has not been refunded yet
+func (itm OrderItem) RefundableQuantity() int32 {
+	return itm.Quantity - itm.RefundedQuantity
+}
+
+// RefundStatus derives the status of a paid order from the refunded quantity of its items
+func RefundStatus(itms []OrderItem) OrderStatus {
+	refunded, remaining := false, false
+	for _, itm := range itms {
+		if itm.RefundedQuantity > 0 {
+			refunded = true
+		}
+		if itm.RefundableQuantity() > 0 {
+			remaining = true
+		}
+	}
+
+	switch {
+	case refunded && remaining:
+		return OrderStatusPartiallyRefunded
+	case refunded:
+		return OrderStatusRefunded
+	default:
+		return OrderStatusCompleted
+	}
 }
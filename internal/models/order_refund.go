@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// OrderRefund is one entry of the refund ledger of an order, a single order may be refunded several times
+type OrderRefund struct {
+	ID              primitive.ObjectID `bson:"_id"`
+	OrderID         primitive.ObjectID `bson:"order_id"`
+	OrderCode       string             `bson:"order_code"`
+	PaymentRefundID string             `bson:"payment_refund_id,omitempty"`
+	Items           []OrderRefundItem  `bson:"items"`
+	Amount          int64              `bson:"amount"`
+	Reason          string             `bson:"reason"`
+	IdempotencyKey  string             `bson:"idempotency_key"`
+	Status          OrderRefundStatus  `bson:"status"`
+	CreatedAt       time.Time          `bson:"created_at"`
+	UpdatedAt       time.Time          `bson:"updated_at"`
+	DeletedAt       *time.Time         `bson:"deleted_at,omitempty"`
+}
+
+type OrderRefundItem struct {
+	OrderItemID   primitive.ObjectID `bson:"order_item_id"`
+	TicketClassID string             `bson:"ticket_class_id"`
+	Quantity      int32              `bson:"quantity"`
+	Amount        int64              `bson:"amount"`
+}
+
+type OrderRefundStatus string
+
+const (
+	OrderRefundStatusPending   OrderRefundStatus = "PENDING"
+	OrderRefundStatusSucceeded OrderRefundStatus = "SUCCEEDED"
+	OrderRefundStatusFailed    OrderRefundStatus = "FAILED"
+)
@@ -0,0 +1,74 @@
+package models
+
+import "testing"
+
+func TestOrderStatusCanTransitionTo(t *testing.T) {
+	tcs := []struct {
+		from OrderStatus
+		to   OrderStatus
+		want bool
+	}{
+		{OrderStatusPending, OrderStatusPending, true},
+		{OrderStatusPending, OrderStatusCompleted, true},
+		{OrderStatusPending, OrderStatusCancelled, true},
+		{OrderStatusPending, OrderStatusPaymentFailed, true},
+		{OrderStatusPending, OrderStatusTimeout, true},
+		{OrderStatusPending, OrderStatusPaymentReview, true},
+		{OrderStatusPending, OrderStatusRefunded, true},
+		{OrderStatusPending, OrderStatusPartiallyRefunded, false},
+
+		{OrderStatusPaymentReview, OrderStatusCompleted, true},
+		{OrderStatusPaymentReview, OrderStatusRefunded, true},
+		{OrderStatusPaymentReview, OrderStatusPending, false},
+		{OrderStatusPaymentReview, OrderStatusCancelled, false},
+		{OrderStatusPaymentReview, OrderStatusPartiallyRefunded, false},
+
+		{OrderStatusCompleted, OrderStatusCompleted, true},
+		{OrderStatusCompleted, OrderStatusPartiallyRefunded, true},
+		{OrderStatusCompleted, OrderStatusRefunded, true},
+		{OrderStatusCompleted, OrderStatusPending, false},
+		{OrderStatusCompleted, OrderStatusCancelled, false},
+		{OrderStatusCompleted, OrderStatusPaymentFailed, false},
+
+		{OrderStatusPartiallyRefunded, OrderStatusPartiallyRefunded, true},
+		{OrderStatusPartiallyRefunded, OrderStatusRefunded, true},
+		{OrderStatusPartiallyRefunded, OrderStatusCompleted, false},
+
+		{OrderStatusRefunded, OrderStatusRefunded, true},
+		{OrderStatusRefunded, OrderStatusPartiallyRefunded, false},
+		{OrderStatusRefunded, OrderStatusCompleted, false},
+		{OrderStatusCancelled, OrderStatusCompleted, false},
+		{OrderStatusCancelled, OrderStatusPending, false},
+		{OrderStatusPaymentFailed, OrderStatusCompleted, false},
+		{OrderStatusTimeout, OrderStatusCompleted, false},
+		{OrderStatusTimeout, OrderStatusRefunded, false},
+	}
+
+	for _, tc := range tcs {
+		if got := tc.from.CanTransitionTo(tc.to); got != tc.want {
+			t.Errorf("%s -> %s allowed %t, want %t", tc.from, tc.to, got, tc.want)
+		}
+	}
+}
+
+func TestOrderStatusIsFinal(t *testing.T) {
+	tcs := []struct {
+		status OrderStatus
+		want   bool
+	}{
+		{OrderStatusPending, false},
+		{OrderStatusPaymentReview, false},
+		{OrderStatusCompleted, false},
+		{OrderStatusPartiallyRefunded, false},
+		{OrderStatusRefunded, true},
+		{OrderStatusCancelled, true},
+		{OrderStatusPaymentFailed, true},
+		{OrderStatusTimeout, true},
+	}
+
+	for _, tc := range tcs {
+		if got := tc.status.IsFinal(); got != tc.want {
+			t.Errorf("%s final %t, want %t", tc.status, got, tc.want)
+		}
+	}
+}
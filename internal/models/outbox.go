@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// OutboxMessage is a Kafka message written in the same Mongo transaction as the change it
+// announces, and published later by the outbox relay
+type OutboxMessage struct {
+	ID            primitive.ObjectID `bson:"_id"`
+	Topic         string             `bson:"topic"`
+	Key           string             `bson:"key"`
+	Payload       []byte             `bson:"payload"`
+	Headers       map[string]string  `bson:"headers,omitempty"`
+	Status        OutboxStatus       `bson:"status"`
+	Attempts      int                `bson:"attempts"`
+	LastError     string             `bson:"last_error,omitempty"`
+	NextAttemptAt time.Time          `bson:"next_attempt_at"`
+	SentAt        *time.Time         `bson:"sent_at,omitempty"`
+	CreatedAt     time.Time          `bson:"created_at"`
+	UpdatedAt     time.Time          `bson:"updated_at"`
+}
+
+type OutboxStatus string
+
+const (
+	OutboxStatusPending OutboxStatus = "PENDING"
+	OutboxStatusSent    OutboxStatus = "SENT"
+	// OutboxStatusDead is a message the relay gave up on, it stays in the outbox with its last error
+	// until an operator sets it back to PENDING
+	OutboxStatusDead OutboxStatus = "DEAD"
+)
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// ProcessedEvent records that a consumed event was handled, so a redelivery is acknowledged
+// without running it again
+type ProcessedEvent struct {
+	// ID is the dedupe key of the event, such as its type and payment ID
+	ID     string               `bson:"_id"`
+	Status ProcessedEventStatus `bson:"status"`
+	// LockedUntil is when a PROCESSING claim is considered abandoned and may be taken over
+	LockedUntil time.Time `bson:"locked_until"`
+	// ExpiresAt is when the record is removed by the TTL index
+	ExpiresAt time.Time `bson:"expires_at"`
+	CreatedAt time.Time `bson:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at"`
+}
+
+type ProcessedEventStatus string
+
+const (
+	ProcessedEventStatusProcessing ProcessedEventStatus = "PROCESSING"
+	ProcessedEventStatusDone       ProcessedEventStatus = "DONE"
+)
@@ -1,10 +1,37 @@
 package order
 
+import "time"
+
 type HandlePaymentCompletedInput struct {
 	OrderCode string
+	// PaymentID and TransactionID identify the event for deduplication
+	PaymentID     string
+	TransactionID string
+	// AmountCents, Currency and Provider are checked against the order before it is confirmed
+	AmountCents int64
+	Currency    string
+	Provider    string
+	PaidAt      time.Time
+	// Topic and EventType label the dedupe metrics
+	Topic     string
+	EventType string
+}
+
+type HandleEventCancelledInput struct {
+	EventID string
+	Reason  string
+}
+
+type HandleSessionExpiredInput struct {
+	SessionID string
 }
 
 type HandlePaymentFailedInput struct {
-	OrderCode string
-	Reason    string
+	OrderCode     string
+	Reason        string
+	PaymentID     string
+	TransactionID string
+	// Topic and EventType label the dedupe metrics
+	Topic     string
+	EventType string
 }
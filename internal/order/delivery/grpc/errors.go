@@ -3,19 +3,30 @@ package grpc
 import (
 	"github.com/vogiaan1904/ticketbottle-order/internal/order"
 	pkgErrors "github.com/vogiaan1904/ticketbottle-order/pkg/errors"
+	"google.golang.org/grpc/codes"
 )
 
 var (
 	ErrValidationFailed = pkgErrors.NewGRPCError("ORD400", "Validation failed")
 	// Order errors
-	ErrGRPCOrderNotFound           = pkgErrors.NewGRPCError("ORD001", "Order not found")
-	ErrGRPCOrderAlreadyExists      = pkgErrors.NewGRPCError("ORD002", "Order already exists")
-	ErrGRPCInvalidOrderStatus      = pkgErrors.NewGRPCError("ORD003", "Invalid order status")
-	ErrGRPCOrderCreationFailed     = pkgErrors.NewGRPCError("ORD004", "Order creation failed")
-	ErrGRPCOrderUpdateFailed       = pkgErrors.NewGRPCError("ORD005", "Order update failed")
-	ErrGRPCOrderCancellationFailed = pkgErrors.NewGRPCError("ORD006", "Order cancellation failed")
-	ErrGRPCOrderNotPending         = pkgErrors.NewGRPCError("ORD007", "Order is not in pending status")
-	ErrGRPCPaymentAmountMismatch   = pkgErrors.NewGRPCError("ORD008", "Payment amount does not match order amount")
+	ErrGRPCOrderNotFound               = pkgErrors.NewGRPCError("ORD001", "Order not found")
+	ErrGRPCOrderAlreadyExists          = pkgErrors.NewGRPCError("ORD002", "Order already exists")
+	ErrGRPCInvalidOrderStatus          = pkgErrors.NewGRPCError("ORD003", "Invalid order status")
+	ErrGRPCOrderCreationFailed         = pkgErrors.NewGRPCError("ORD004", "Order creation failed")
+	ErrGRPCOrderUpdateFailed           = pkgErrors.NewGRPCError("ORD005", "Order update failed")
+	ErrGRPCOrderCancellationFailed     = pkgErrors.NewGRPCError("ORD006", "Order cancellation failed")
+	ErrGRPCOrderNotPending             = pkgErrors.NewGRPCError("ORD007", "Order is not in pending status")
+	ErrGRPCPaymentAmountMismatch       = pkgErrors.NewGRPCError("ORD008", "Payment amount does not match order amount")
+	ErrGRPCOrderNotRefundable          = pkgErrors.NewGRPCError("ORD017", "Order is not refundable")
+	ErrGRPCOrderRefundFailed           = pkgErrors.NewGRPCError("ORD018", "Order refund failed")
+	ErrGRPCInvalidRefundItems          = pkgErrors.NewGRPCError("ORD019", "Invalid refund items")
+	ErrGRPCOrderAlreadyPaid            = pkgErrors.NewGRPCError("ORD020", "Order is already paid")
+	ErrGRPCOrderCancellationInProgress = pkgErrors.NewGRPCError("ORD021", "Order cancellation is in progress")
+	ErrGRPCOrderConflict               = pkgErrors.NewGRPCErrorWithCode("ORD022", "Order was changed concurrently, retry the request", codes.FailedPrecondition)
+	ErrGRPCInvalidStatusTransition     = pkgErrors.NewGRPCErrorWithCode("ORD023", "Order cannot move to the requested status", codes.FailedPrecondition)
+	ErrGRPCInvalidCursor               = pkgErrors.NewGRPCError("ORD024", "Invalid pagination cursor")
+	ErrGRPCRefundsDisabled             = pkgErrors.NewGRPCErrorWithCode("ORD025", "Refunds are not available yet", codes.Unimplemented)
+	ErrGRPCOrderNotOwned               = pkgErrors.NewGRPCErrorWithCode("ORD026", "Order does not belong to the user", codes.PermissionDenied)
 
 	// Event errors
 	ErrGRPCEventNotFound        = pkgErrors.NewGRPCError("ORD009", "Event not found")
@@ -43,8 +54,28 @@ func (s *grpcService) mapError(err error) error {
 		return ErrGRPCOrderUpdateFailed
 	case order.ErrOrderCancellationFailed:
 		return ErrGRPCOrderCancellationFailed
+	case order.ErrOrderCancellationInProgress:
+		return ErrGRPCOrderCancellationInProgress
 	case order.ErrOrderNotPending:
 		return ErrGRPCOrderNotPending
+	case order.ErrOrderNotOwned:
+		return ErrGRPCOrderNotOwned
+	case order.ErrOrderNotRefundable:
+		return ErrGRPCOrderNotRefundable
+	case order.ErrOrderRefundFailed:
+		return ErrGRPCOrderRefundFailed
+	case order.ErrRefundsDisabled:
+		return ErrGRPCRefundsDisabled
+	case order.ErrInvalidRefundItems:
+		return ErrGRPCInvalidRefundItems
+	case order.ErrOrderAlreadyPaid:
+		return ErrGRPCOrderAlreadyPaid
+	case order.ErrOrderConflict:
+		return ErrGRPCOrderConflict
+	case order.ErrInvalidStatusTransition:
+		return ErrGRPCInvalidStatusTransition
+	case order.ErrInvalidCursor:
+		return ErrGRPCInvalidCursor
 	case order.ErrPaymentAmountMismatch:
 		return ErrGRPCPaymentAmountMismatch
 	case order.ErrEventNotFound:
@@ -8,34 +8,74 @@ import (
 )
 
 var GrpcOrderStatusValue = map[models.OrderStatus]orderpb.OrderStatus{
-	models.OrderStatusPending:       orderpb.OrderStatus_ORDER_STATUS_PENDING,
-	models.OrderStatusCompleted:     orderpb.OrderStatus_ORDER_STATUS_COMPLETED,
-	models.OrderStatusCancelled:     orderpb.OrderStatus_ORDER_STATUS_CANCELED,
-	models.OrderStatusPaymentFailed: orderpb.OrderStatus_ORDER_STATUS_FAILED,
+	models.OrderStatusPending:           orderpb.OrderStatus_ORDER_STATUS_PENDING,
+	models.OrderStatusCompleted:         orderpb.OrderStatus_ORDER_STATUS_COMPLETED,
+	models.OrderStatusCancelled:         orderpb.OrderStatus_ORDER_STATUS_CANCELED,
+	models.OrderStatusPaymentFailed:     orderpb.OrderStatus_ORDER_STATUS_FAILED,
+	models.OrderStatusRefunded:          orderpb.OrderStatus_ORDER_STATUS_REFUNDED,
+	models.OrderStatusPartiallyRefunded: orderpb.OrderStatus_ORDER_STATUS_PARTIALLY_REFUNDED,
+	models.OrderStatusPaymentReview:     orderpb.OrderStatus_ORDER_STATUS_PAYMENT_REVIEW,
+	models.OrderStatusTimeout:           orderpb.OrderStatus_ORDER_STATUS_TIMEOUT,
 }
 
 var OrderStatus = map[orderpb.OrderStatus]models.OrderStatus{
-	orderpb.OrderStatus_ORDER_STATUS_PENDING:   models.OrderStatusPending,
-	orderpb.OrderStatus_ORDER_STATUS_COMPLETED: models.OrderStatusCompleted,
-	orderpb.OrderStatus_ORDER_STATUS_CANCELED:  models.OrderStatusCancelled,
-	orderpb.OrderStatus_ORDER_STATUS_FAILED:    models.OrderStatusPaymentFailed,
+	orderpb.OrderStatus_ORDER_STATUS_PENDING:            models.OrderStatusPending,
+	orderpb.OrderStatus_ORDER_STATUS_COMPLETED:          models.OrderStatusCompleted,
+	orderpb.OrderStatus_ORDER_STATUS_CANCELED:           models.OrderStatusCancelled,
+	orderpb.OrderStatus_ORDER_STATUS_FAILED:             models.OrderStatusPaymentFailed,
+	orderpb.OrderStatus_ORDER_STATUS_REFUNDED:           models.OrderStatusRefunded,
+	orderpb.OrderStatus_ORDER_STATUS_PARTIALLY_REFUNDED: models.OrderStatusPartiallyRefunded,
+	orderpb.OrderStatus_ORDER_STATUS_PAYMENT_REVIEW:     models.OrderStatusPaymentReview,
+	orderpb.OrderStatus_ORDER_STATUS_TIMEOUT:            models.OrderStatusTimeout,
+}
+
+var GrpcOrderActorTypeValue = map[models.OrderActorType]orderpb.OrderActorType{
+	models.OrderActorTypeUser:     orderpb.OrderActorType_ORDER_ACTOR_TYPE_USER,
+	models.OrderActorTypeOperator: orderpb.OrderActorType_ORDER_ACTOR_TYPE_OPERATOR,
+	models.OrderActorTypeSystem:   orderpb.OrderActorType_ORDER_ACTOR_TYPE_SYSTEM,
+}
+
+var GrpcOrderChangeSourceValue = map[models.OrderChangeSource]orderpb.OrderChangeSource{
+	models.OrderChangeSourceGRPC:     orderpb.OrderChangeSource_ORDER_CHANGE_SOURCE_GRPC,
+	models.OrderChangeSourceKafka:    orderpb.OrderChangeSource_ORDER_CHANGE_SOURCE_KAFKA,
+	models.OrderChangeSourceWorkflow: orderpb.OrderChangeSource_ORDER_CHANGE_SOURCE_WORKFLOW,
 }
 
 func (s *grpcService) newOrderItems(itms []models.OrderItem) []*orderpb.OrderItem {
 	pbItems := make([]*orderpb.OrderItem, len(itms))
 	for i, itm := range itms {
 		pbItems[i] = &orderpb.OrderItem{
-			TicketClassId: itm.TicketClassID,
-			Quantity:      itm.Quantity,
-			PriceCents:    itm.TotalAmount,
+			TicketClassId:    itm.TicketClassID,
+			Quantity:         itm.Quantity,
+			PriceCents:       itm.TotalAmount,
+			RefundedQuantity: itm.RefundedQuantity,
 		}
 	}
 
 	return pbItems
 }
 
+var GrpcCheckoutStateValue = map[models.CheckoutState]orderpb.CheckoutState{
+	models.CheckoutStateCreating:          orderpb.CheckoutState_CHECKOUT_STATE_CREATING,
+	models.CheckoutStateAwaitingPayment:   orderpb.CheckoutState_CHECKOUT_STATE_AWAITING_PAYMENT,
+	models.CheckoutStateProcessingPayment: orderpb.CheckoutState_CHECKOUT_STATE_PROCESSING_PAYMENT,
+	models.CheckoutStateCompleted:         orderpb.CheckoutState_CHECKOUT_STATE_COMPLETED,
+	models.CheckoutStateFailed:            orderpb.CheckoutState_CHECKOUT_STATE_FAILED,
+}
+
 func (s *grpcService) newCreateResponses(out order.CreateOrderOutput) *orderpb.CreateOrderResponse {
-	ord := &orderpb.Order{
+	resp := &orderpb.CreateOrderResponse{
+		OrderCode:     out.OrderCode,
+		CheckoutState: GrpcCheckoutStateValue[out.State],
+		PaymentUrl:    out.PaymentUrl,
+	}
+
+	// Asynchronous checkouts return before the order is persisted
+	if out.Order == nil {
+		return resp
+	}
+
+	resp.Order = &orderpb.Order{
 		Id:               out.Order.ID.Hex(),
 		Code:             out.Order.Code,
 		UserId:           out.Order.UserID,
@@ -51,10 +91,29 @@ func (s *grpcService) newCreateResponses(out order.CreateOrderOutput) *orderpb.C
 		Items:            s.newOrderItems(out.OrderItems),
 	}
 
-	return &orderpb.CreateOrderResponse{
-		Order:      ord,
-		PaymentUrl: out.PaymentUrl,
+	return resp
+}
+
+func (s *grpcService) newGetCheckoutStatusResponse(st models.CheckoutStatus) *orderpb.GetCheckoutStatusResponse {
+	resp := &orderpb.GetCheckoutStatusResponse{
+		OrderCode: st.OrderCode,
+		State:     GrpcCheckoutStateValue[st.State],
+		Progress: &orderpb.CheckoutProgress{
+			AvailabilityChecked: st.AvailabilityChecked,
+			OrderPersisted:      st.OrderPersisted,
+			Reserved:            st.Reserved,
+			PaymentUrlReady:     st.PaymentUrlReady,
+		},
+		PaymentUrl: st.PaymentUrl,
+		Error:      st.Error,
 	}
+
+	if st.Order != nil {
+		resp.Order = s.newOrderResponse(*st.Order)
+		resp.Order.Items = s.newOrderItems(st.OrderItems)
+	}
+
+	return resp
 }
 
 func (s *grpcService) newGetManyOrderResponse(out order.GetManyOrderOutput) *orderpb.GetManyOrdersResponse {
@@ -63,46 +122,57 @@ func (s *grpcService) newGetManyOrderResponse(out order.GetManyOrderOutput) *ord
 		os[i] = s.newOrderResponse(o)
 	}
 
-	pagResp := out.Pag.ToResponse()
 	return &orderpb.GetManyOrdersResponse{
 		Orders: os,
 		Pagination: &orderpb.PaginationInfo{
-			Page:        pagResp.Page,
-			PageSize:    pagResp.PageSize,
-			Total:       pagResp.Total,
-			Count:       pagResp.Count,
-			LastPage:    pagResp.LastPage,
-			HasNext:     pagResp.HasNext,
-			HasPrevious: pagResp.HasPrevious,
+			PageSize:    out.Pag.PageSize,
+			Total:       out.Pag.Total,
+			Count:       out.Pag.Count,
+			HasNext:     out.Pag.Next != "",
+			HasPrevious: out.Pag.Prev != "",
+			NextCursor:  out.Pag.Next,
+			PrevCursor:  out.Pag.Prev,
 		},
 	}
 }
 
 func (s *grpcService) newOrderResponse(o models.Order) *orderpb.Order {
-	return &orderpb.Order{
-		Id:               o.ID.Hex(),
-		Code:             o.Code,
-		UserId:           o.UserID,
-		EventId:          o.EventID,
-		UserFullname:     o.UserFullName,
-		UserEmail:        o.Email,
-		TotalAmountCents: o.TotalAmount,
-		Currency:         o.Currency,
-		PaymentMethod:    string(o.PaymentMethod),
-		Status:           GrpcOrderStatusValue[o.Status],
-		CreatedAt:        util.TimeToISO8601Str(o.CreatedAt),
-		UpdatedAt:        util.TimeToISO8601Str(o.UpdatedAt),
+	pbo := &orderpb.Order{
+		Id:                  o.ID.Hex(),
+		Code:                o.Code,
+		UserId:              o.UserID,
+		EventId:             o.EventID,
+		UserFullname:        o.UserFullName,
+		UserEmail:           o.Email,
+		TotalAmountCents:    o.TotalAmount,
+		Currency:            o.Currency,
+		PaymentMethod:       string(o.PaymentMethod),
+		Status:              GrpcOrderStatusValue[o.Status],
+		CreatedAt:           util.TimeToISO8601Str(o.CreatedAt),
+		UpdatedAt:           util.TimeToISO8601Str(o.UpdatedAt),
+		RefundedAmountCents: o.RefundedAmount,
+		RefundReason:        o.RefundReason,
+		NetAmountCents:      o.NetAmount(),
+		FailureReason:       o.FailureReason,
+		PaymentId:           o.PaymentID,
+		TransactionId:       o.TransactionID,
 	}
+	if o.PaidAt != nil {
+		pbo.PaidAt = util.TimeToISO8601Str(*o.PaidAt)
+	}
+
+	return pbo
 }
 
-func (s *grpcService) newListOrderResponse(os []models.Order) *orderpb.ListOrdersResponse {
-	pbos := make([]*orderpb.Order, len(os))
-	for i, o := range os {
+func (s *grpcService) newListOrderResponse(out order.ListOrderOutput) *orderpb.ListOrdersResponse {
+	pbos := make([]*orderpb.Order, len(out.Orders))
+	for i, o := range out.Orders {
 		pbos[i] = s.newOrderResponse(o)
 	}
 
 	return &orderpb.ListOrdersResponse{
-		Orders: pbos,
+		Orders:     pbos,
+		NextCursor: out.Next,
 	}
 }
 
@@ -112,6 +182,30 @@ func (s *grpcService) newGetOrderResponse(o models.Order) *orderpb.GetOrderRespo
 	}
 }
 
+func (s *grpcService) newGetOrderHistoryResponse(o models.Order) *orderpb.GetOrderHistoryResponse {
+	chs := make([]*orderpb.OrderStatusChange, len(o.StatusHistory))
+	for i, ch := range o.StatusHistory {
+		chs[i] = &orderpb.OrderStatusChange{
+			From: GrpcOrderStatusValue[ch.From],
+			To:   GrpcOrderStatusValue[ch.To],
+			Actor: &orderpb.OrderActor{
+				Type:   GrpcOrderActorTypeValue[ch.Actor.Type],
+				Id:     ch.Actor.ID,
+				Source: GrpcOrderChangeSourceValue[ch.Actor.Source],
+			},
+			WorkflowId: ch.WorkflowID,
+			Reason:     ch.Reason,
+			ChangedAt:  util.TimeToISO8601Str(ch.ChangedAt),
+		}
+	}
+
+	return &orderpb.GetOrderHistoryResponse{
+		OrderId:   o.ID.Hex(),
+		OrderCode: o.Code,
+		Changes:   chs,
+	}
+}
+
 func (s *grpcService) newOrderFilter(reqFil *orderpb.OrderFilter) order.FilterOrder {
 	fil := order.FilterOrder{}
 
@@ -2,6 +2,7 @@ package grpc
 
 import (
 	"context"
+	"time"
 
 	"github.com/vogiaan1904/ticketbottle-order/internal/models"
 	"github.com/vogiaan1904/ticketbottle-order/internal/order"
@@ -9,9 +10,14 @@ import (
 	"github.com/vogiaan1904/ticketbottle-order/pkg/logger"
 	"github.com/vogiaan1904/ticketbottle-order/pkg/paginator"
 	"github.com/vogiaan1904/ticketbottle-order/pkg/response"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
+// checkoutStatusPollInterval is how often WatchCheckoutStatus queries the checkout for changes
+const checkoutStatusPollInterval = 500 * time.Millisecond
+
 type grpcService struct {
 	svc order.Service
 	l   logger.Logger
@@ -40,6 +46,7 @@ func (s *grpcService) CreateOrder(ctx context.Context, req *orderpb.CreateOrderR
 		PaymentMethod: models.PaymentMethod(req.PaymentMethod),
 		RedirectUrl:   req.RedirectUrl,
 		CheckoutToken: req.CheckoutToken,
+		Async:         req.Async,
 	}
 
 	itms := make([]order.OrderItemInput, len(req.Items))
@@ -67,7 +74,10 @@ func (s *grpcService) CancelOrder(ctx context.Context, req *orderpb.CancelOrderR
 		return nil, response.GrpcError(err)
 	}
 
-	err := s.svc.Cancel(ctx, req.Id)
+	err := s.svc.Cancel(ctx, order.CancelOrderInput{
+		ID:     req.GetId(),
+		UserID: req.GetUserId(),
+	})
 	if err != nil {
 		err := s.mapError(err)
 		s.l.Errorf(ctx, "internal.order.delivery.grpc.service.Cancel: %v", err)
@@ -77,15 +87,122 @@ func (s *grpcService) CancelOrder(ctx context.Context, req *orderpb.CancelOrderR
 	return &emptypb.Empty{}, nil
 }
 
+func (s *grpcService) RefundOrder(ctx context.Context, req *orderpb.RefundOrderRequest) (*orderpb.RefundOrderResponse, error) {
+	if err := s.validateRefundOrderRequest(req); err != nil {
+		s.l.Errorf(ctx, "internal.order.delivery.grpc.service.RefundOrder.validateRefundOrderRequest: %v", err)
+		return nil, response.GrpcError(err)
+	}
+
+	itms := make([]order.RefundOrderItemInput, len(req.GetItems()))
+	for i, itm := range req.GetItems() {
+		itms[i] = order.RefundOrderItemInput{
+			TicketClassID: itm.GetTicketClassId(),
+			Quantity:      itm.GetQuantity(),
+		}
+	}
+
+	o, err := s.svc.Refund(ctx, order.RefundOrderInput{
+		ID:       req.GetId(),
+		Reason:   req.GetReason(),
+		Items:    itms,
+		Operator: req.GetOperator(),
+	})
+	if err != nil {
+		err := s.mapError(err)
+		s.l.Errorf(ctx, "internal.order.delivery.grpc.service.RefundOrder: %v", err)
+		return nil, response.GrpcError(err)
+	}
+
+	return &orderpb.RefundOrderResponse{
+		Order: s.newOrderResponse(o),
+	}, nil
+}
+
+func (s *grpcService) GetOrderHistory(ctx context.Context, req *orderpb.GetOrderHistoryRequest) (*orderpb.GetOrderHistoryResponse, error) {
+	if err := s.validateGetOrderHistoryRequest(req); err != nil {
+		s.l.Errorf(ctx, "internal.order.delivery.grpc.service.GetOrderHistory.validateGetOrderHistoryRequest: %v", err)
+		return nil, response.GrpcError(err)
+	}
+
+	o, err := s.svc.GetByID(ctx, req.GetId())
+	if err != nil {
+		err := s.mapError(err)
+		s.l.Errorf(ctx, "internal.order.delivery.grpc.service.GetOrderHistory: %v", err)
+		return nil, response.GrpcError(err)
+	}
+
+	return s.newGetOrderHistoryResponse(o), nil
+}
+
+func (s *grpcService) GetCheckoutStatus(ctx context.Context, req *orderpb.GetCheckoutStatusRequest) (*orderpb.GetCheckoutStatusResponse, error) {
+	if err := s.validateGetCheckoutStatusRequest(req); err != nil {
+		s.l.Errorf(ctx, "internal.order.delivery.grpc.service.GetCheckoutStatus.validateGetCheckoutStatusRequest: %v", err)
+		return nil, response.GrpcError(err)
+	}
+
+	st, err := s.svc.GetCheckoutStatus(ctx, req.GetOrderCode())
+	if err != nil {
+		err := s.mapError(err)
+		s.l.Errorf(ctx, "internal.order.delivery.grpc.service.GetCheckoutStatus: %v", err)
+		return nil, response.GrpcError(err)
+	}
+
+	return s.newGetCheckoutStatusResponse(st), nil
+}
+
+// WatchCheckoutStatus polls the checkout and sends every change until the checkout is final
+// or the client goes away
+func (s *grpcService) WatchCheckoutStatus(req *orderpb.GetCheckoutStatusRequest, stream grpc.ServerStreamingServer[orderpb.GetCheckoutStatusResponse]) error {
+	ctx := stream.Context()
+
+	if err := s.validateGetCheckoutStatusRequest(req); err != nil {
+		s.l.Errorf(ctx, "internal.order.delivery.grpc.service.WatchCheckoutStatus.validateGetCheckoutStatusRequest: %v", err)
+		return response.GrpcError(err)
+	}
+
+	ticker := time.NewTicker(checkoutStatusPollInterval)
+	defer ticker.Stop()
+
+	var last *orderpb.GetCheckoutStatusResponse
+	for {
+		st, err := s.svc.GetCheckoutStatus(ctx, req.GetOrderCode())
+		if err != nil {
+			err := s.mapError(err)
+			s.l.Errorf(ctx, "internal.order.delivery.grpc.service.WatchCheckoutStatus: %v", err)
+			return response.GrpcError(err)
+		}
+
+		resp := s.newGetCheckoutStatusResponse(st)
+		if !proto.Equal(resp, last) {
+			if err := stream.Send(resp); err != nil {
+				s.l.Errorf(ctx, "internal.order.delivery.grpc.service.WatchCheckoutStatus.stream.Send: %v", err)
+				return err
+			}
+			last = resp
+		}
+
+		if st.IsFinal() {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
 func (s *grpcService) GetManyOrders(ctx context.Context, req *orderpb.GetManyOrdersRequest) (*orderpb.GetManyOrdersResponse, error) {
 	if err := s.validateGetManyOrdersRequest(req); err != nil {
 		s.l.Errorf(ctx, "internal.order.delivery.grpc.service.GetManyOrders.validateGetManyOrdersRequest: %v", err)
 		return nil, response.GrpcError(err)
 	}
 
-	pagQ := paginator.PaginatorQuery{
-		Page:  req.GetPage(),
-		Limit: req.GetPageSize(),
+	pagQ := paginator.CursorQuery{
+		Cursor:    req.GetCursor(),
+		Limit:     req.GetPageSize(),
+		WithTotal: req.GetIncludeTotal(),
 	}
 
 	in := order.GetManyOrderInput{
@@ -111,16 +228,18 @@ func (s *grpcService) ListOrders(ctx context.Context, req *orderpb.ListOrdersReq
 
 	in := order.ListOrderInput{
 		FilterOrder: s.newOrderFilter(req.GetFilter()),
+		Cursor:      req.GetCursor(),
+		Limit:       req.GetLimit(),
 	}
 
-	os, err := s.svc.List(ctx, in)
+	out, err := s.svc.List(ctx, in)
 	if err != nil {
 		err := s.mapError(err)
 		s.l.Errorf(ctx, "internal.order.delivery.grpc.service.ListOrders: %v", err)
 		return nil, response.GrpcError(err)
 	}
 
-	return s.newListOrderResponse(os), nil
+	return s.newListOrderResponse(out), nil
 }
 
 func (s *grpcService) GetOrder(ctx context.Context, req *orderpb.GetOrderRequest) (*orderpb.GetOrderResponse, error) {
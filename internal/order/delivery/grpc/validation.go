@@ -54,10 +54,11 @@ func validateCreateOrderItem(item *orderpb.CreateOrderItem) error {
 }
 
 func (s *grpcService) validateGetManyOrdersRequest(req *orderpb.GetManyOrdersRequest) error {
-	if req.GetPage() <= 0 {
+	// Pages past the first are reached through cursors, page is only accepted as 0 or 1
+	if req.GetPage() < 0 || req.GetPage() > 1 {
 		return ErrValidationFailed
 	}
-	if req.GetPageSize() <= 0 {
+	if req.GetPageSize() < 0 {
 		return ErrValidationFailed
 	}
 	if req.GetFilter() != nil {
@@ -87,6 +88,9 @@ func (s *grpcService) validateGetOrderRequest(req *orderpb.GetOrderRequest) erro
 }
 
 func (s *grpcService) validateListOrdersRequest(req *orderpb.ListOrdersRequest) error {
+	if req.GetLimit() < 0 {
+		return ErrValidationFailed
+	}
 	if req.GetFilter() != nil {
 		if err := s.validateOrderFilter(req.GetFilter()); err != nil {
 			return err
@@ -95,9 +99,41 @@ func (s *grpcService) validateListOrdersRequest(req *orderpb.ListOrdersRequest)
 	return nil
 }
 
-func (s *grpcService) validateCancelOrderRequest(req *orderpb.CancelOrderRequest) error {
+func (s *grpcService) validateRefundOrderRequest(req *orderpb.RefundOrderRequest) error {
 	if req.GetId() == "" {
 		return ErrValidationFailed
 	}
+	if req.GetReason() == "" {
+		return ErrValidationFailed
+	}
+	for _, itm := range req.GetItems() {
+		if itm.GetTicketClassId() == "" {
+			return ErrValidationFailed
+		}
+		if itm.GetQuantity() <= 0 {
+			return ErrValidationFailed
+		}
+	}
+	return nil
+}
+
+func (s *grpcService) validateGetOrderHistoryRequest(req *orderpb.GetOrderHistoryRequest) error {
+	if req.GetId() == "" {
+		return ErrValidationFailed
+	}
+	return nil
+}
+
+func (s *grpcService) validateGetCheckoutStatusRequest(req *orderpb.GetCheckoutStatusRequest) error {
+	if req.GetOrderCode() == "" {
+		return ErrValidationFailed
+	}
+	return nil
+}
+
+func (s *grpcService) validateCancelOrderRequest(req *orderpb.CancelOrderRequest) error {
+	if req.GetId() == "" || req.GetUserId() == "" {
+		return ErrValidationFailed
+	}
 	return nil
 }
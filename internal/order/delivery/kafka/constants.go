@@ -4,6 +4,34 @@ const (
 	TopicPaymentCompleted = "payment.completed"
 	TopicPaymentFailed    = "payment.failed"
 
+	// Retry and dead-letter topics of the payment events, see consumer.retryTiers
+	TopicPaymentCompletedRetry1m  = "payment.completed.retry.1m"
+	TopicPaymentCompletedRetry10m = "payment.completed.retry.10m"
+	TopicPaymentCompletedDLQ      = "payment.completed.dlq"
+	TopicPaymentFailedRetry1m     = "payment.failed.retry.1m"
+	TopicPaymentFailedRetry10m    = "payment.failed.retry.10m"
+	TopicPaymentFailedDLQ         = "payment.failed.dlq"
+
+	// TopicEventCancelled is published by the event service when an organizer cancels an event
+	TopicEventCancelled         = "event.cancelled"
+	TopicEventCancelledRetry1m  = "event.cancelled.retry.1m"
+	TopicEventCancelledRetry10m = "event.cancelled.retry.10m"
+	TopicEventCancelledDLQ      = "event.cancelled.dlq"
+
+	// TopicWaitroomSessionExpired is published by the waitroom service when a checkout session expires
+	TopicWaitroomSessionExpired         = "waitroom.session.expired"
+	TopicWaitroomSessionExpiredRetry1m  = "waitroom.session.expired.retry.1m"
+	TopicWaitroomSessionExpiredRetry10m = "waitroom.session.expired.retry.10m"
+	TopicWaitroomSessionExpiredDLQ      = "waitroom.session.expired.dlq"
+
 	TopicCheckoutCompleted = "checkout.completed"
 	TopicCheckoutFailed    = "checkout.failed"
+
+	// Order lifecycle topics, keyed by order code
+	TopicOrderCreated       = "order.created"
+	TopicOrderCompleted     = "order.completed"
+	TopicOrderCancelled     = "order.cancelled"
+	TopicOrderPaymentFailed = "order.payment_failed"
+	TopicOrderTimedOut      = "order.timed_out"
+	TopicOrderRefunded      = "order.refunded"
 )
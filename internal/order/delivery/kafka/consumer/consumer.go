@@ -2,6 +2,7 @@ package consumer
 
 import (
 	"context"
+	"fmt"
 	"sync"
 
 	"github.com/IBM/sarama"
@@ -11,38 +12,80 @@ import (
 )
 
 type Consumer struct {
-	consGr sarama.ConsumerGroup
-	svc    order.Service
-	l      logger.Logger
-	wg     sync.WaitGroup
+	consGr   sarama.ConsumerGroup
+	prod     sarama.SyncProducer
+	svc      order.Service
+	l        logger.Logger
+	cfg      Config
+	wg       sync.WaitGroup
+	handlers map[handlerKey]eventHandler
 }
 
 func NewConsumer(
 	consGr sarama.ConsumerGroup,
+	prod sarama.SyncProducer,
 	svc order.Service,
 	l logger.Logger,
+	cfg Config,
 ) *Consumer {
-	return &Consumer{
+	if cfg.Workers < 1 {
+		cfg.Workers = 1
+	}
+	if cfg.MaxInFlight < cfg.Workers {
+		cfg.MaxInFlight = cfg.Workers
+	}
+
+	c := &Consumer{
 		consGr: consGr,
+		prod:   prod,
 		svc:    svc,
 		l:      l,
+		cfg:    cfg,
 	}
+	c.handlers = c.newHandlers()
+
+	return c
 }
 
+// processMessage dispatches a message on its event type and schema version. Legacy bare messages
+// take the event type of their topic.
 func (c *Consumer) processMessage(ctx context.Context, msg *sarama.ConsumerMessage) error {
-	switch msg.Topic {
-	case kafka.TopicPaymentCompleted:
-		return c.HandlePaymentCompleted(ctx, msg)
-	case kafka.TopicPaymentFailed:
-		return c.HandlePaymentFailed(ctx, msg)
-	default:
+	legacyType, ok := legacyEventTypes[sourceTopic(msg)]
+	if !ok {
 		c.l.Warn(ctx, "Unknown topic", "topic", msg.Topic)
 		return nil
 	}
+
+	env, err := kafka.ParseEnvelope(msg.Value, legacyType)
+	if err != nil {
+		c.l.Errorf(ctx, "delivery.kafka.consumer.consumer.processMessage.ParseEnvelope: %v", err)
+		return fmt.Errorf("%w: %w", ErrPoisonMessage, err)
+	}
+	env.Topic = msg.Topic
+
+	h, ok := c.handlers[handlerKey{typ: env.Type, version: env.SchemaVersion}]
+	if !ok {
+		return fmt.Errorf("%w: unsupported event %s at schema version %s", ErrPoisonMessage, env.Type, env.SchemaVersion)
+	}
+
+	return h(ctx, env)
 }
 
 func (c *Consumer) Start(ctx context.Context) error {
-	topics := []string{kafka.TopicPaymentCompleted, kafka.TopicPaymentFailed}
+	topics := []string{
+		kafka.TopicPaymentCompleted,
+		kafka.TopicPaymentFailed,
+		kafka.TopicPaymentCompletedRetry1m,
+		kafka.TopicPaymentCompletedRetry10m,
+		kafka.TopicPaymentFailedRetry1m,
+		kafka.TopicPaymentFailedRetry10m,
+		kafka.TopicEventCancelled,
+		kafka.TopicEventCancelledRetry1m,
+		kafka.TopicEventCancelledRetry10m,
+		kafka.TopicWaitroomSessionExpired,
+		kafka.TopicWaitroomSessionExpiredRetry1m,
+		kafka.TopicWaitroomSessionExpiredRetry10m,
+	}
 	c.wg.Add(1)
 	go func() {
 		defer c.wg.Done()
@@ -87,27 +130,3 @@ func (c *Consumer) Cleanup(sarama.ConsumerGroupSession) error {
 	c.l.Debug(context.Background(), "Consumer group session ended")
 	return nil
 }
-
-func (c *Consumer) ConsumeClaim(ss sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
-	for {
-		select {
-		case message := <-claim.Messages():
-			if message == nil {
-				return nil
-			}
-
-			if err := c.processMessage(ss.Context(), message); err != nil {
-				c.l.Error(ss.Context(), "delivery.kafka.consumer.consumer.ConsumeClaim: %v", err,
-					"topic", message.Topic,
-					"offset", message.Offset,
-				)
-				continue
-			}
-
-			ss.MarkMessage(message, "")
-
-		case <-ss.Context().Done():
-			return nil
-		}
-	}
-}
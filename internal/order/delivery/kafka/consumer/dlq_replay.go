@@ -0,0 +1,175 @@
+package consumer
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/vogiaan1904/ticketbottle-order/pkg/logger"
+)
+
+// replayIdleTimeout is how long a partition may stay silent before it counts as drained
+const replayIdleTimeout = 5 * time.Second
+
+// DLQReplayer moves dead-lettered messages back onto the topic they were first published to,
+// with their original key and headers and a fresh attempt count
+type DLQReplayer struct {
+	consGr sarama.ConsumerGroup
+	prod   sarama.SyncProducer
+	l      logger.Logger
+}
+
+func NewDLQReplayer(consGr sarama.ConsumerGroup, prod sarama.SyncProducer, l logger.Logger) *DLQReplayer {
+	return &DLQReplayer{
+		consGr: consGr,
+		prod:   prod,
+		l:      l,
+	}
+}
+
+// Replay republishes every message of a dead-letter topic that the replayer's consumer group has
+// not seen yet, and returns how many it replayed once all partitions are drained
+func (r *DLQReplayer) Replay(ctx context.Context, topic string) (int, error) {
+	if !isDLQTopic(topic) {
+		return 0, fmt.Errorf("%s is not a dead-letter topic", topic)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	h := &replayHandler{r: r, done: cancel}
+	for ctx.Err() == nil {
+		if err := r.consGr.Consume(ctx, []string{topic}, h); err != nil {
+			return h.replayed, err
+		}
+	}
+
+	return h.replayed, h.err
+}
+
+type replayHandler struct {
+	r    *DLQReplayer
+	done context.CancelFunc
+
+	mu       sync.Mutex
+	pending  int
+	replayed int
+	err      error
+}
+
+func (h *replayHandler) Setup(ss sarama.ConsumerGroupSession) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.pending = 0
+	for _, partitions := range ss.Claims() {
+		h.pending += len(partitions)
+	}
+	if h.pending == 0 {
+		h.done()
+	}
+
+	return nil
+}
+
+func (h *replayHandler) Cleanup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+func (h *replayHandler) ConsumeClaim(ss sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	if !h.drain(ss, claim) {
+		return nil
+	}
+	h.drained(ss)
+
+	// Returning would end the session for the partitions that are still draining
+	<-ss.Context().Done()
+	return nil
+}
+
+// drain replays the claim until it is caught up, it reports false when the session ended first
+func (h *replayHandler) drain(ss sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) bool {
+	idle := time.NewTimer(replayIdleTimeout)
+	defer idle.Stop()
+
+	for {
+		select {
+		case msg := <-claim.Messages():
+			if msg == nil {
+				return false
+			}
+
+			if err := h.replay(ss.Context(), msg); err != nil {
+				h.mu.Lock()
+				h.err = err
+				h.mu.Unlock()
+				h.done()
+				return false
+			}
+			ss.MarkMessage(msg, "")
+
+			if msg.Offset+1 >= claim.HighWaterMarkOffset() {
+				return true
+			}
+			idle.Reset(replayIdleTimeout)
+
+		case <-idle.C:
+			return true
+
+		case <-ss.Context().Done():
+			return false
+		}
+	}
+}
+
+func (h *replayHandler) replay(ctx context.Context, msg *sarama.ConsumerMessage) error {
+	src, ok := header(msg, HeaderOriginalTopic)
+	if !ok {
+		h.r.l.Warnf(ctx, "Skipping message at offset %d of %s without %s header", msg.Offset, msg.Topic, HeaderOriginalTopic)
+		return nil
+	}
+
+	out := &sarama.ProducerMessage{
+		Topic:   src,
+		Value:   sarama.ByteEncoder(msg.Value),
+		Headers: originalHeaders(msg),
+	}
+	if msg.Key != nil {
+		out.Key = sarama.ByteEncoder(msg.Key)
+	}
+
+	if _, _, err := h.r.prod.SendMessage(out); err != nil {
+		h.r.l.Errorf(ctx, "delivery.kafka.consumer.dlq_replay.replay.prod.SendMessage: %v", err)
+		return err
+	}
+
+	h.mu.Lock()
+	h.replayed++
+	h.mu.Unlock()
+
+	return nil
+}
+
+// drained commits the claim and stops the replay once every claimed partition is done
+func (h *replayHandler) drained(ss sarama.ConsumerGroupSession) {
+	ss.Commit()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.pending--
+	if h.pending <= 0 {
+		h.done()
+	}
+}
+
+func isDLQTopic(topic string) bool {
+	for _, t := range dlqTopics {
+		if t == topic {
+			return true
+		}
+	}
+	return false
+}
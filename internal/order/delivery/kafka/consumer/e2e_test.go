@@ -0,0 +1,362 @@
+package consumer_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/vogiaan1904/ticketbottle-order/internal/activities"
+	"github.com/vogiaan1904/ticketbottle-order/internal/models"
+	"github.com/vogiaan1904/ticketbottle-order/internal/order"
+	"github.com/vogiaan1904/ticketbottle-order/internal/order/delivery/kafka"
+	"github.com/vogiaan1904/ticketbottle-order/internal/order/delivery/kafka/consumer"
+	"github.com/vogiaan1904/ticketbottle-order/internal/order/delivery/kafka/producer"
+	repo "github.com/vogiaan1904/ticketbottle-order/internal/order/repository"
+	oSvc "github.com/vogiaan1904/ticketbottle-order/internal/order/service"
+	peRepo "github.com/vogiaan1904/ticketbottle-order/internal/processedevent/repository"
+	"github.com/vogiaan1904/ticketbottle-order/pkg/grpc/inventory"
+	"github.com/vogiaan1904/ticketbottle-order/pkg/kafka/kafkatest"
+	"github.com/vogiaan1904/ticketbottle-order/pkg/logger"
+	"github.com/vogiaan1904/ticketbottle-order/pkg/mongo"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/testsuite"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// TestPaymentCompletedConfirmsOrder runs a payment.completed event through the consumer, the order
+// service and the ConfirmOrder workflow with its activities, down to the checkout.completed event
+// published for the waitroom. Kafka is the in-memory broker, workflows run in the Temporal test
+// environment, and the order store and inventory service are in memory.
+func TestPaymentCompletedConfirmsOrder(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	l := logger.InitializeTestZapLogger()
+	b := kafkatest.NewBroker(kafkatest.DefaultPartitions)
+	kProd := b.NewSyncProducer()
+	oProd := producer.NewProducer(kProd, l)
+
+	o := models.Order{
+		ID:            primitive.NewObjectID(),
+		SessionID:     "session-1",
+		Code:          "ORD-E2E-1",
+		UserID:        "user-1",
+		EventID:       "event-1",
+		TotalAmount:   150000,
+		Currency:      "VND",
+		PaymentMethod: models.PaymentMethodZalopay,
+		Status:        models.OrderStatusPending,
+		CreatedAt:     time.Now(),
+	}
+	oRepo := newOrderRepo(o)
+	inv := &inventoryClient{}
+
+	tCli := &temporalClient{
+		acts: []any{
+			activities.NewOrderActivities(oRepo, oProd),
+			activities.NewInventoryActivities(inv, false),
+			activities.NewEventPublishingActivities(oProd, oRepo),
+			activities.NewInterventionActivities(nil),
+		},
+	}
+
+	svc := oSvc.New(l, oRepo, newProcessedEventRepo(), nil, inv, nil, nil, oProd, tCli, false)
+	cons := consumer.NewConsumer(b.NewConsumerGroup("order-service", sarama.OffsetOldest), kProd, svc, l,
+		consumer.Config{Workers: 4, MaxInFlight: 16})
+
+	consCtx, stop := context.WithCancel(ctx)
+	if err := cons.Start(consCtx); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer func() {
+		stop()
+		cons.Close()
+	}()
+
+	payload, err := json.Marshal(kafka.PaymentCompletedEvent{
+		OrderCode:     o.Code,
+		PaymentID:     "payment-1",
+		AmountCents:   o.TotalAmount,
+		Currency:      o.Currency,
+		Provider:      string(o.PaymentMethod),
+		TransactionID: "txn-1",
+		CompletedAt:   time.Now().UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	part, _, err := kProd.SendMessage(&sarama.ProducerMessage{
+		Topic: kafka.TopicPaymentCompleted,
+		Key:   sarama.StringEncoder(o.Code),
+		Value: sarama.ByteEncoder(payload),
+	})
+	if err != nil {
+		t.Fatalf("SendMessage: %v", err)
+	}
+
+	msgs, err := b.WaitForMessages(ctx, kafka.TopicCheckoutCompleted, 1)
+	if err != nil {
+		t.Fatalf("WaitForMessages: %v", err)
+	}
+
+	env, err := kafka.ParseEnvelope(msgs[0].Value, kafka.EventTypeCheckoutCompleted)
+	if err != nil {
+		t.Fatalf("ParseEnvelope: %v", err)
+	}
+	var e kafka.CheckoutCompletedEvent
+	if err := json.Unmarshal(env.Data, &e); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if e.SessionID != o.SessionID || e.UserID != o.UserID || e.EventID != o.EventID {
+		t.Fatalf("checkout.completed for %+v, want session %s of user %s on event %s", e, o.SessionID, o.UserID, o.EventID)
+	}
+
+	if err := tCli.wait(ctx); err != nil {
+		t.Fatalf("ConfirmOrder: %v", err)
+	}
+
+	got := oRepo.get()
+	if got.Status != models.OrderStatusCompleted {
+		t.Fatalf("order status %s, want %s", got.Status, models.OrderStatusCompleted)
+	}
+	if got.PaymentID != "payment-1" || got.TransactionID != "txn-1" {
+		t.Fatalf("order payment %s / %s, want payment-1 / txn-1", got.PaymentID, got.TransactionID)
+	}
+	if codes := inv.confirmedCodes(); len(codes) != 1 || codes[0] != o.Code {
+		t.Fatalf("inventory confirmed for %v, want [%s]", codes, o.Code)
+	}
+	if n := len(b.Messages(kafka.TopicOrderCompleted)); n != 1 {
+		t.Fatalf("%d order.completed events, want 1", n)
+	}
+
+	// The event is committed once it was handled
+	for {
+		if off, ok := b.CommittedOffset("order-service", kafka.TopicPaymentCompleted, part); ok && off == 1 {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			t.Fatalf("payment.completed offset was not committed")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+// temporalClient runs every started workflow to completion in its own test environment. Signals
+// find no workflow, so payments take the path of orders without a running CreateOrder workflow.
+type temporalClient struct {
+	client.Client
+	acts []any
+
+	wg   sync.WaitGroup
+	mu   sync.Mutex
+	errs []error
+}
+
+func (c *temporalClient) SignalWorkflow(ctx context.Context, workflowID string, runID string, signalName string, arg any) error {
+	return serviceerror.NewNotFound("workflow not found")
+}
+
+func (c *temporalClient) ExecuteWorkflow(ctx context.Context, opts client.StartWorkflowOptions, wf any, args ...any) (client.WorkflowRun, error) {
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+
+		var s testsuite.WorkflowTestSuite
+		env := s.NewTestWorkflowEnvironment()
+		env.RegisterWorkflow(wf)
+		for _, a := range c.acts {
+			env.RegisterActivity(a)
+		}
+
+		env.ExecuteWorkflow(wf, args...)
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if !env.IsWorkflowCompleted() {
+			c.errs = append(c.errs, errors.New("workflow did not complete"))
+		}
+		if err := env.GetWorkflowError(); err != nil {
+			c.errs = append(c.errs, err)
+		}
+	}()
+
+	return workflowRun{id: opts.ID}, nil
+}
+
+// wait returns the first error of the workflows started so far, once all of them completed
+func (c *temporalClient) wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		c.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.errs) > 0 {
+		return c.errs[0]
+	}
+	return nil
+}
+
+type workflowRun struct {
+	client.WorkflowRun
+	id string
+}
+
+func (r workflowRun) GetID() string {
+	return r.id
+}
+
+func (r workflowRun) GetRunID() string {
+	return "test-run"
+}
+
+// orderRepo keeps a single order in memory
+type orderRepo struct {
+	repo.Repository
+
+	mu sync.Mutex
+	o  models.Order
+}
+
+func newOrderRepo(o models.Order) *orderRepo {
+	return &orderRepo{o: o}
+}
+
+func (r *orderRepo) get() models.Order {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.o
+}
+
+func (r *orderRepo) GetOne(ctx context.Context, opt repo.GetOneOrderOption) (models.Order, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opt.Code != r.o.Code {
+		return models.Order{}, mongo.ErrNoDocuments
+	}
+	return r.o, nil
+}
+
+func (r *orderRepo) GetByID(ctx context.Context, ID string) (models.Order, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if ID != r.o.ID.Hex() {
+		return models.Order{}, mongo.ErrNoDocuments
+	}
+	return r.o, nil
+}
+
+func (r *orderRepo) Update(ctx context.Context, ID string, opt repo.UpdateOrderOption) (models.Order, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if ID != r.o.ID.Hex() {
+		return models.Order{}, mongo.ErrNoDocuments
+	}
+	if !opt.Model.ID.IsZero() && (opt.Model.Status != r.o.Status || opt.Model.Version != r.o.Version) {
+		return models.Order{}, &order.ConflictError{OrderID: ID, Status: opt.Model.Status, Version: opt.Model.Version}
+	}
+
+	if opt.Status != "" && opt.Status != r.o.Status {
+		r.o.StatusHistory = append(r.o.StatusHistory, models.OrderStatusChange{
+			From:       r.o.Status,
+			To:         opt.Status,
+			Actor:      opt.Change.Actor,
+			WorkflowID: opt.Change.WorkflowID,
+			Reason:     opt.Change.Reason,
+			ChangedAt:  time.Now(),
+		})
+		r.o.Status = opt.Status
+	}
+	r.o.Version++
+
+	return r.o, nil
+}
+
+func (r *orderRepo) UpdatePayment(ctx context.Context, ID string, opt repo.UpdateOrderPaymentOption) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.o.PaymentID = opt.PaymentID
+	r.o.TransactionID = opt.TransactionID
+	r.o.Version++
+
+	return nil
+}
+
+func (r *orderRepo) ListItemByOrderID(ctx context.Context, ordID string) ([]models.OrderItem, error) {
+	return nil, nil
+}
+
+type inventoryClient struct {
+	inventory.InventoryServiceClient
+
+	mu        sync.Mutex
+	confirmed []string
+}
+
+func (c *inventoryClient) Confirm(ctx context.Context, in *inventory.ConfirmRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.confirmed = append(c.confirmed, in.GetOrderCode())
+	return &emptypb.Empty{}, nil
+}
+
+func (c *inventoryClient) confirmedCodes() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]string(nil), c.confirmed...)
+}
+
+type processedEventRepo struct {
+	mu   sync.Mutex
+	keys map[string]bool
+}
+
+func newProcessedEventRepo() *processedEventRepo {
+	return &processedEventRepo{keys: make(map[string]bool)}
+}
+
+func (r *processedEventRepo) Claim(ctx context.Context, key string, opt peRepo.ClaimOption) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.keys[key] {
+		return false, nil
+	}
+	r.keys[key] = true
+	return true, nil
+}
+
+func (r *processedEventRepo) Complete(ctx context.Context, key string, ttl time.Duration) error {
+	return nil
+}
+
+func (r *processedEventRepo) Release(ctx context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.keys, key)
+	return nil
+}
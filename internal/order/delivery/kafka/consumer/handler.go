@@ -3,23 +3,60 @@ package consumer
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"time"
 
-	"github.com/IBM/sarama"
 	"github.com/vogiaan1904/ticketbottle-order/internal/order"
 	"github.com/vogiaan1904/ticketbottle-order/internal/order/delivery/kafka"
+	"github.com/vogiaan1904/ticketbottle-order/pkg/util"
 )
 
-func (c *Consumer) HandlePaymentCompleted(ctx context.Context, msg *sarama.ConsumerMessage) error {
-	c.l.Info(ctx, "HandlePaymentCompleted consumed")
+// eventHandler handles one event type at one schema version
+type eventHandler func(ctx context.Context, e kafka.Envelope) error
+
+type handlerKey struct {
+	typ     string
+	version string
+}
+
+// legacyEventTypes is the event type of the bare messages each topic carried before the envelope
+var legacyEventTypes = map[string]string{
+	kafka.TopicPaymentCompleted:       kafka.EventTypePaymentCompleted,
+	kafka.TopicPaymentFailed:          kafka.EventTypePaymentFailed,
+	kafka.TopicEventCancelled:         kafka.EventTypeEventCancelled,
+	kafka.TopicWaitroomSessionExpired: kafka.EventTypeSessionExpired,
+}
+
+// newHandlers registers a handler per event type and schema version. A new schema version gets its
+// own handler next to the old one until no producer sends the old version anymore.
+func (c *Consumer) newHandlers() map[handlerKey]eventHandler {
+	return map[handlerKey]eventHandler{
+		{typ: kafka.EventTypePaymentCompleted, version: kafka.SchemaVersionV1}: c.HandlePaymentCompleted,
+		{typ: kafka.EventTypePaymentFailed, version: kafka.SchemaVersionV1}:    c.HandlePaymentFailed,
+		{typ: kafka.EventTypeEventCancelled, version: kafka.SchemaVersionV1}:   c.HandleEventCancelled,
+		{typ: kafka.EventTypeSessionExpired, version: kafka.SchemaVersionV1}:   c.HandleSessionExpired,
+	}
+}
+
+func (c *Consumer) HandlePaymentCompleted(ctx context.Context, env kafka.Envelope) error {
+	c.l.Infof(ctx, "HandlePaymentCompleted consumed event %s (legacy: %t)", env.ID, env.Legacy)
 
 	var e kafka.PaymentCompletedEvent
-	if err := json.Unmarshal(msg.Value, &e); err != nil {
+	if err := json.Unmarshal(env.Data, &e); err != nil {
 		c.l.Error(ctx, "delivery.kafka.consumer.handlers.HandlePaymentCompleted: %v", err)
-		return err
+		return fmt.Errorf("%w: %w", ErrPoisonMessage, err)
 	}
 
 	if err := c.svc.HandlePaymentCompleted(ctx, order.HandlePaymentCompletedInput{
-		OrderCode: e.OrderCode,
+		OrderCode:     e.OrderCode,
+		PaymentID:     e.PaymentID,
+		TransactionID: e.TransactionID,
+		AmountCents:   e.AmountCents,
+		Currency:      e.Currency,
+		Provider:      e.Provider,
+		PaidAt:        c.paidAt(ctx, e),
+		Topic:         env.Topic,
+		EventType:     env.Type,
 	}); err != nil {
 		c.l.Error(ctx, "delivery.kafka.consumer.handlers.HandlePaymentCompleted: %v", err)
 		return err
@@ -28,17 +65,22 @@ func (c *Consumer) HandlePaymentCompleted(ctx context.Context, msg *sarama.Consu
 	return nil
 }
 
-func (c *Consumer) HandlePaymentFailed(ctx context.Context, msg *sarama.ConsumerMessage) error {
-	c.l.Info(ctx, "HandlePaymentFailed consumed")
+func (c *Consumer) HandlePaymentFailed(ctx context.Context, env kafka.Envelope) error {
+	c.l.Infof(ctx, "HandlePaymentFailed consumed event %s (legacy: %t)", env.ID, env.Legacy)
 
 	var e kafka.PaymentFailedEvent
-	if err := json.Unmarshal(msg.Value, &e); err != nil {
+	if err := json.Unmarshal(env.Data, &e); err != nil {
 		c.l.Error(ctx, "delivery.kafka.consumer.handlers.HandlePaymentFailed: %v", err)
-		return err
+		return fmt.Errorf("%w: %w", ErrPoisonMessage, err)
 	}
 
 	if err := c.svc.HandlePaymentFailed(ctx, order.HandlePaymentFailedInput{
-		OrderCode: e.OrderCode,
+		OrderCode:     e.OrderCode,
+		Reason:        e.Reason,
+		PaymentID:     e.PaymentID,
+		TransactionID: e.TransactionID,
+		Topic:         env.Topic,
+		EventType:     env.Type,
 	}); err != nil {
 		c.l.Error(ctx, "delivery.kafka.consumer.handlers.HandlePaymentFailed: %v", err)
 		return err
@@ -46,3 +88,67 @@ func (c *Consumer) HandlePaymentFailed(ctx context.Context, msg *sarama.Consumer
 
 	return nil
 }
+
+func (c *Consumer) HandleEventCancelled(ctx context.Context, env kafka.Envelope) error {
+	c.l.Infof(ctx, "HandleEventCancelled consumed event %s (legacy: %t)", env.ID, env.Legacy)
+
+	var e kafka.EventCancelledEvent
+	if err := json.Unmarshal(env.Data, &e); err != nil {
+		c.l.Error(ctx, "delivery.kafka.consumer.handlers.HandleEventCancelled: %v", err)
+		return fmt.Errorf("%w: %w", ErrPoisonMessage, err)
+	}
+	if e.EventID == "" {
+		return fmt.Errorf("%w: missing event_id", ErrPoisonMessage)
+	}
+
+	if err := c.svc.HandleEventCancelled(ctx, order.HandleEventCancelledInput{
+		EventID: e.EventID,
+		Reason:  e.Reason,
+	}); err != nil {
+		c.l.Error(ctx, "delivery.kafka.consumer.handlers.HandleEventCancelled: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func (c *Consumer) HandleSessionExpired(ctx context.Context, env kafka.Envelope) error {
+	c.l.Infof(ctx, "HandleSessionExpired consumed event %s (legacy: %t)", env.ID, env.Legacy)
+
+	var e kafka.SessionExpiredEvent
+	if err := json.Unmarshal(env.Data, &e); err != nil {
+		c.l.Error(ctx, "delivery.kafka.consumer.handlers.HandleSessionExpired: %v", err)
+		return fmt.Errorf("%w: %w", ErrPoisonMessage, err)
+	}
+	if e.SessionID == "" {
+		return fmt.Errorf("%w: missing session_id", ErrPoisonMessage)
+	}
+
+	if err := c.svc.HandleSessionExpired(ctx, order.HandleSessionExpiredInput{
+		SessionID: e.SessionID,
+	}); err != nil {
+		c.l.Error(ctx, "delivery.kafka.consumer.handlers.HandleSessionExpired: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// paidAt parses when the payment was made, falling back to when it was completed. An unreadable time
+// is left zero rather than failing the event, the order then keeps no payment time.
+func (c *Consumer) paidAt(ctx context.Context, e kafka.PaymentCompletedEvent) time.Time {
+	for _, v := range []string{e.PaidAt, e.CompletedAt} {
+		if v == "" {
+			continue
+		}
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			return t
+		}
+		if t, err := util.ParseISO8601(v); err == nil {
+			return t
+		}
+		c.l.Warnf(ctx, "Unreadable payment time %q of order %s", v, e.OrderCode)
+	}
+
+	return time.Time{}
+}
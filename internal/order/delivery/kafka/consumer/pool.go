@@ -0,0 +1,193 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"hash/fnv"
+	"sync"
+
+	"github.com/IBM/sarama"
+)
+
+type Config struct {
+	// Workers is how many messages of one partition are processed at once
+	Workers int
+	// MaxInFlight is how many messages of one partition may be fetched and not yet done before
+	// fetching from that partition pauses
+	MaxInFlight int
+}
+
+type result struct {
+	msg *sarama.ConsumerMessage
+	ok  bool
+}
+
+// ConsumeClaim spreads the messages of a partition over a pool of workers. Messages of one order
+// hash to the same worker, so they are still processed one at a time and in order.
+func (c *Consumer) ConsumeClaim(ss sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	ctx := ss.Context()
+
+	// Never more results than messages in flight, so workers do not block on sending them
+	results := make(chan result, c.cfg.MaxInFlight)
+	queues := make([]chan *sarama.ConsumerMessage, c.cfg.Workers)
+
+	var wg sync.WaitGroup
+	for i := range queues {
+		queues[i] = make(chan *sarama.ConsumerMessage, c.cfg.MaxInFlight)
+
+		wg.Add(1)
+		go func(q <-chan *sarama.ConsumerMessage) {
+			defer wg.Done()
+			for msg := range q {
+				results <- result{msg: msg, ok: c.handleMessage(ctx, msg)}
+			}
+		}(queues[i])
+	}
+
+	tr := newOffsetTracker()
+
+	// Messages already dispatched are finished before the claim is released, so their offsets
+	// can still be marked
+	defer func() {
+		for _, q := range queues {
+			close(q)
+		}
+		go func() {
+			wg.Wait()
+			close(results)
+		}()
+		for r := range results {
+			tr.complete(ss, r)
+		}
+	}()
+
+	for {
+		msgs := claim.Messages()
+		if tr.inFlight() >= c.cfg.MaxInFlight {
+			msgs = nil
+		}
+
+		select {
+		case msg, ok := <-msgs:
+			if !ok || msg == nil {
+				return nil
+			}
+
+			// Messages from retry topics wait for their tier delay, which keeps later ones on the partition waiting too
+			if err := awaitRetryAt(ctx, msg); err != nil {
+				return nil
+			}
+
+			tr.add(msg)
+			queues[queueOf(msg, len(queues))] <- msg
+
+		case r := <-results:
+			tr.complete(ss, r)
+
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// handleMessage processes a message and reports whether its offset may be marked. The offset is only
+// marked once the message is processed or safely on a retry or dead-letter topic.
+func (c *Consumer) handleMessage(ctx context.Context, msg *sarama.ConsumerMessage) bool {
+	if err := c.processMessage(ctx, msg); err != nil {
+		c.l.Error(ctx, "delivery.kafka.consumer.consumer.ConsumeClaim: %v", err,
+			"topic", msg.Topic,
+			"offset", msg.Offset,
+		)
+		if err := c.handleFailure(ctx, msg, err); err != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// queueOf picks the worker of a message from its ordering key
+func queueOf(msg *sarama.ConsumerMessage, n int) int {
+	h := fnv.New32a()
+	h.Write([]byte(orderingKey(msg)))
+	return int(h.Sum32() % uint32(n))
+}
+
+// orderingKey is the order code of a message, taken from the event data of both enveloped and bare
+// messages, or else the message key. Messages without either all go to the same worker.
+func orderingKey(msg *sarama.ConsumerMessage) string {
+	var body struct {
+		OrderCode string `json:"order_code"`
+		Data      struct {
+			OrderCode string `json:"order_code"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(msg.Value, &body); err == nil {
+		if body.Data.OrderCode != "" {
+			return body.Data.OrderCode
+		}
+		if body.OrderCode != "" {
+			return body.OrderCode
+		}
+	}
+
+	return string(msg.Key)
+}
+
+type inFlightMessage struct {
+	msg  *sarama.ConsumerMessage
+	done bool
+}
+
+// offsetTracker marks a message only once it and every earlier message of the claim are done, so a
+// restart never skips a message that was still being processed
+type offsetTracker struct {
+	pending  []*inFlightMessage
+	byOffset map[int64]*inFlightMessage
+	// stalled is set by a message that could not be handled, nothing after it is marked anymore
+	stalled bool
+}
+
+func newOffsetTracker() *offsetTracker {
+	return &offsetTracker{
+		byOffset: make(map[int64]*inFlightMessage),
+	}
+}
+
+func (t *offsetTracker) add(msg *sarama.ConsumerMessage) {
+	m := &inFlightMessage{msg: msg}
+	t.pending = append(t.pending, m)
+	t.byOffset[msg.Offset] = m
+}
+
+func (t *offsetTracker) inFlight() int {
+	return len(t.pending)
+}
+
+func (t *offsetTracker) complete(ss sarama.ConsumerGroupSession, r result) {
+	if !r.ok {
+		t.stalled = true
+		return
+	}
+
+	m, ok := t.byOffset[r.msg.Offset]
+	if !ok {
+		return
+	}
+	m.done = true
+	delete(t.byOffset, r.msg.Offset)
+
+	if t.stalled {
+		return
+	}
+
+	var last *sarama.ConsumerMessage
+	for len(t.pending) > 0 && t.pending[0].done {
+		last = t.pending[0].msg
+		t.pending[0] = nil
+		t.pending = t.pending[1:]
+	}
+	if last != nil {
+		ss.MarkMessage(last, "")
+	}
+}
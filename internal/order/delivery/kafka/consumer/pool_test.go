@@ -0,0 +1,167 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/vogiaan1904/ticketbottle-order/internal/order"
+	"github.com/vogiaan1904/ticketbottle-order/internal/order/delivery/kafka"
+	"github.com/vogiaan1904/ticketbottle-order/pkg/kafka/kafkatest"
+	"github.com/vogiaan1904/ticketbottle-order/pkg/logger"
+)
+
+// markSession records the offsets marked by the offset tracker
+type markSession struct {
+	sarama.ConsumerGroupSession
+	marked []int64
+}
+
+func (s *markSession) MarkMessage(msg *sarama.ConsumerMessage, metadata string) {
+	s.marked = append(s.marked, msg.Offset)
+}
+
+func TestOffsetTrackerMarksOnlyWhenEarlierMessagesAreDone(t *testing.T) {
+	ss := &markSession{}
+	tr := newOffsetTracker()
+
+	msgs := make([]*sarama.ConsumerMessage, 4)
+	for i := range msgs {
+		msgs[i] = &sarama.ConsumerMessage{Topic: kafka.TopicPaymentFailed, Offset: int64(i)}
+		tr.add(msgs[i])
+	}
+
+	steps := []struct {
+		done     int
+		marked   []int64
+		inFlight int
+	}{
+		{2, nil, 4},
+		{0, []int64{0}, 3},
+		{1, []int64{0, 2}, 1},
+		{3, []int64{0, 2, 3}, 0},
+	}
+	for _, st := range steps {
+		tr.complete(ss, result{msg: msgs[st.done], ok: true})
+		if !slices.Equal(ss.marked, st.marked) || tr.inFlight() != st.inFlight {
+			t.Fatalf("after offset %d: marked %v with %d in flight, want %v with %d", st.done, ss.marked, tr.inFlight(), st.marked, st.inFlight)
+		}
+	}
+}
+
+func TestOffsetTrackerStopsMarkingAfterUnhandledMessage(t *testing.T) {
+	ss := &markSession{}
+	tr := newOffsetTracker()
+
+	msgs := make([]*sarama.ConsumerMessage, 3)
+	for i := range msgs {
+		msgs[i] = &sarama.ConsumerMessage{Topic: kafka.TopicPaymentFailed, Offset: int64(i)}
+		tr.add(msgs[i])
+	}
+
+	tr.complete(ss, result{msg: msgs[0], ok: true})
+	tr.complete(ss, result{msg: msgs[1], ok: false})
+	tr.complete(ss, result{msg: msgs[2], ok: true})
+
+	// The unhandled message is fetched again after a restart, and everything after it with it
+	if !slices.Equal(ss.marked, []int64{0}) {
+		t.Fatalf("marked %v, want [0]", ss.marked)
+	}
+}
+
+// orderedService records the payment failures it handles per order, taking a random time for each
+type orderedService struct {
+	order.Service
+
+	mu   sync.Mutex
+	seen map[string][]string
+	n    int
+	done chan struct{}
+}
+
+func (s *orderedService) HandlePaymentFailed(ctx context.Context, in order.HandlePaymentFailedInput) error {
+	time.Sleep(time.Duration(rand.IntN(3)) * time.Millisecond)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.seen[in.OrderCode] = append(s.seen[in.OrderCode], in.Reason)
+	if s.n--; s.n == 0 {
+		close(s.done)
+	}
+	return nil
+}
+
+func TestConsumeClaimKeepsOrderPerKey(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	codes := []string{"ORD-A", "ORD-B", "ORD-C", "ORD-D"}
+	const perOrder = 20
+
+	// One partition, so only the worker pool spreads the orders
+	b := kafkatest.NewBroker(1)
+	p := b.NewSyncProducer()
+	for i := range perOrder {
+		for _, code := range codes {
+			payload, err := json.Marshal(kafka.PaymentFailedEvent{OrderCode: code, Reason: fmt.Sprintf("%02d", i)})
+			if err != nil {
+				t.Fatalf("Marshal: %v", err)
+			}
+			if _, _, err := p.SendMessage(&sarama.ProducerMessage{
+				Topic: kafka.TopicPaymentFailed,
+				Key:   sarama.StringEncoder(code),
+				Value: sarama.ByteEncoder(payload),
+			}); err != nil {
+				t.Fatalf("SendMessage: %v", err)
+			}
+		}
+	}
+
+	svc := &orderedService{seen: make(map[string][]string), n: len(codes) * perOrder, done: make(chan struct{})}
+	c := NewConsumer(b.NewConsumerGroup("order-service", sarama.OffsetOldest), p, svc, logger.InitializeTestZapLogger(),
+		Config{Workers: 4, MaxInFlight: 8})
+
+	consCtx, stop := context.WithCancel(ctx)
+	if err := c.Start(consCtx); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer func() {
+		stop()
+		c.Close()
+	}()
+
+	select {
+	case <-svc.done:
+	case <-ctx.Done():
+		t.Fatalf("not every message was handled")
+	}
+
+	svc.mu.Lock()
+	for _, code := range codes {
+		if got := svc.seen[code]; len(got) != perOrder || !slices.IsSorted(got) {
+			t.Errorf("order %s handled in order %v, want %d events in order", code, got, perOrder)
+		}
+	}
+	svc.mu.Unlock()
+
+	// Every message is committed once all of them were handled
+	want := int64(len(codes) * perOrder)
+	for {
+		if off, ok := b.CommittedOffset("order-service", kafka.TopicPaymentFailed, 0); ok && off == want {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			off, _ := b.CommittedOffset("order-service", kafka.TopicPaymentFailed, 0)
+			t.Fatalf("committed offset %d, want %d", off, want)
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
@@ -0,0 +1,233 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/vogiaan1904/ticketbottle-order/internal/order/delivery/kafka"
+)
+
+// ErrPoisonMessage marks a message that can never be processed, such as one that is not valid JSON.
+// Poison messages go straight to the dead-letter topic instead of through the retry topics.
+var ErrPoisonMessage = errors.New("poison message")
+
+// Headers added to retried and dead-lettered messages, next to the original headers
+const (
+	HeaderOriginalTopic     = "x-original-topic"
+	HeaderOriginalPartition = "x-original-partition"
+	HeaderOriginalOffset    = "x-original-offset"
+	HeaderAttempt           = "x-attempt"
+	HeaderError             = "x-error"
+	HeaderErrorClass        = "x-error-class"
+	HeaderRetryAt           = "x-retry-at"
+	HeaderFailedAt          = "x-failed-at"
+)
+
+const (
+	ErrorClassPoison    = "poison"
+	ErrorClassTransient = "transient"
+)
+
+type retryTier struct {
+	topic string
+	delay time.Duration
+}
+
+// retryTiers lists, per source topic, where the n-th failed attempt of a message goes.
+// A message that failed once more than there are tiers is dead-lettered.
+var retryTiers = map[string][]retryTier{
+	kafka.TopicPaymentCompleted: {
+		{topic: kafka.TopicPaymentCompletedRetry1m, delay: time.Minute},
+		{topic: kafka.TopicPaymentCompletedRetry10m, delay: 10 * time.Minute},
+	},
+	kafka.TopicPaymentFailed: {
+		{topic: kafka.TopicPaymentFailedRetry1m, delay: time.Minute},
+		{topic: kafka.TopicPaymentFailedRetry10m, delay: 10 * time.Minute},
+	},
+	kafka.TopicEventCancelled: {
+		{topic: kafka.TopicEventCancelledRetry1m, delay: time.Minute},
+		{topic: kafka.TopicEventCancelledRetry10m, delay: 10 * time.Minute},
+	},
+	kafka.TopicWaitroomSessionExpired: {
+		{topic: kafka.TopicWaitroomSessionExpiredRetry1m, delay: time.Minute},
+		{topic: kafka.TopicWaitroomSessionExpiredRetry10m, delay: 10 * time.Minute},
+	},
+}
+
+var dlqTopics = map[string]string{
+	kafka.TopicPaymentCompleted:       kafka.TopicPaymentCompletedDLQ,
+	kafka.TopicPaymentFailed:          kafka.TopicPaymentFailedDLQ,
+	kafka.TopicEventCancelled:         kafka.TopicEventCancelledDLQ,
+	kafka.TopicWaitroomSessionExpired: kafka.TopicWaitroomSessionExpiredDLQ,
+}
+
+// DLQTopics returns the dead-letter topic of every consumed topic
+func DLQTopics() []string {
+	topics := make([]string, 0, len(dlqTopics))
+	for _, t := range dlqTopics {
+		topics = append(topics, t)
+	}
+	return topics
+}
+
+// publishRetryInterval is how long to wait before publishing to a retry or dead-letter topic again
+const publishRetryInterval = 5 * time.Second
+
+// handleFailure moves a message that failed to process to its next retry topic, or to the
+// dead-letter topic when it is poison or out of attempts. It only returns once the message is
+// published, or with the context error when the session ends first.
+func (c *Consumer) handleFailure(ctx context.Context, msg *sarama.ConsumerMessage, procErr error) error {
+	src := sourceTopic(msg)
+	if _, ok := dlqTopics[src]; !ok {
+		c.l.Errorf(ctx, "delivery.kafka.consumer.retry.handleFailure: no dead-letter topic for %s, dropping message: %v", src, procErr)
+		return nil
+	}
+
+	attempt := attemptOf(msg) + 1
+	tiers := retryTiers[src]
+
+	var out *sarama.ProducerMessage
+	if errors.Is(procErr, ErrPoisonMessage) || attempt > len(tiers) {
+		out = failedMessage(msg, dlqTopics[src], attempt, procErr)
+		c.l.Warnf(ctx, "Dead-lettering message from %s at offset %d after %d attempt(s): %v", msg.Topic, msg.Offset, attempt, procErr)
+	} else {
+		tier := tiers[attempt-1]
+		out = failedMessage(msg, tier.topic, attempt, procErr)
+		out.Headers = append(out.Headers, sarama.RecordHeader{
+			Key:   []byte(HeaderRetryAt),
+			Value: []byte(time.Now().Add(tier.delay).Format(time.RFC3339)),
+		})
+		c.l.Infof(ctx, "Retrying message from %s at offset %d through %s", msg.Topic, msg.Offset, tier.topic)
+	}
+
+	for {
+		_, _, err := c.prod.SendMessage(out)
+		if err == nil {
+			return nil
+		}
+		c.l.Errorf(ctx, "delivery.kafka.consumer.retry.handleFailure.prod.SendMessage: %v", err)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(publishRetryInterval):
+		}
+	}
+}
+
+// awaitRetryAt holds a message from a retry topic back until its tier delay has passed
+func awaitRetryAt(ctx context.Context, msg *sarama.ConsumerMessage) error {
+	v, ok := header(msg, HeaderRetryAt)
+	if !ok {
+		return nil
+	}
+
+	at, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return nil
+	}
+
+	wait := time.Until(at)
+	if wait <= 0 {
+		return nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(wait):
+		return nil
+	}
+}
+
+// failedMessage copies msg to topic with its original headers and the failure details
+func failedMessage(msg *sarama.ConsumerMessage, topic string, attempt int, procErr error) *sarama.ProducerMessage {
+	class := ErrorClassTransient
+	if errors.Is(procErr, ErrPoisonMessage) {
+		class = ErrorClassPoison
+	}
+
+	partition := strconv.Itoa(int(msg.Partition))
+	offset := strconv.FormatInt(msg.Offset, 10)
+	if v, ok := header(msg, HeaderOriginalPartition); ok {
+		partition = v
+	}
+	if v, ok := header(msg, HeaderOriginalOffset); ok {
+		offset = v
+	}
+
+	hdrs := append(originalHeaders(msg),
+		sarama.RecordHeader{Key: []byte(HeaderOriginalTopic), Value: []byte(sourceTopic(msg))},
+		sarama.RecordHeader{Key: []byte(HeaderOriginalPartition), Value: []byte(partition)},
+		sarama.RecordHeader{Key: []byte(HeaderOriginalOffset), Value: []byte(offset)},
+		sarama.RecordHeader{Key: []byte(HeaderAttempt), Value: []byte(strconv.Itoa(attempt))},
+		sarama.RecordHeader{Key: []byte(HeaderError), Value: []byte(procErr.Error())},
+		sarama.RecordHeader{Key: []byte(HeaderErrorClass), Value: []byte(class)},
+		sarama.RecordHeader{Key: []byte(HeaderFailedAt), Value: []byte(time.Now().Format(time.RFC3339))},
+	)
+
+	out := &sarama.ProducerMessage{
+		Topic:   topic,
+		Value:   sarama.ByteEncoder(msg.Value),
+		Headers: hdrs,
+	}
+	if msg.Key != nil {
+		out.Key = sarama.ByteEncoder(msg.Key)
+	}
+
+	return out
+}
+
+// originalHeaders returns the headers of msg without the ones added by the retry pipeline
+func originalHeaders(msg *sarama.ConsumerMessage) []sarama.RecordHeader {
+	hdrs := make([]sarama.RecordHeader, 0, len(msg.Headers))
+	for _, h := range msg.Headers {
+		if h == nil || isPipelineHeader(string(h.Key)) {
+			continue
+		}
+		hdrs = append(hdrs, *h)
+	}
+	return hdrs
+}
+
+func isPipelineHeader(key string) bool {
+	switch key {
+	case HeaderOriginalTopic, HeaderOriginalPartition, HeaderOriginalOffset, HeaderAttempt,
+		HeaderError, HeaderErrorClass, HeaderRetryAt, HeaderFailedAt:
+		return true
+	default:
+		return false
+	}
+}
+
+// sourceTopic is the topic a message was first published to
+func sourceTopic(msg *sarama.ConsumerMessage) string {
+	if v, ok := header(msg, HeaderOriginalTopic); ok {
+		return v
+	}
+	return msg.Topic
+}
+
+func attemptOf(msg *sarama.ConsumerMessage) int {
+	v, ok := header(msg, HeaderAttempt)
+	if !ok {
+		return 0
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+func header(msg *sarama.ConsumerMessage, key string) (string, bool) {
+	for _, h := range msg.Headers {
+		if h != nil && string(h.Key) == key {
+			return string(h.Value), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,100 @@
+package kafka
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SpecVersion is the CloudEvents version the envelope follows
+const SpecVersion = "1.0"
+
+// EventSource identifies this service as the producer of an event
+const EventSource = "ticketbottle-order"
+
+// ContentTypeCloudEvents is the content-type header of an enveloped message
+const ContentTypeCloudEvents = "application/cloudevents+json"
+
+const (
+	EventTypePaymentCompleted   = "ticketbottle.payment.completed"
+	EventTypePaymentFailed      = "ticketbottle.payment.failed"
+	EventTypeEventCancelled     = "ticketbottle.event.cancelled"
+	EventTypeSessionExpired     = "ticketbottle.waitroom.session.expired"
+	EventTypeCheckoutCompleted  = "ticketbottle.checkout.completed"
+	EventTypeCheckoutFailed     = "ticketbottle.checkout.failed"
+	EventTypeOrderCreated       = "ticketbottle.order.created"
+	EventTypeOrderCompleted     = "ticketbottle.order.completed"
+	EventTypeOrderCancelled     = "ticketbottle.order.cancelled"
+	EventTypeOrderPaymentFailed = "ticketbottle.order.payment_failed"
+	EventTypeOrderTimedOut      = "ticketbottle.order.timed_out"
+	EventTypeOrderRefunded      = "ticketbottle.order.refunded"
+)
+
+// SchemaVersionV1 is the schema of the data structs in presenter.go, also the schema of the legacy
+// bare messages published before the envelope existed
+const SchemaVersionV1 = "1"
+
+var ErrInvalidEnvelope = errors.New("invalid event envelope")
+
+// Envelope wraps every message produced and consumed by the service
+type Envelope struct {
+	ID              string `json:"id"`
+	Type            string `json:"type"`
+	Source          string `json:"source"`
+	SpecVersion     string `json:"specversion"`
+	SchemaVersion   string `json:"schemaversion"`
+	Time            string `json:"time"`
+	CorrelationID   string `json:"correlationid,omitempty"`
+	DataContentType string `json:"datacontenttype"`
+	// Legacy is set on a bare message wrapped by ParseEnvelope, it has no ID, source or time
+	Legacy bool            `json:"-"`
+	Data   json.RawMessage `json:"data"`
+	// Topic is the topic the message was consumed from, set by the consumer
+	Topic string `json:"-"`
+}
+
+// NewEnvelope wraps data in a new envelope sent by this service at t
+func NewEnvelope(typ string, schemaVersion string, correlationID string, t time.Time, data any) (Envelope, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return Envelope{}, err
+	}
+
+	return Envelope{
+		ID:              uuid.NewString(),
+		Type:            typ,
+		Source:          EventSource,
+		SpecVersion:     SpecVersion,
+		SchemaVersion:   schemaVersion,
+		Time:            t.UTC().Format(time.RFC3339Nano),
+		CorrelationID:   correlationID,
+		DataContentType: "application/json",
+		Data:            raw,
+	}, nil
+}
+
+// ParseEnvelope decodes an enveloped message. A bare message, as published before the envelope
+// existed, is wrapped as legacyType at SchemaVersionV1.
+func ParseEnvelope(b []byte, legacyType string) (Envelope, error) {
+	var e Envelope
+	if err := json.Unmarshal(b, &e); err != nil {
+		return Envelope{}, err
+	}
+
+	if e.SpecVersion == "" {
+		return Envelope{
+			Type:          legacyType,
+			SchemaVersion: SchemaVersionV1,
+			Legacy:        true,
+			Data:          b,
+		}, nil
+	}
+
+	if e.Type == "" || e.SchemaVersion == "" || len(e.Data) == 0 {
+		return Envelope{}, ErrInvalidEnvelope
+	}
+
+	return e, nil
+}
@@ -1,5 +1,10 @@
 package kafka
 
+import (
+	"github.com/vogiaan1904/ticketbottle-order/internal/models"
+	"github.com/vogiaan1904/ticketbottle-order/pkg/util"
+)
+
 type PaymentCompletedEvent struct {
 	OrderCode     string `json:"order_code"`
 	PaidAt        string `json:"paid_at,omitempty"`
@@ -18,8 +23,23 @@ type PaymentFailedEvent struct {
 	Currency      string `json:"currency"`
 	Provider      string `json:"provider"`
 	TransactionID string `json:"transaction_id"`
+	Reason        string `json:"reason,omitempty"`
 	FailedAt      string `json:"failed_at"`
 }
+
+type EventCancelledEvent struct {
+	EventID     string `json:"event_id"`
+	Reason      string `json:"reason"`
+	CancelledAt string `json:"cancelled_at"`
+}
+
+type SessionExpiredEvent struct {
+	SessionID string `json:"session_id"`
+	UserID    string `json:"user_id"`
+	EventID   string `json:"event_id"`
+	ExpiredAt string `json:"expired_at"`
+}
+
 type CheckoutCompletedEvent struct {
 	SessionID string `json:"session_id"`
 	UserID    string `json:"user_id"`
@@ -33,3 +53,140 @@ type CheckoutFailedEvent struct {
 	EventID   string `json:"event_id"`
 	Timestamp string `json:"timestamp"`
 }
+
+type OrderRefundedEvent struct {
+	OrderID     string              `json:"order_id"`
+	OrderCode   string              `json:"order_code"`
+	RefundID    string              `json:"refund_id"`
+	UserID      string              `json:"user_id"`
+	EventID     string              `json:"event_id"`
+	Status      string              `json:"status"`
+	AmountCents int64               `json:"amount_cents"`
+	Currency    string              `json:"currency"`
+	Reason      string              `json:"reason"`
+	Items       []OrderRefundedItem `json:"items"`
+	// Order and Transition give order.refunded the same shape as the other lifecycle events
+	Order      *OrderPayload    `json:"order,omitempty"`
+	Transition *OrderTransition `json:"transition,omitempty"`
+	Timestamp  string           `json:"timestamp"`
+}
+
+type OrderRefundedItem struct {
+	TicketClassID string `json:"ticket_class_id"`
+	Quantity      int32  `json:"quantity"`
+}
+
+// OrderEvent is the data of every order lifecycle event: the order as it is after the transition
+type OrderEvent struct {
+	Order      OrderPayload    `json:"order"`
+	Transition OrderTransition `json:"transition"`
+	Timestamp  string          `json:"timestamp"`
+}
+
+type OrderPayload struct {
+	ID             string             `json:"id"`
+	Code           string             `json:"code"`
+	SessionID      string             `json:"session_id,omitempty"`
+	UserID         string             `json:"user_id"`
+	UserFullName   string             `json:"user_full_name"`
+	Email          string             `json:"email"`
+	Phone          string             `json:"phone"`
+	EventID        string             `json:"event_id"`
+	TotalAmount    int64              `json:"total_amount"`
+	Currency       string             `json:"currency"`
+	PaymentMethod  string             `json:"payment_method"`
+	PaymentID      string             `json:"payment_id,omitempty"`
+	TransactionID  string             `json:"transaction_id,omitempty"`
+	Status         string             `json:"status"`
+	PaidAt         string             `json:"paid_at,omitempty"`
+	RefundedAmount int64              `json:"refunded_amount"`
+	RefundReason   string             `json:"refund_reason,omitempty"`
+	RefundedAt     string             `json:"refunded_at,omitempty"`
+	FailureReason  string             `json:"failure_reason,omitempty"`
+	Items          []OrderItemPayload `json:"items"`
+	CreatedAt      string             `json:"created_at"`
+	UpdatedAt      string             `json:"updated_at"`
+}
+
+type OrderItemPayload struct {
+	ID               string `json:"id"`
+	TicketClassID    string `json:"ticket_class_id"`
+	TicketClassName  string `json:"ticket_class_name"`
+	PriceAtPurchase  int64  `json:"price_at_purchase"`
+	Quantity         int32  `json:"quantity"`
+	TotalAmount      int64  `json:"total_amount"`
+	RefundedQuantity int32  `json:"refunded_quantity"`
+}
+
+// OrderTransition is the status change an event announces, From is empty for order.created
+type OrderTransition struct {
+	From   string `json:"from,omitempty"`
+	To     string `json:"to"`
+	Reason string `json:"reason,omitempty"`
+}
+
+func NewOrderPayload(o models.Order, itms []models.OrderItem) OrderPayload {
+	p := OrderPayload{
+		ID:             o.ID.Hex(),
+		Code:           o.Code,
+		SessionID:      o.SessionID,
+		UserID:         o.UserID,
+		UserFullName:   o.UserFullName,
+		Email:          o.Email,
+		Phone:          o.Phone,
+		EventID:        o.EventID,
+		TotalAmount:    o.TotalAmount,
+		Currency:       o.Currency,
+		PaymentMethod:  string(o.PaymentMethod),
+		PaymentID:      o.PaymentID,
+		TransactionID:  o.TransactionID,
+		Status:         string(o.Status),
+		RefundedAmount: o.RefundedAmount,
+		RefundReason:   o.RefundReason,
+		FailureReason:  o.FailureReason,
+		Items:          make([]OrderItemPayload, len(itms)),
+		CreatedAt:      util.TimeToISO8601Str(o.CreatedAt.UTC()),
+		UpdatedAt:      util.TimeToISO8601Str(o.UpdatedAt.UTC()),
+	}
+	if o.PaidAt != nil {
+		p.PaidAt = util.TimeToISO8601Str(o.PaidAt.UTC())
+	}
+	if o.RefundedAt != nil {
+		p.RefundedAt = util.TimeToISO8601Str(o.RefundedAt.UTC())
+	}
+
+	for i, itm := range itms {
+		p.Items[i] = OrderItemPayload{
+			ID:               itm.ID.Hex(),
+			TicketClassID:    itm.TicketClassID,
+			TicketClassName:  itm.TicketClassName,
+			PriceAtPurchase:  itm.PriceAtPurchase,
+			Quantity:         itm.Quantity,
+			TotalAmount:      itm.TotalAmount,
+			RefundedQuantity: itm.RefundedQuantity,
+		}
+	}
+
+	return p
+}
+
+type orderEventRoute struct {
+	topic string
+	typ   string
+}
+
+// orderEventRoutes maps the status an order moved to onto its lifecycle topic
+var orderEventRoutes = map[models.OrderStatus]orderEventRoute{
+	models.OrderStatusPending:       {topic: TopicOrderCreated, typ: EventTypeOrderCreated},
+	models.OrderStatusCompleted:     {topic: TopicOrderCompleted, typ: EventTypeOrderCompleted},
+	models.OrderStatusCancelled:     {topic: TopicOrderCancelled, typ: EventTypeOrderCancelled},
+	models.OrderStatusPaymentFailed: {topic: TopicOrderPaymentFailed, typ: EventTypeOrderPaymentFailed},
+	models.OrderStatusTimeout:       {topic: TopicOrderTimedOut, typ: EventTypeOrderTimedOut},
+}
+
+// OrderEventRoute returns the topic and event type of an order that moved to status, refunds have
+// their own event, see OrderRefundedEvent
+func OrderEventRoute(status models.OrderStatus) (topic string, typ string, ok bool) {
+	r, ok := orderEventRoutes[status]
+	return r.topic, r.typ, ok
+}
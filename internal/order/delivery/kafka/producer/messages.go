@@ -0,0 +1,75 @@
+package producer
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/vogiaan1904/ticketbottle-order/internal/models"
+	kafka "github.com/vogiaan1904/ticketbottle-order/internal/order/delivery/kafka"
+	"github.com/vogiaan1904/ticketbottle-order/pkg/util"
+)
+
+func newCheckoutCompletedMessage(event kafka.CheckoutCompletedEvent) (*sarama.ProducerMessage, error) {
+	now := time.Now()
+	event.Timestamp = util.TimeToISO8601Str(now.UTC())
+
+	return newMessage(kafka.TopicCheckoutCompleted, event.EventID, kafka.EventTypeCheckoutCompleted, event.SessionID, now, event)
+}
+
+func newCheckoutFailedMessage(event kafka.CheckoutFailedEvent) (*sarama.ProducerMessage, error) {
+	now := time.Now()
+	event.Timestamp = util.TimeToISO8601Str(now.UTC())
+
+	return newMessage(kafka.TopicCheckoutFailed, event.EventID, kafka.EventTypeCheckoutFailed, event.SessionID, now, event)
+}
+
+func newOrderRefundedMessage(event kafka.OrderRefundedEvent) (*sarama.ProducerMessage, error) {
+	now := time.Now()
+	event.Timestamp = util.TimeToISO8601Str(now.UTC())
+
+	return newMessage(kafka.TopicOrderRefunded, event.OrderCode, kafka.EventTypeOrderRefunded, event.OrderCode, now, event)
+}
+
+func newOrderEventMessage(event kafka.OrderEvent) (*sarama.ProducerMessage, error) {
+	topic, typ, ok := kafka.OrderEventRoute(models.OrderStatus(event.Transition.To))
+	if !ok {
+		return nil, fmt.Errorf("no order lifecycle topic for status %s", event.Transition.To)
+	}
+
+	now := time.Now()
+	event.Timestamp = util.TimeToISO8601Str(now.UTC())
+
+	return newMessage(topic, event.Order.Code, typ, event.Order.Code, now, event)
+}
+
+// newMessage wraps data in an envelope at the current schema version. Checkout events are
+// correlated by waitroom session, order events by order code.
+func newMessage(topic, key, typ, correlationID string, t time.Time, data any) (*sarama.ProducerMessage, error) {
+	env, err := kafka.NewEnvelope(typ, kafka.SchemaVersionV1, correlationID, t, data)
+	if err != nil {
+		return nil, err
+	}
+
+	val, err := json.Marshal(env)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sarama.ProducerMessage{
+		Topic: topic,
+		Key:   sarama.StringEncoder(key),
+		Value: sarama.ByteEncoder(val),
+		Headers: []sarama.RecordHeader{
+			{
+				Key:   []byte("content-type"),
+				Value: []byte(kafka.ContentTypeCloudEvents),
+			},
+			{
+				Key:   []byte("timestamp"),
+				Value: []byte(t.Format(time.RFC3339)),
+			},
+		},
+	}, nil
+}
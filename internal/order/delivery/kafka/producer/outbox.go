@@ -0,0 +1,106 @@
+package producer
+
+import (
+	"context"
+
+	"github.com/IBM/sarama"
+	kafka "github.com/vogiaan1904/ticketbottle-order/internal/order/delivery/kafka"
+	outboxRepo "github.com/vogiaan1904/ticketbottle-order/internal/outbox/repository"
+	"github.com/vogiaan1904/ticketbottle-order/pkg/logger"
+)
+
+type outboxProducer struct {
+	l    logger.Logger
+	repo outboxRepo.Repository
+}
+
+// NewOutboxProducer returns a Producer that writes messages to the outbox. Publishing with a context
+// from Repository.WithTransaction commits the message together with the rest of the transaction.
+func NewOutboxProducer(repo outboxRepo.Repository, l logger.Logger) Producer {
+	return &outboxProducer{
+		l:    l,
+		repo: repo,
+	}
+}
+
+func (p *outboxProducer) PublishCheckoutCompleted(ctx context.Context, event kafka.CheckoutCompletedEvent) error {
+	msg, err := newCheckoutCompletedMessage(event)
+	if err != nil {
+		p.l.Errorf(ctx, "order.delivery.kafka.producer.outbox.PublishCheckoutCompleted: %v", err)
+		return err
+	}
+
+	return p.store(ctx, msg)
+}
+
+func (p *outboxProducer) PublishCheckoutFailed(ctx context.Context, event kafka.CheckoutFailedEvent) error {
+	msg, err := newCheckoutFailedMessage(event)
+	if err != nil {
+		p.l.Errorf(ctx, "order.delivery.kafka.producer.outbox.PublishCheckoutFailed: %v", err)
+		return err
+	}
+
+	return p.store(ctx, msg)
+}
+
+func (p *outboxProducer) PublishOrderRefunded(ctx context.Context, event kafka.OrderRefundedEvent) error {
+	msg, err := newOrderRefundedMessage(event)
+	if err != nil {
+		p.l.Errorf(ctx, "order.delivery.kafka.producer.outbox.PublishOrderRefunded: %v", err)
+		return err
+	}
+
+	return p.store(ctx, msg)
+}
+
+func (p *outboxProducer) PublishOrderEvent(ctx context.Context, event kafka.OrderEvent) error {
+	msg, err := newOrderEventMessage(event)
+	if err != nil {
+		p.l.Errorf(ctx, "order.delivery.kafka.producer.outbox.PublishOrderEvent: %v", err)
+		return err
+	}
+
+	return p.store(ctx, msg)
+}
+
+func (p *outboxProducer) Mode() Mode {
+	return ModeOutbox
+}
+
+func (p *outboxProducer) Close() error {
+	return nil
+}
+
+func (p *outboxProducer) store(ctx context.Context, msg *sarama.ProducerMessage) error {
+	opt := outboxRepo.CreateOutboxMessageOption{
+		Topic:   msg.Topic,
+		Headers: make(map[string]string, len(msg.Headers)),
+	}
+
+	if msg.Key != nil {
+		key, err := msg.Key.Encode()
+		if err != nil {
+			p.l.Errorf(ctx, "order.delivery.kafka.producer.outbox.store: %v", err)
+			return err
+		}
+		opt.Key = string(key)
+	}
+
+	val, err := msg.Value.Encode()
+	if err != nil {
+		p.l.Errorf(ctx, "order.delivery.kafka.producer.outbox.store: %v", err)
+		return err
+	}
+	opt.Payload = val
+
+	for _, h := range msg.Headers {
+		opt.Headers[string(h.Key)] = string(h.Value)
+	}
+
+	if _, err := p.repo.Create(ctx, opt); err != nil {
+		p.l.Errorf(ctx, "order.delivery.kafka.producer.outbox.store.repo.Create: %v", err)
+		return err
+	}
+
+	return nil
+}
@@ -2,22 +2,35 @@ package producer
 
 import (
 	"context"
-	"encoding/json"
-	"time"
 
 	"github.com/IBM/sarama"
 	kafka "github.com/vogiaan1904/ticketbottle-order/internal/order/delivery/kafka"
 	"github.com/vogiaan1904/ticketbottle-order/pkg/logger"
-	"github.com/vogiaan1904/ticketbottle-order/pkg/util"
 )
 
 type Producer interface {
 	PublishCheckoutCompleted(ctx context.Context, event kafka.CheckoutCompletedEvent) error
 	PublishCheckoutFailed(ctx context.Context, event kafka.CheckoutFailedEvent) error
+	PublishOrderRefunded(ctx context.Context, event kafka.OrderRefundedEvent) error
+	// PublishOrderEvent publishes to the lifecycle topic of the status the order moved to
+	PublishOrderEvent(ctx context.Context, event kafka.OrderEvent) error
+
+	// Mode tells whether messages go straight to Kafka or through the outbox
+	Mode() Mode
 
 	Close() error
 }
 
+type Mode string
+
+const (
+	// ModeDirect publishes to Kafka right away
+	ModeDirect Mode = "direct"
+	// ModeOutbox writes messages to the outbox collection, joining the Mongo transaction carried by
+	// the context, and leaves publishing to the outbox relay
+	ModeOutbox Mode = "outbox"
+)
+
 type implProducer struct {
 	l    logger.Logger
 	prod sarama.SyncProducer
@@ -30,58 +43,58 @@ func NewProducer(prod sarama.SyncProducer, l logger.Logger) Producer {
 	}
 }
 
-func (p implProducer) PublishCheckoutCompleted(ctx context.Context, event kafka.CheckoutCompletedEvent) error {
-	event.Timestamp = util.TimeToISO8601Str(time.Now())
-	val, err := json.Marshal(event)
+func (p *implProducer) PublishCheckoutCompleted(ctx context.Context, event kafka.CheckoutCompletedEvent) error {
+	msg, err := newCheckoutCompletedMessage(event)
 	if err != nil {
 		p.l.Errorf(ctx, "order.delivery.kafka.producer.PublishCheckoutCompleted: %v", err)
 		return err
 	}
 
-	msg := &sarama.ProducerMessage{
-		Topic: kafka.TopicCheckoutCompleted,
-		Key:   sarama.StringEncoder(event.EventID),
-		Value: sarama.ByteEncoder(val),
-		Headers: []sarama.RecordHeader{
-			{
-				Key:   []byte("timestamp"),
-				Value: []byte(time.Now().Format(time.RFC3339)),
-			},
-		},
-	}
-
 	_, _, err = p.prod.SendMessage(msg)
 	return err
 }
 
-func (p *implProducer) Close() error {
-	if err := p.prod.Close(); err != nil {
+func (p *implProducer) PublishCheckoutFailed(ctx context.Context, event kafka.CheckoutFailedEvent) error {
+	msg, err := newCheckoutFailedMessage(event)
+	if err != nil {
+		p.l.Errorf(ctx, "order.delivery.kafka.producer.publishCheckoutFailed: %v", err)
 		return err
 	}
 
-	return nil
+	_, _, err = p.prod.SendMessage(msg)
+	return err
 }
 
-func (p *implProducer) PublishCheckoutFailed(ctx context.Context, event kafka.CheckoutFailedEvent) error {
-	event.Timestamp = time.Now().String()
-	val, err := json.Marshal(event)
+func (p *implProducer) PublishOrderRefunded(ctx context.Context, event kafka.OrderRefundedEvent) error {
+	msg, err := newOrderRefundedMessage(event)
 	if err != nil {
-		p.l.Errorf(ctx, "order.delivery.kafka.producer.publishCheckoutFailed: %v", err)
+		p.l.Errorf(ctx, "order.delivery.kafka.producer.PublishOrderRefunded: %v", err)
 		return err
 	}
 
-	msg := &sarama.ProducerMessage{
-		Topic: kafka.TopicCheckoutFailed,
-		Key:   sarama.StringEncoder(event.EventID),
-		Value: sarama.ByteEncoder(val),
-		Headers: []sarama.RecordHeader{
-			{
-				Key:   []byte("timestamp"),
-				Value: []byte(time.Now().Format(time.RFC3339)),
-			},
-		},
+	_, _, err = p.prod.SendMessage(msg)
+	return err
+}
+
+func (p *implProducer) PublishOrderEvent(ctx context.Context, event kafka.OrderEvent) error {
+	msg, err := newOrderEventMessage(event)
+	if err != nil {
+		p.l.Errorf(ctx, "order.delivery.kafka.producer.PublishOrderEvent: %v", err)
+		return err
 	}
 
 	_, _, err = p.prod.SendMessage(msg)
 	return err
 }
+
+func (p *implProducer) Mode() Mode {
+	return ModeDirect
+}
+
+func (p *implProducer) Close() error {
+	if err := p.prod.Close(); err != nil {
+		return err
+	}
+
+	return nil
+}
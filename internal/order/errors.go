@@ -1,16 +1,32 @@
 package order
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+
+	"github.com/vogiaan1904/ticketbottle-order/internal/models"
+	"github.com/vogiaan1904/ticketbottle-order/pkg/paginator"
+)
 
 var (
-	ErrOrderNotFound           = errors.New("order not found")
-	ErrOrderAlreadyExists      = errors.New("order already exists")
-	ErrInvalidOrderStatus      = errors.New("invalid order status")
-	ErrOrderCreationFailed     = errors.New("order creation failed")
-	ErrOrderUpdateFailed       = errors.New("order update failed")
-	ErrOrderCancellationFailed = errors.New("order cancellation failed")
-	ErrOrderNotPending         = errors.New("order is not in pending status")
-	ErrPaymentAmountMismatch   = errors.New("payment amount does not match order amount")
+	ErrOrderNotFound               = errors.New("order not found")
+	ErrOrderAlreadyExists          = errors.New("order already exists")
+	ErrInvalidOrderStatus          = errors.New("invalid order status")
+	ErrOrderCreationFailed         = errors.New("order creation failed")
+	ErrOrderUpdateFailed           = errors.New("order update failed")
+	ErrOrderCancellationFailed     = errors.New("order cancellation failed")
+	ErrOrderCancellationInProgress = errors.New("order cancellation is in progress")
+	ErrOrderNotPending             = errors.New("order is not in pending status")
+	ErrOrderNotOwned               = errors.New("order does not belong to the user")
+	ErrOrderNotRefundable          = errors.New("order is not refundable")
+	ErrOrderRefundFailed           = errors.New("order refund failed")
+	ErrRefundsDisabled             = errors.New("refunds are disabled")
+	ErrInvalidRefundItems          = errors.New("invalid refund items")
+	ErrPaymentAmountMismatch       = errors.New("payment amount does not match order amount")
+	ErrOrderAlreadyPaid            = errors.New("order is already paid")
+	ErrOrderConflict               = errors.New("order was changed concurrently")
+	ErrInvalidStatusTransition     = errors.New("invalid order status transition")
+	ErrInvalidCursor               = paginator.ErrInvalidCursor
 
 	ErrEventNotFound        = errors.New("event not found")
 	ErrEventNotReadyForSale = errors.New("event not ready for sale")
@@ -21,3 +37,35 @@ var (
 
 	ErrInvalidCheckoutToken = errors.New("invalid checkout token")
 )
+
+// ConflictError is returned by an order update that lost a race: the order is no longer in the
+// status or at the version the update was based on. Reading the order again and retrying resolves it.
+type ConflictError struct {
+	OrderID string
+	Status  models.OrderStatus
+	Version int64
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%v: order %s is no longer %s at version %d", ErrOrderConflict, e.OrderID, e.Status, e.Version)
+}
+
+func (e *ConflictError) Unwrap() error {
+	return ErrOrderConflict
+}
+
+// TransitionError is returned by an order update to a status the order cannot move to from its
+// current one, retrying does not help
+type TransitionError struct {
+	OrderID string
+	From    models.OrderStatus
+	To      models.OrderStatus
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("%v: order %s cannot move from %s to %s", ErrInvalidStatusTransition, e.OrderID, e.From, e.To)
+}
+
+func (e *TransitionError) Unwrap() error {
+	return ErrInvalidStatusTransition
+}
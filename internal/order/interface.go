@@ -8,11 +8,13 @@ import (
 
 type Service interface {
 	Create(ctx context.Context, in CreateOrderInput) (CreateOrderOutput, error)
-	Cancel(ctx context.Context, ID string) error
+	Cancel(ctx context.Context, in CancelOrderInput) error
+	Refund(ctx context.Context, in RefundOrderInput) (models.Order, error)
+	GetCheckoutStatus(ctx context.Context, code string) (models.CheckoutStatus, error)
 	GetByID(ctx context.Context, ID string) (models.Order, error)
 	GetOne(ctx context.Context, in GetOneOrderInput) (models.Order, error)
 	GetMany(ctx context.Context, in GetManyOrderInput) (GetManyOrderOutput, error)
-	List(ctx context.Context, in ListOrderInput) ([]models.Order, error)
+	List(ctx context.Context, in ListOrderInput) (ListOrderOutput, error)
 
 	Consumer
 }
@@ -20,4 +22,6 @@ type Service interface {
 type Consumer interface {
 	HandlePaymentCompleted(ctx context.Context, in HandlePaymentCompletedInput) error
 	HandlePaymentFailed(ctx context.Context, in HandlePaymentFailedInput) error
+	HandleEventCancelled(ctx context.Context, in HandleEventCancelledInput) error
+	HandleSessionExpired(ctx context.Context, in HandleSessionExpiredInput) error
 }
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/vogiaan1904/ticketbottle-order/internal/models"
+	"github.com/vogiaan1904/ticketbottle-order/internal/order"
+	"github.com/vogiaan1904/ticketbottle-order/pkg/paginator"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// pageCursor is the content of the opaque cursors of GetMany and List. A Prev cursor pages towards
+// newer orders, the others continue towards older ones.
+type pageCursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        string    `json:"i"`
+	Prev      bool      `json:"p,omitempty"`
+}
+
+func newPageCursor(o models.Order, prev bool) (string, error) {
+	return paginator.EncodeCursor(pageCursor{
+		CreatedAt: o.CreatedAt,
+		ID:        o.ID.Hex(),
+		Prev:      prev,
+	})
+}
+
+// decodePageCursor returns order.ErrInvalidCursor for a cursor that was not made by newPageCursor
+func decodePageCursor(s string) (pageCursor, error) {
+	var c pageCursor
+	if err := paginator.DecodeCursor(s, &c); err != nil {
+		return pageCursor{}, order.ErrInvalidCursor
+	}
+
+	if c.CreatedAt.IsZero() || !primitive.IsValidObjectID(c.ID) {
+		return pageCursor{}, order.ErrInvalidCursor
+	}
+
+	return c, nil
+}
+
+func (c pageCursor) listCursor() ListOrderCursor {
+	return ListOrderCursor{CreatedAt: c.CreatedAt, ID: c.ID}
+}
+
+// EncodeListCursor is the opaque cursor continuing a List behind o
+func EncodeListCursor(o models.Order) (string, error) {
+	return newPageCursor(o, false)
+}
+
+// DecodeListCursor reads a cursor made by EncodeListCursor, or the next cursor of GetMany, and
+// returns order.ErrInvalidCursor for anything else
+func DecodeListCursor(s string) (ListOrderCursor, error) {
+	c, err := decodePageCursor(s)
+	if err != nil {
+		return ListOrderCursor{}, err
+	}
+
+	if c.Prev {
+		return ListOrderCursor{}, order.ErrInvalidCursor
+	}
+
+	return c.listCursor(), nil
+}
@@ -10,15 +10,23 @@ import (
 type Repository interface {
 	OrderRepository
 	OrderItemRepository
+	OrderRefundRepository
+
+	// WithTransaction runs fn in a Mongo transaction. Repository calls made with the ctx passed to fn,
+	// including other repositories on the same database, join it, and so does a nested WithTransaction.
+	// Requires a replica set.
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
 type OrderRepository interface {
 	Create(ctx context.Context, opt CreateOrderOption) (models.Order, error)
 	GetByID(ctx context.Context, ID string) (models.Order, error)
 	GetOne(ctx context.Context, opt GetOneOrderOption) (models.Order, error)
-	GetMany(ctx context.Context, opt GetManyOrderOption) ([]models.Order, paginator.Paginator, error)
+	GetMany(ctx context.Context, opt GetManyOrderOption) ([]models.Order, paginator.CursorPaginator, error)
 	List(ctx context.Context, opt ListOrderOption) ([]models.Order, error)
 	Update(ctx context.Context, ID string, opt UpdateOrderOption) (models.Order, error)
+	UpdatePaymentID(ctx context.Context, ID string, paymentID string) error
+	UpdatePayment(ctx context.Context, ID string, opt UpdateOrderPaymentOption) error
 	Delete(ctx context.Context, ID string) error
 }
 
@@ -26,4 +34,12 @@ type OrderItemRepository interface {
 	CreateManyItems(ctx context.Context, ordID string, opts []CreateOrderItemOption) ([]models.OrderItem, error)
 	ListItemByOrderID(ctx context.Context, ordID string) ([]models.OrderItem, error)
 	DeleteItemByOrderID(ctx context.Context, ordID string) error
+	UpdateItemRefundedQuantity(ctx context.Context, ID string, qty int32) error
+}
+
+type OrderRefundRepository interface {
+	CreateRefund(ctx context.Context, opt CreateOrderRefundOption) (models.OrderRefund, error)
+	GetRefundByID(ctx context.Context, ID string) (models.OrderRefund, error)
+	ListRefundByOrderID(ctx context.Context, ordID string) ([]models.OrderRefund, error)
+	UpdateRefund(ctx context.Context, ID string, opt UpdateOrderRefundOption) (models.OrderRefund, error)
 }
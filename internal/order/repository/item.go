@@ -96,3 +96,27 @@ func (r *implRepository) DeleteItemByOrderID(ctx context.Context, ordID string)
 
 	return nil
 }
+
+func (r *implRepository) UpdateItemRefundedQuantity(ctx context.Context, ID string, qty int32) error {
+	col := r.getOrderItemCollection()
+
+	fil, err := r.buildGetByIDQuery(ctx, ID)
+	if err != nil {
+		r.l.Errorf(ctx, "order.repository.OrderItemRepository.UpdateItemRefundedQuantity: %v", err)
+		return err
+	}
+
+	upDoc := bson.M{
+		"$set": bson.M{
+			"refunded_quantity": qty,
+			"updated_at":        r.clock(),
+		},
+	}
+
+	if _, err := col.UpdateOne(ctx, fil, upDoc); err != nil {
+		r.l.Errorf(ctx, "order.repository.OrderItemRepository.UpdateItemRefundedQuantity: %v", err)
+		return err
+	}
+
+	return nil
+}
@@ -14,6 +14,7 @@ func (r *implRepository) buildOrderItemModel(ordID string, opt CreateOrderItemOp
 		TicketClassName: opt.TicketClassName,
 		PriceAtPurchase: opt.PriceAtPurchase,
 		Quantity:        opt.Quantity,
+		TotalAmount:     opt.TotalAmount,
 		CreatedAt:       now,
 		UpdatedAt:       now,
 	}
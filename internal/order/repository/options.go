@@ -9,31 +9,79 @@ import (
 )
 
 type CreateOrderOption struct {
-	SessionID    string
-	Code         string
-	UserID       string
-	UserFullName string
-	Email        string
-	Phone        string
-	EventID      string
-	TotalAmount  int64
-	Currency     string
-	Status       models.OrderStatus
+	SessionID     string
+	Code          string
+	UserID        string
+	UserFullName  string
+	Email         string
+	Phone         string
+	EventID       string
+	TotalAmount   int64
+	Currency      string
+	PaymentMethod models.PaymentMethod
+	Status        models.OrderStatus
+	// Actor and WorkflowID are recorded as the first entry of the status history
+	Actor      models.OrderActor
+	WorkflowID string
 }
 
 type UpdateOrderOption struct {
-	Model  models.Order
-	Status models.OrderStatus
-	PaidAt *time.Time
+	// Model is the order the update is based on. The update only applies while the order is still in
+	// its status and at its version, and returns an *order.ConflictError otherwise. A zero Model is
+	// read first.
+	Model         models.Order
+	Status        models.OrderStatus
+	FailureReason string
+	PaidAt        *time.Time
+	Refund        *RefundOrderOption
+	// ClearRefund removes a previously recorded refund, used when a refund is rolled back
+	ClearRefund bool
+	// Change is recorded in the status history when the status changes
+	Change StatusChangeOption
+}
+
+// StatusChangeOption tells who changes the status of an order and why, a zero Actor is recorded as
+// the system
+type StatusChangeOption struct {
+	Actor      models.OrderActor
+	WorkflowID string
+	Reason     string
+}
+
+// UpdateOrderPaymentOption records the payment that paid the order
+type UpdateOrderPaymentOption struct {
+	PaymentID     string
+	TransactionID string
+	PaidAt        time.Time
+}
+
+type RefundOrderOption struct {
+	Amount     int64
+	Reason     string
+	RefundedAt time.Time
 }
 
 type GetManyOrderOption struct {
 	order.FilterOrder
-	Pag paginator.PaginatorQuery
+	Pag paginator.CursorQuery
 }
 
 type ListOrderOption struct {
 	order.FilterOrder
+	// After continues the listing behind the given order, zero starts from the newest order
+	After ListOrderCursor
+	// Limit caps the number of orders returned, zero returns all of them
+	Limit int64
+}
+
+// ListOrderCursor names an order by the created_at, _id sort of List
+type ListOrderCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+func (c ListOrderCursor) IsZero() bool {
+	return c.ID == ""
 }
 
 type GetOneOrderOption struct {
@@ -2,9 +2,12 @@ package repository
 
 import (
 	"context"
+	"maps"
+	"slices"
 	"sync"
 
 	"github.com/vogiaan1904/ticketbottle-order/internal/models"
+	"github.com/vogiaan1904/ticketbottle-order/internal/order"
 	"github.com/vogiaan1904/ticketbottle-order/pkg/mongo"
 	"github.com/vogiaan1904/ticketbottle-order/pkg/paginator"
 	"go.mongodb.org/mongo-driver/bson"
@@ -66,22 +69,92 @@ func (r *implRepository) GetByID(ctx context.Context, ID string) (models.Order,
 func (r *implRepository) Update(ctx context.Context, ID string, opt UpdateOrderOption) (models.Order, error) {
 	col := r.getOrderCollection()
 
-	o, upDoc := r.buildUpdateOrderModel(opt)
+	cur := opt.Model
+	if cur.ID.IsZero() {
+		var err error
+		if cur, err = r.GetByID(ctx, ID); err != nil {
+			return models.Order{}, err
+		}
+	}
 
-	fil, err := r.buildGetByIDQuery(ctx, ID)
+	if !cur.Status.CanTransitionTo(opt.Status) {
+		return models.Order{}, &order.TransitionError{OrderID: ID, From: cur.Status, To: opt.Status}
+	}
+
+	o, upDoc := r.buildUpdateOrderModel(cur, opt)
+
+	fil, err := r.buildUpdateQuery(ctx, ID, cur)
 	if err != nil {
 		r.l.Errorf(ctx, "order.repository.OrderRepository.Update: %v", err)
 		return models.Order{}, err
 	}
 
-	if _, err := col.UpdateOne(ctx, fil, upDoc); err != nil {
+	res, err := col.UpdateOne(ctx, fil, upDoc)
+	if err != nil {
 		r.l.Errorf(ctx, "order.repository.OrderRepository.Update: %v", err)
 		return models.Order{}, err
 	}
 
+	if res.MatchedCount == 0 {
+		return models.Order{}, &order.ConflictError{OrderID: ID, Status: cur.Status, Version: cur.Version}
+	}
+
 	return o, nil
 }
 
+func (r *implRepository) UpdatePaymentID(ctx context.Context, ID string, paymentID string) error {
+	col := r.getOrderCollection()
+
+	fil, err := r.buildGetByIDQuery(ctx, ID)
+	if err != nil {
+		r.l.Errorf(ctx, "order.repository.OrderRepository.UpdatePaymentID: %v", err)
+		return err
+	}
+
+	upDoc := bson.M{
+		"$set": bson.M{
+			"payment_id": paymentID,
+			"updated_at": r.clock(),
+		},
+		"$inc": bson.M{"version": 1},
+	}
+
+	if _, err := col.UpdateOne(ctx, fil, upDoc); err != nil {
+		r.l.Errorf(ctx, "order.repository.OrderRepository.UpdatePaymentID: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *implRepository) UpdatePayment(ctx context.Context, ID string, opt UpdateOrderPaymentOption) error {
+	col := r.getOrderCollection()
+
+	fil, err := r.buildGetByIDQuery(ctx, ID)
+	if err != nil {
+		r.l.Errorf(ctx, "order.repository.OrderRepository.UpdatePayment: %v", err)
+		return err
+	}
+
+	set := bson.M{
+		"transaction_id": opt.TransactionID,
+		"updated_at":     r.clock(),
+	}
+	if opt.PaymentID != "" {
+		set["payment_id"] = opt.PaymentID
+	}
+	if !opt.PaidAt.IsZero() {
+		set["paid_at"] = opt.PaidAt
+	}
+
+	if _, err := col.UpdateOne(ctx, fil, bson.M{"$set": set, "$inc": bson.M{"version": 1}}); err != nil {
+		r.l.Errorf(ctx, "order.repository.OrderRepository.UpdatePayment: %v", err)
+		return err
+	}
+
+	return nil
+}
+
 func (r *implRepository) Delete(ctx context.Context, ID string) error {
 	col := r.getOrderCollection()
 
@@ -103,11 +176,22 @@ func (r *implRepository) List(ctx context.Context, opt ListOrderOption) ([]model
 	col := r.getOrderCollection()
 
 	q := r.buildFilterQuery(ctx, opt.FilterOrder)
+	if !opt.After.IsZero() {
+		var err error
+		if q, err = r.buildListAfterQuery(ctx, q, opt.After); err != nil {
+			return nil, err
+		}
+	}
 
-	cur, err := col.Find(ctx, q, options.Find().SetSort(bson.D{
+	findOpts := options.Find().SetSort(bson.D{
 		{Key: "created_at", Value: -1},
 		{Key: "_id", Value: -1},
-	}))
+	})
+	if opt.Limit > 0 {
+		findOpts.SetLimit(opt.Limit)
+	}
+
+	cur, err := col.Find(ctx, q, findOpts)
 	if err != nil {
 		r.l.Errorf(ctx, "order.repository.OrderRepository.List: %v", err)
 		return nil, err
@@ -122,58 +206,117 @@ func (r *implRepository) List(ctx context.Context, opt ListOrderOption) ([]model
 	return os, nil
 }
 
-func (r *implRepository) GetMany(ctx context.Context, opt GetManyOrderOption) ([]models.Order, paginator.Paginator, error) {
+func (r *implRepository) GetMany(ctx context.Context, opt GetManyOrderOption) ([]models.Order, paginator.CursorPaginator, error) {
 	col := r.getOrderCollection()
 
 	q := r.buildFilterQuery(ctx, opt.FilterOrder)
 
-	var total int64
-	os := []models.Order{}
-	var wgErr error
+	var cur pageCursor
+	if opt.Pag.Cursor != "" {
+		var err error
+		if cur, err = decodePageCursor(opt.Pag.Cursor); err != nil {
+			r.l.Warnf(ctx, "order.repository.OrderRepository.GetMany: %v", err)
+			return nil, paginator.CursorPaginator{}, err
+		}
+	}
 
+	// The count covers the whole filter, it runs on a copy before the cursor narrows q
+	var total int64
+	var cntErr error
 	var wg sync.WaitGroup
-	wg.Add(2)
-
-	wg.Go(func() {
-		cnt, err := col.CountDocuments(ctx, q)
-		if err != nil {
-			r.l.Errorf(ctx, "order.repository.mongo.GetMany.col.CountDocuments: %v", err)
-			wgErr = err
-			return
-		}
-		total = cnt
-	})
+	if opt.Pag.WithTotal {
+		cntQ := maps.Clone(q)
+		wg.Go(func() {
+			total, cntErr = col.CountDocuments(ctx, cntQ)
+		})
+	}
+
+	os, err := r.findPage(ctx, q, cur, opt.Pag.Limit)
+	wg.Wait()
+	if err != nil {
+		return nil, paginator.CursorPaginator{}, err
+	}
+	if cntErr != nil {
+		r.l.Errorf(ctx, "order.repository.OrderRepository.GetMany: %v", cntErr)
+		return nil, paginator.CursorPaginator{}, cntErr
+	}
+
+	// One more order than the limit is read to tell whether another page follows in that direction
+	more := int64(len(os)) > opt.Pag.Limit
+	if more {
+		os = os[:opt.Pag.Limit]
+	}
+	if cur.Prev {
+		slices.Reverse(os)
+	}
+
+	pag := paginator.CursorPaginator{
+		Count:    int64(len(os)),
+		PageSize: opt.Pag.Limit,
+	}
+	if opt.Pag.WithTotal {
+		pag.Total = &total
+	}
+	if len(os) == 0 {
+		return os, pag, nil
+	}
 
-	wg.Go(func() {
-		cur, err := col.Find(ctx, q, options.Find().SetSkip(opt.Pag.Offset()).
-			SetLimit(opt.Pag.Limit).
-			SetSort(bson.D{
-				{Key: "created_at", Value: -1},
-				{Key: "_id", Value: -1},
-			}))
-		if err != nil {
-			r.l.Errorf(ctx, "order.repository.mongo.GetMany.col.Find: %v", err)
-			wgErr = err
-			return
+	// Paging back always leaves a page behind, paging forward from a cursor always leaves one before
+	hasNext, hasPrev := more, opt.Pag.Cursor != ""
+	if cur.Prev {
+		hasNext, hasPrev = true, more
+	}
+	if hasNext {
+		if pag.Next, err = newPageCursor(os[len(os)-1], false); err != nil {
+			r.l.Errorf(ctx, "order.repository.OrderRepository.GetMany: %v", err)
+			return nil, paginator.CursorPaginator{}, err
 		}
+	}
+	if hasPrev {
+		if pag.Prev, err = newPageCursor(os[0], true); err != nil {
+			r.l.Errorf(ctx, "order.repository.OrderRepository.GetMany: %v", err)
+			return nil, paginator.CursorPaginator{}, err
+		}
+	}
+
+	return os, pag, nil
+}
+
+// findPage reads up to limit+1 orders on the side of cur its direction points to, in the order they
+// are read: newest first, or oldest first for a Prev cursor
+func (r *implRepository) findPage(ctx context.Context, q bson.M, cur pageCursor, limit int64) ([]models.Order, error) {
+	sort := bson.D{
+		{Key: "created_at", Value: -1},
+		{Key: "_id", Value: -1},
+	}
 
-		if err = cur.All(ctx, &os); err != nil {
-			r.l.Errorf(ctx, "order.repository.mongo.GetMany.cur.All: %v", err)
-			wgErr = err
-			return
+	var err error
+	switch {
+	case cur.ID == "":
+	case cur.Prev:
+		sort = bson.D{
+			{Key: "created_at", Value: 1},
+			{Key: "_id", Value: 1},
 		}
-	})
+		q, err = r.buildListBeforeQuery(ctx, q, cur.listCursor())
+	default:
+		q, err = r.buildListAfterQuery(ctx, q, cur.listCursor())
+	}
+	if err != nil {
+		return nil, err
+	}
 
-	wg.Wait()
-	if wgErr != nil {
-		return nil, paginator.Paginator{}, wgErr
+	c, err := r.getOrderCollection().Find(ctx, q, options.Find().SetSort(sort).SetLimit(limit+1))
+	if err != nil {
+		r.l.Errorf(ctx, "order.repository.OrderRepository.findPage: %v", err)
+		return nil, err
 	}
 
-	return os, paginator.Paginator{
-		Total:    total,
-		Count:    int64(len(os)),
-		PageSize: opt.Pag.Limit,
-		Page:     opt.Pag.Page,
-	}, nil
+	os := []models.Order{}
+	if err := c.All(ctx, &os); err != nil {
+		r.l.Errorf(ctx, "order.repository.OrderRepository.findPage: %v", err)
+		return nil, err
+	}
 
+	return os, nil
 }
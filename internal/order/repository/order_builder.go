@@ -1,6 +1,9 @@
 package repository
 
 import (
+	"slices"
+	"time"
+
 	"github.com/vogiaan1904/ticketbottle-order/internal/models"
 	"go.mongodb.org/mongo-driver/bson"
 )
@@ -8,42 +11,97 @@ import (
 func (r *implRepository) buildOrderModel(opt CreateOrderOption) models.Order {
 	now := r.clock()
 	m := models.Order{
-		ID:           r.db.NewObjectID(),
-		SessionID:    opt.SessionID,
-		Code:         opt.Code,
-		UserID:       opt.UserID,
-		UserFullName: opt.UserFullName,
-		Email:        opt.Email,
-		Phone:        opt.Phone,
-		EventID:      opt.EventID,
-		TotalAmount:  opt.TotalAmount,
-		Currency:     opt.Currency,
-		Status:       opt.Status,
-		CreatedAt:    now,
-		UpdatedAt:    now,
+		ID:            r.db.NewObjectID(),
+		SessionID:     opt.SessionID,
+		Code:          opt.Code,
+		UserID:        opt.UserID,
+		UserFullName:  opt.UserFullName,
+		Email:         opt.Email,
+		Phone:         opt.Phone,
+		EventID:       opt.EventID,
+		TotalAmount:   opt.TotalAmount,
+		Currency:      opt.Currency,
+		PaymentMethod: opt.PaymentMethod,
+		Status:        opt.Status,
+		StatusHistory: []models.OrderStatusChange{
+			newStatusChange("", opt.Status, StatusChangeOption{Actor: opt.Actor, WorkflowID: opt.WorkflowID}, now),
+		},
+		CreatedAt: now,
+		UpdatedAt: now,
 	}
 
 	return m
 }
 
-func (r *implRepository) buildUpdateOrderModel(opt UpdateOrderOption) (models.Order, bson.M) {
+func (r *implRepository) buildUpdateOrderModel(cur models.Order, opt UpdateOrderOption) (models.Order, bson.M) {
 	now := r.clock()
 
-	m := opt.Model
+	m := cur
 	set := bson.M{
 		"status": opt.Status,
 	}
 	m.Status = opt.Status
 
+	if opt.FailureReason != "" {
+		set["failure_reason"] = opt.FailureReason
+		m.FailureReason = opt.FailureReason
+	}
+
 	if opt.PaidAt != nil {
 		set["paid_at"] = *opt.PaidAt
 		m.PaidAt = opt.PaidAt
 	}
 
+	if opt.Refund != nil {
+		set["refunded_amount"] = opt.Refund.Amount
+		set["refund_reason"] = opt.Refund.Reason
+		set["refunded_at"] = opt.Refund.RefundedAt
+		m.RefundedAmount = opt.Refund.Amount
+		m.RefundReason = opt.Refund.Reason
+		m.RefundedAt = &opt.Refund.RefundedAt
+	}
+
 	m.UpdatedAt = now
 	set["updated_at"] = now
+	m.Version++
+
+	upDoc := bson.M{
+		"$set": set,
+		"$inc": bson.M{"version": 1},
+	}
 
-	upDoc := bson.M{"$set": set}
+	if cur.Status != opt.Status {
+		ch := newStatusChange(cur.Status, opt.Status, opt.Change, now)
+		upDoc["$push"] = bson.M{"status_history": ch}
+		m.StatusHistory = append(slices.Clip(cur.StatusHistory), ch)
+	}
+
+	if opt.ClearRefund {
+		upDoc["$unset"] = bson.M{
+			"refunded_amount": "",
+			"refund_reason":   "",
+			"refunded_at":     "",
+		}
+		m.RefundedAmount = 0
+		m.RefundReason = ""
+		m.RefundedAt = nil
+	}
 
 	return m, upDoc
 }
+
+func newStatusChange(from, to models.OrderStatus, opt StatusChangeOption, at time.Time) models.OrderStatusChange {
+	act := opt.Actor
+	if act.IsZero() {
+		act = models.SystemActor("")
+	}
+
+	return models.OrderStatusChange{
+		From:       from,
+		To:         to,
+		Actor:      act,
+		WorkflowID: opt.WorkflowID,
+		Reason:     opt.Reason,
+		ChangedAt:  at,
+	}
+}
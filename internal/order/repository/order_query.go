@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 
+	"github.com/vogiaan1904/ticketbottle-order/internal/models"
 	"github.com/vogiaan1904/ticketbottle-order/internal/order"
 	"github.com/vogiaan1904/ticketbottle-order/pkg/mongo"
 	"go.mongodb.org/mongo-driver/bson"
@@ -23,6 +24,24 @@ func (r *implRepository) buildGetByIDQuery(ctx context.Context, ID string) (bson
 	return q, nil
 }
 
+// buildUpdateQuery matches the order only while it is still in the status and at the version of cur.
+// Orders written before versions existed have no version field until migration 4 ran, they are at
+// version 0.
+func (r *implRepository) buildUpdateQuery(ctx context.Context, ID string, cur models.Order) (bson.M, error) {
+	q, err := r.buildGetByIDQuery(ctx, ID)
+	if err != nil {
+		return nil, err
+	}
+
+	q["status"] = cur.Status
+	q["version"] = cur.Version
+	if cur.Version == 0 {
+		q["version"] = bson.M{"$in": bson.A{int64(0), nil}}
+	}
+
+	return q, nil
+}
+
 func (r *implRepository) buildFilterQuery(ctx context.Context, fil order.FilterOrder) bson.M {
 	q := bson.M{}
 	q = mongo.BuildQueryWithSoftDelete(q)
@@ -49,3 +68,35 @@ func (r *implRepository) buildFilterQuery(ctx context.Context, fil order.FilterO
 
 	return q
 }
+
+// buildListAfterQuery narrows q to the orders sorted after cur, newest first
+func (r *implRepository) buildListAfterQuery(ctx context.Context, q bson.M, cur ListOrderCursor) (bson.M, error) {
+	objID, err := primitive.ObjectIDFromHex(cur.ID)
+	if err != nil {
+		r.l.Errorf(ctx, "order.repository.OrderRepository.buildListAfterQuery: %v", err)
+		return nil, err
+	}
+
+	q["$or"] = bson.A{
+		bson.M{"created_at": bson.M{"$lt": cur.CreatedAt}},
+		bson.M{"created_at": cur.CreatedAt, "_id": bson.M{"$lt": objID}},
+	}
+
+	return q, nil
+}
+
+// buildListBeforeQuery narrows q to the orders sorted before cur, oldest first
+func (r *implRepository) buildListBeforeQuery(ctx context.Context, q bson.M, cur ListOrderCursor) (bson.M, error) {
+	objID, err := primitive.ObjectIDFromHex(cur.ID)
+	if err != nil {
+		r.l.Errorf(ctx, "order.repository.OrderRepository.buildListBeforeQuery: %v", err)
+		return nil, err
+	}
+
+	q["$or"] = bson.A{
+		bson.M{"created_at": bson.M{"$gt": cur.CreatedAt}},
+		bson.M{"created_at": cur.CreatedAt, "_id": bson.M{"$gt": objID}},
+	}
+
+	return q, nil
+}
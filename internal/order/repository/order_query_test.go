@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/vogiaan1904/ticketbottle-order/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestBuildUpdateQueryMatchesVersion(t *testing.T) {
+	var r implRepository
+	id := primitive.NewObjectID()
+
+	tcs := []struct {
+		name    string
+		version int64
+		want    any
+	}{
+		{"version 0 also matches a missing version", 0, bson.M{"$in": bson.A{int64(0), nil}}},
+		{"later versions match exactly", 3, int64(3)},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			q, err := r.buildUpdateQuery(context.Background(), id.Hex(), models.Order{
+				ID:      id,
+				Status:  models.OrderStatusPending,
+				Version: tc.version,
+			})
+			if err != nil {
+				t.Fatalf("buildUpdateQuery: %v", err)
+			}
+
+			if q["_id"] != id || q["status"] != models.OrderStatusPending {
+				t.Fatalf("query %v, want order %s in status %s", q, id.Hex(), models.OrderStatusPending)
+			}
+			if !reflect.DeepEqual(q["version"], tc.want) {
+				t.Fatalf("version filter %v, want %v", q["version"], tc.want)
+			}
+		})
+	}
+}
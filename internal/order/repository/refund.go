@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/vogiaan1904/ticketbottle-order/internal/models"
+	"github.com/vogiaan1904/ticketbottle-order/pkg/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	orderRefundCollection = "order_refunds"
+)
+
+func (r *implRepository) getOrderRefundCollection() mongo.Collection {
+	return r.db.Collection(orderRefundCollection)
+}
+
+func (r *implRepository) CreateRefund(ctx context.Context, opt CreateOrderRefundOption) (models.OrderRefund, error) {
+	col := r.getOrderRefundCollection()
+
+	rf := r.buildOrderRefundModel(opt)
+	if _, err := col.InsertOne(ctx, rf); err != nil {
+		r.l.Errorf(ctx, "order.repository.OrderRefundRepository.CreateRefund: %v", err)
+		return models.OrderRefund{}, err
+	}
+
+	return rf, nil
+}
+
+func (r *implRepository) GetRefundByID(ctx context.Context, ID string) (models.OrderRefund, error) {
+	col := r.getOrderRefundCollection()
+
+	fil, err := r.buildGetByIDQuery(ctx, ID)
+	if err != nil {
+		r.l.Errorf(ctx, "order.repository.OrderRefundRepository.GetRefundByID: %v", err)
+		return models.OrderRefund{}, err
+	}
+
+	var rf models.OrderRefund
+	if err := col.FindOne(ctx, fil).Decode(&rf); err != nil {
+		r.l.Errorf(ctx, "order.repository.OrderRefundRepository.GetRefundByID: %v", err)
+		return models.OrderRefund{}, err
+	}
+
+	return rf, nil
+}
+
+func (r *implRepository) ListRefundByOrderID(ctx context.Context, ordID string) ([]models.OrderRefund, error) {
+	col := r.getOrderRefundCollection()
+
+	oID, err := primitive.ObjectIDFromHex(ordID)
+	if err != nil {
+		r.l.Errorf(ctx, "order.repository.OrderRefundRepository.ListRefundByOrderID: %v", err)
+		return nil, err
+	}
+
+	q := mongo.BuildQueryWithSoftDelete(bson.M{"order_id": oID})
+	cur, err := col.Find(ctx, q, options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}))
+	if err != nil {
+		r.l.Errorf(ctx, "order.repository.OrderRefundRepository.ListRefundByOrderID: %v", err)
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var rfs []models.OrderRefund
+	if err := cur.All(ctx, &rfs); err != nil {
+		r.l.Errorf(ctx, "order.repository.OrderRefundRepository.ListRefundByOrderID: %v", err)
+		return nil, err
+	}
+
+	return rfs, nil
+}
+
+func (r *implRepository) UpdateRefund(ctx context.Context, ID string, opt UpdateOrderRefundOption) (models.OrderRefund, error) {
+	col := r.getOrderRefundCollection()
+
+	rf, upDoc := r.buildUpdateOrderRefundModel(opt)
+
+	fil, err := r.buildGetByIDQuery(ctx, ID)
+	if err != nil {
+		r.l.Errorf(ctx, "order.repository.OrderRefundRepository.UpdateRefund: %v", err)
+		return models.OrderRefund{}, err
+	}
+
+	if _, err := col.UpdateOne(ctx, fil, upDoc); err != nil {
+		r.l.Errorf(ctx, "order.repository.OrderRefundRepository.UpdateRefund: %v", err)
+		return models.OrderRefund{}, err
+	}
+
+	return rf, nil
+}
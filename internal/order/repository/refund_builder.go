@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"github.com/vogiaan1904/ticketbottle-order/internal/models"
+	"github.com/vogiaan1904/ticketbottle-order/pkg/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func (r *implRepository) buildOrderRefundModel(opt CreateOrderRefundOption) models.OrderRefund {
+	now := r.clock()
+	m := models.OrderRefund{
+		ID:             r.db.NewObjectID(),
+		OrderID:        mongo.ObjectIDFromHexOrNil(opt.OrderID),
+		OrderCode:      opt.OrderCode,
+		Items:          opt.Items,
+		Amount:         opt.Amount,
+		Reason:         opt.Reason,
+		IdempotencyKey: opt.IdempotencyKey,
+		Status:         models.OrderRefundStatusPending,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	return m
+}
+
+func (r *implRepository) buildUpdateOrderRefundModel(opt UpdateOrderRefundOption) (models.OrderRefund, bson.M) {
+	now := r.clock()
+
+	m := opt.Model
+	set := bson.M{
+		"status": opt.Status,
+	}
+	m.Status = opt.Status
+
+	if opt.PaymentRefundID != "" {
+		set["payment_refund_id"] = opt.PaymentRefundID
+		m.PaymentRefundID = opt.PaymentRefundID
+	}
+
+	m.UpdatedAt = now
+	set["updated_at"] = now
+
+	upDoc := bson.M{"$set": set}
+
+	return m, upDoc
+}
@@ -0,0 +1,18 @@
+package repository
+
+import "github.com/vogiaan1904/ticketbottle-order/internal/models"
+
+type CreateOrderRefundOption struct {
+	OrderID        string
+	OrderCode      string
+	Items          []models.OrderRefundItem
+	Amount         int64
+	Reason         string
+	IdempotencyKey string
+}
+
+type UpdateOrderRefundOption struct {
+	Model           models.OrderRefund
+	Status          models.OrderRefundStatus
+	PaymentRefundID string
+}
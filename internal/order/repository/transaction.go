@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func (r *implRepository) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	// A transaction started by the caller is joined, it commits or aborts as a whole
+	if mongo.SessionFromContext(ctx) != nil {
+		return fn(ctx)
+	}
+
+	sess, err := r.db.Client().StartSession()
+	if err != nil {
+		r.l.Errorf(ctx, "order.repository.WithTransaction.StartSession: %v", err)
+		return err
+	}
+	defer sess.EndSession(ctx)
+
+	_, err = sess.WithTransaction(ctx, func(sc mongo.SessionContext) (any, error) {
+		return nil, fn(sc)
+	})
+	if err != nil {
+		r.l.Errorf(ctx, "order.repository.WithTransaction: %v", err)
+		return err
+	}
+
+	return nil
+}
@@ -2,47 +2,223 @@ package service
 
 import (
 	"context"
+	"errors"
 
 	"github.com/vogiaan1904/ticketbottle-order/internal/infra/temporal"
 	"github.com/vogiaan1904/ticketbottle-order/internal/models"
 	"github.com/vogiaan1904/ticketbottle-order/internal/order"
+	repo "github.com/vogiaan1904/ticketbottle-order/internal/order/repository"
 	"github.com/vogiaan1904/ticketbottle-order/internal/workflows"
+	"github.com/vogiaan1904/ticketbottle-order/pkg/mongo"
+	"go.temporal.io/api/serviceerror"
 	"go.temporal.io/sdk/client"
 )
 
 func (s *implService) HandlePaymentCompleted(ctx context.Context, in order.HandlePaymentCompletedInput) error {
+	return s.processOnce(ctx, dedupeEvent{
+		Key:       paymentEventKey("payment.completed", in.PaymentID, in.TransactionID),
+		Topic:     in.Topic,
+		EventType: in.EventType,
+	}, func() error {
+		return s.handlePaymentCompleted(ctx, in)
+	})
+}
+
+func (s *implService) handlePaymentCompleted(ctx context.Context, in order.HandlePaymentCompletedInput) error {
+	pmt := &workflows.PaymentDetails{
+		PaymentID:     in.PaymentID,
+		TransactionID: in.TransactionID,
+		AmountCents:   in.AmountCents,
+		Currency:      in.Currency,
+		Provider:      in.Provider,
+		PaidAt:        in.PaidAt,
+	}
+
+	err := s.temporal.SignalWorkflow(ctx, workflows.GetCreateOrderWorkflowID(in.OrderCode), "",
+		workflows.SignalNamePaymentCompleted, workflows.PaymentCompletedSignal{
+			OrderCode: in.OrderCode,
+			Payment:   pmt,
+		})
+	if err == nil {
+		return nil
+	}
+
+	var nfErr *serviceerror.NotFound
+	if !errors.As(err, &nfErr) {
+		s.l.Errorf(ctx, "internal.order.service.HandlePaymentCompleted.temporal.SignalWorkflow: %v", err)
+		return err
+	}
+
+	// Orders placed before the payment-wait phase existed have no running CreateOrder workflow
+	s.l.Warnf(ctx, "No running create order workflow for order %s, starting confirm order workflow", in.OrderCode)
+	return s.confirmOrder(ctx, in.OrderCode, pmt)
+}
+
+// paymentFailedReason is the failure reason of an order whose payment failed without a reason
+const paymentFailedReason = "Payment failed"
+
+func (s *implService) HandlePaymentFailed(ctx context.Context, in order.HandlePaymentFailedInput) error {
+	if in.Reason == "" {
+		in.Reason = paymentFailedReason
+	}
+
+	return s.processOnce(ctx, dedupeEvent{
+		Key:       paymentEventKey("payment.failed", in.PaymentID, in.TransactionID),
+		Topic:     in.Topic,
+		EventType: in.EventType,
+	}, func() error {
+		return s.handlePaymentFailed(ctx, in)
+	})
+}
+
+func (s *implService) handlePaymentFailed(ctx context.Context, in order.HandlePaymentFailedInput) error {
+	err := s.temporal.SignalWorkflow(ctx, workflows.GetCreateOrderWorkflowID(in.OrderCode), "",
+		workflows.SignalNamePaymentFailed, workflows.PaymentFailedSignal{
+			OrderCode: in.OrderCode,
+			Reason:    in.Reason,
+		})
+	if err == nil {
+		s.l.Infof(ctx, "Payment failure signaled for order: %s", in.OrderCode)
+		return nil
+	}
+
+	var nfErr *serviceerror.NotFound
+	if !errors.As(err, &nfErr) {
+		s.l.Errorf(ctx, "internal.order.service.HandlePaymentFailed.temporal.SignalWorkflow: %v", err)
+		return err
+	}
+
+	// Orders placed before the payment-wait phase existed have no running CreateOrder workflow
+	s.l.Warnf(ctx, "No running create order workflow for order %s, starting fail order workflow", in.OrderCode)
+	return s.failOrder(ctx, in.OrderCode, in.Reason)
+}
+
+// confirmOrder starts the ConfirmOrder workflow and does not wait for it, since it can park for days
+// on an intervention. A confirmation already running for the order is left to finish.
+func (s *implService) confirmOrder(ctx context.Context, code string, pmt *workflows.PaymentDetails) error {
 	wfOpts := client.StartWorkflowOptions{
-		ID:        workflows.GetConfirmOrderWorkflowID(in.OrderCode),
-		TaskQueue: temporal.ConfirmOrderTaskQueue,
+		ID:                                       workflows.GetConfirmOrderWorkflowID(code),
+		TaskQueue:                                temporal.ConfirmOrderTaskQueue,
+		WorkflowExecutionErrorWhenAlreadyStarted: true,
 	}
 
 	wfIn := workflows.ConfirmOrderWorkflowInput{
-		OrderCode: in.OrderCode,
+		OrderCode: code,
 		Status:    models.OrderStatusCompleted,
+		Payment:   pmt,
+		Actor:     models.SystemActor(models.OrderChangeSourceKafka),
 	}
 
 	wfRun, err := s.temporal.ExecuteWorkflow(ctx, wfOpts, workflows.ConfirmOrder, &wfIn)
 	if err != nil {
+		var asErr *serviceerror.WorkflowExecutionAlreadyStarted
+		if errors.As(err, &asErr) {
+			s.l.Infof(ctx, "Confirm order workflow already running for order %s", code)
+			return nil
+		}
 		s.l.Errorf(ctx, "Failed to start confirm order workflow: %v", err)
 		return err
 	}
 
-	err = wfRun.Get(ctx, nil)
+	s.l.Infof(ctx, "Confirm order workflow started for order %s, run %s", code, wfRun.GetRunID())
+	return nil
+}
+
+// failOrder hands the payment failure to the FailOrder workflow, which retries until the order is
+// failed, so the consumer does not wait for it to finish
+func (s *implService) failOrder(ctx context.Context, code string, reason string) error {
+	wfOpts := client.StartWorkflowOptions{
+		ID:        workflows.GetFailOrderWorkflowID(code),
+		TaskQueue: temporal.FailOrderTaskQueue,
+	}
+
+	wfIn := workflows.FailOrderWorkflowInput{
+		OrderCode: code,
+		Status:    models.OrderStatusPaymentFailed,
+		Reason:    reason,
+		Actor:     models.SystemActor(models.OrderChangeSourceKafka),
+	}
+
+	wfRun, err := s.temporal.ExecuteWorkflow(ctx, wfOpts, workflows.FailOrder, &wfIn)
 	if err != nil {
-		s.l.Errorf(ctx, "Confirm order workflow failed: %v", err)
+		s.l.Errorf(ctx, "Failed to start fail order workflow: %v", err)
 		return err
 	}
 
+	s.l.Infof(ctx, "Fail order workflow started for order %s, run %s", code, wfRun.GetRunID())
 	return nil
 }
 
-func (s *implService) HandlePaymentFailed(ctx context.Context, in order.HandlePaymentFailedInput) error {
-	err := s.handlePaymentFailure(ctx, in.OrderCode)
+// eventCancelledReason is the refund reason of orders of an event cancelled without a reason
+const eventCancelledReason = "Event cancelled by the organizer"
+
+// HandleEventCancelled starts the CancelEventOrders workflow of the event and does not wait for it.
+// A redelivered event joins the running workflow, or reruns a finished one, which then skips every
+// order already handled.
+func (s *implService) HandleEventCancelled(ctx context.Context, in order.HandleEventCancelledInput) error {
+	wfOpts := client.StartWorkflowOptions{
+		ID:        workflows.GetCancelEventOrdersWorkflowID(in.EventID),
+		TaskQueue: temporal.CancelEventOrdersTaskQueue,
+	}
+
+	reason := in.Reason
+	if reason == "" {
+		reason = eventCancelledReason
+	}
+
+	wfIn := workflows.CancelEventOrdersWorkflowInput{
+		EventID:       in.EventID,
+		Reason:        reason,
+		Concurrency:   workflows.DefaultEventOrdersConcurrency,
+		BatchInterval: workflows.DefaultEventOrdersBatchInterval,
+	}
+
+	wfRun, err := s.temporal.ExecuteWorkflow(ctx, wfOpts, workflows.CancelEventOrders, &wfIn)
 	if err != nil {
-		s.l.Errorf(ctx, "Failed to handle payment failure: %v", err)
+		s.l.Errorf(ctx, "internal.order.service.HandleEventCancelled.temporal.ExecuteWorkflow: %v", err)
 		return err
 	}
 
-	s.l.Infof(ctx, "Payment failure handled successfully for order: %s", in.OrderCode)
+	s.l.Infof(ctx, "Cancel event orders workflow started for event %s, run %s", in.EventID, wfRun.GetRunID())
 	return nil
 }
+
+// sessionExpiredReason is the failure reason of an order cancelled because its waitroom session expired
+const sessionExpiredReason = "Waitroom session expired"
+
+// HandleSessionExpired cancels the pending order of an expired waitroom session the same way a
+// customer cancellation does. Sessions without a pending order, and orders paid in the meantime,
+// are left alone; a payment completing after the cancellation is refunded by ConfirmOrder.
+func (s *implService) HandleSessionExpired(ctx context.Context, in order.HandleSessionExpiredInput) error {
+	st := models.OrderStatusPending
+	o, err := s.repo.GetOne(ctx, repo.GetOneOrderOption{
+		FilterOrder: order.FilterOrder{
+			SessionID: in.SessionID,
+			Status:    &st,
+		},
+	})
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			s.l.Infof(ctx, "No pending order for expired session %s", in.SessionID)
+			return nil
+		}
+		s.l.Errorf(ctx, "internal.order.service.HandleSessionExpired.repo.GetOne: %v", err)
+		return err
+	}
+
+	err = s.cancelOrder(ctx, o.Code, sessionExpiredReason, models.SystemActor(models.OrderChangeSourceKafka))
+	switch err {
+	case nil:
+		s.l.Infof(ctx, "Order %s of expired session %s cancelled", o.Code, in.SessionID)
+		return nil
+	case order.ErrOrderAlreadyPaid, order.ErrOrderNotPending:
+		s.l.Infof(ctx, "Order %s of expired session %s left as is: %v", o.Code, in.SessionID, err)
+		return nil
+	case order.ErrOrderCancellationInProgress:
+		// The workflow keeps retrying until the order is cancelled
+		return nil
+	default:
+		s.l.Errorf(ctx, "internal.order.service.HandleSessionExpired.cancelOrder: %v", err)
+		return err
+	}
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	peRepo "github.com/vogiaan1904/ticketbottle-order/internal/processedevent/repository"
+	"github.com/vogiaan1904/ticketbottle-order/pkg/metrics"
+)
+
+const (
+	// processedEventLockTTL bounds how long a crashed consumer keeps a payment event claimed
+	processedEventLockTTL = 5 * time.Minute
+	// processedEventTTL is how long a redelivery of a processed payment event is recognized
+	processedEventTTL = 7 * 24 * time.Hour
+)
+
+// dedupeHits counts the redeliveries acknowledged without being processed again
+var dedupeHits = metrics.NewCounterVec("order_dedupe_hits_total", "topic", "event_type")
+
+// dedupeEvent identifies a consumed event for processOnce, Topic and EventType label its metrics
+type dedupeEvent struct {
+	Key       string
+	Topic     string
+	EventType string
+}
+
+// paymentEventKey is the dedupe key of a payment event, empty when the event carries no payment
+// or transaction ID to tell it apart
+func paymentEventKey(typ string, pmtID string, txnID string) string {
+	if pmtID == "" && txnID == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s:%s:%s", typ, pmtID, txnID)
+}
+
+// processOnce runs fn unless the event was already processed or is being processed by another
+// consumer, in which case the redelivery is acknowledged without side effects
+func (s *implService) processOnce(ctx context.Context, e dedupeEvent, fn func() error) error {
+	if e.Key == "" {
+		return fn()
+	}
+
+	claimed, err := s.peRepo.Claim(ctx, e.Key, peRepo.ClaimOption{
+		LockTTL: processedEventLockTTL,
+		TTL:     processedEventTTL,
+	})
+	if err != nil {
+		s.l.Errorf(ctx, "internal.order.service.processOnce.peRepo.Claim: %v", err)
+		return err
+	}
+	if !claimed {
+		s.l.Infof(ctx, "Dedupe hit, skipping already processed event %s", e.Key)
+		dedupeHits.Inc(e.Topic, e.EventType)
+		return nil
+	}
+
+	if err := fn(); err != nil {
+		if rErr := s.peRepo.Release(ctx, e.Key); rErr != nil {
+			s.l.Errorf(ctx, "internal.order.service.processOnce.peRepo.Release: %v", rErr)
+		}
+		return err
+	}
+
+	// The event was processed, a failure here only lets a redelivery after the lock run it again
+	if err := s.peRepo.Complete(ctx, e.Key, processedEventTTL); err != nil {
+		s.l.Errorf(ctx, "internal.order.service.processOnce.peRepo.Complete: %v", err)
+	}
+
+	return nil
+}
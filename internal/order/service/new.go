@@ -4,6 +4,7 @@ import (
 	"github.com/vogiaan1904/ticketbottle-order/internal/order"
 	"github.com/vogiaan1904/ticketbottle-order/internal/order/delivery/kafka/producer"
 	repo "github.com/vogiaan1904/ticketbottle-order/internal/order/repository"
+	peRepo "github.com/vogiaan1904/ticketbottle-order/internal/processedevent/repository"
 	"github.com/vogiaan1904/ticketbottle-order/pkg/grpc/event"
 	"github.com/vogiaan1904/ticketbottle-order/pkg/grpc/inventory"
 	"github.com/vogiaan1904/ticketbottle-order/pkg/grpc/payment"
@@ -13,25 +14,29 @@ import (
 )
 
 type implService struct {
-	l        logger.Logger
-	repo     repo.Repository
-	jwt      pkgJwt.Manager
-	prod     producer.Producer
-	invSvc   inventory.InventoryServiceClient
-	evSvc    event.EventServiceClient
-	pmtSvc   payment.PaymentServiceClient
-	temporal temporalCli.Client
+	l              logger.Logger
+	repo           repo.Repository
+	peRepo         peRepo.Repository
+	jwt            pkgJwt.Manager
+	prod           producer.Producer
+	invSvc         inventory.InventoryServiceClient
+	evSvc          event.EventServiceClient
+	pmtSvc         payment.PaymentServiceClient
+	temporal       temporalCli.Client
+	refundsEnabled bool
 }
 
-func New(l logger.Logger, repo repo.Repository, jwt pkgJwt.Manager, invSvc inventory.InventoryServiceClient, evSvc event.EventServiceClient, pmtSvc payment.PaymentServiceClient, prod producer.Producer, tprCli temporalCli.Client) order.Service {
+func New(l logger.Logger, repo repo.Repository, peRepo peRepo.Repository, jwt pkgJwt.Manager, invSvc inventory.InventoryServiceClient, evSvc event.EventServiceClient, pmtSvc payment.PaymentServiceClient, prod producer.Producer, tprCli temporalCli.Client, refundsEnabled bool) order.Service {
 	return &implService{
-		l:        l,
-		repo:     repo,
-		jwt:      jwt,
-		invSvc:   invSvc,
-		evSvc:    evSvc,
-		pmtSvc:   pmtSvc,
-		prod:     prod,
-		temporal: tprCli,
+		l:              l,
+		repo:           repo,
+		peRepo:         peRepo,
+		jwt:            jwt,
+		invSvc:         invSvc,
+		evSvc:          evSvc,
+		pmtSvc:         pmtSvc,
+		prod:           prod,
+		temporal:       tprCli,
+		refundsEnabled: refundsEnabled,
 	}
 }
@@ -2,9 +2,11 @@ package service
 
 import (
 	"context"
+	"errors"
 	"sync"
 	"time"
 
+	"github.com/vogiaan1904/ticketbottle-order/internal/activities"
 	"github.com/vogiaan1904/ticketbottle-order/internal/infra/temporal"
 	"github.com/vogiaan1904/ticketbottle-order/internal/models"
 	"github.com/vogiaan1904/ticketbottle-order/internal/order"
@@ -14,8 +16,11 @@ import (
 	"github.com/vogiaan1904/ticketbottle-order/pkg/grpc/inventory"
 	"github.com/vogiaan1904/ticketbottle-order/pkg/grpc/payment"
 	"github.com/vogiaan1904/ticketbottle-order/pkg/mongo"
+	"github.com/vogiaan1904/ticketbottle-order/pkg/paginator"
 	"github.com/vogiaan1904/ticketbottle-order/pkg/util"
+	"go.temporal.io/api/serviceerror"
 	"go.temporal.io/sdk/client"
+	temporalSdk "go.temporal.io/sdk/temporal"
 )
 
 func (s *implService) Create(ctx context.Context, in order.CreateOrderInput) (order.CreateOrderOutput, error) {
@@ -107,6 +112,8 @@ func (s *implService) Create(ctx context.Context, in order.CreateOrderInput) (or
 				}
 
 				return order.CreateOrderOutput{
+					OrderCode:  existingOrder.Code,
+					State:      checkoutStateFromOrder(existingOrder),
 					Order:      &existingOrder,
 					OrderItems: itms,
 					PaymentUrl: pmtResp.PaymentUrl,
@@ -194,104 +201,201 @@ func (s *implService) Create(ctx context.Context, in order.CreateOrderInput) (or
 		return order.CreateOrderOutput{}, err
 	}
 
-	var wfRes workflows.CreateOrderWorkflowResult
-	err = wfRun.Get(ctx, &wfRes)
+	if in.Async {
+		return order.CreateOrderOutput{
+			OrderCode: code,
+			State:     models.CheckoutStateCreating,
+		}, nil
+	}
+
+	wfRes, err := s.awaitPaymentUrl(ctx, wfRun)
 	if err != nil {
 		s.l.Errorf(ctx, "create order workflow failed: %v", err)
 		return order.CreateOrderOutput{}, err
 	}
 
 	return order.CreateOrderOutput{
+		OrderCode:  code,
+		State:      models.CheckoutStateAwaitingPayment,
 		Order:      wfRes.Order,
 		OrderItems: wfRes.OrderItems,
 		PaymentUrl: wfRes.PaymentUrl,
 	}, nil
 }
 
-func (s *implService) handlePaymentFailure(ctx context.Context, code string) error {
-	err := s.releaseTickets(ctx, code)
-	if err != nil {
-		s.l.Errorf(ctx, "internal.order.service.handlePaymentFailure.releaseTickets: %v", err)
-	}
-
-	o, err := s.repo.GetOne(ctx, repo.GetOneOrderOption{
-		FilterOrder: order.FilterOrder{
-			Code: code,
-		},
-	})
+func (s *implService) Cancel(ctx context.Context, in order.CancelOrderInput) error {
+	o, err := s.repo.GetByID(ctx, in.ID)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			s.l.Warnf(ctx, "internal.order.service.handlePaymentFailure.repo.GetByCode: %v", order.ErrOrderNotFound)
+			s.l.Warnf(ctx, "internal.order.service.Cancel: %v", order.ErrOrderNotFound)
 			return order.ErrOrderNotFound
 		}
-		s.l.Errorf(ctx, "internal.order.service.handlePaymentFailure.repo.GetByCode: %v", err)
+		s.l.Errorf(ctx, "internal.order.service.Cancel.repo.GetByID:%v", err)
 		return err
 	}
 
-	_, err = s.repo.Update(ctx, o.ID.Hex(), repo.UpdateOrderOption{
-		Status: models.OrderStatusPaymentFailed,
-	})
-	if err != nil {
-		s.l.Errorf(ctx, "internal.order.service.handlePaymentFailure.repo.Update: %v", err)
-		return err
+	if in.UserID == "" || in.UserID != o.UserID {
+		s.l.Warnf(ctx, "internal.order.service.Cancel: %v", order.ErrOrderNotOwned)
+		return order.ErrOrderNotOwned
 	}
 
-	err = s.publishCheckoutFailedEvent(ctx, order.PubCheckoutFailedEventInput{
-		SessionID: o.SessionID,
-		UserID:    o.UserID,
-		EventID:   o.EventID,
-	})
-	if err != nil {
-		s.l.Errorf(ctx, "internal.order.service.handlePaymentFailure.publishCheckoutFailedEvent: %v", err)
+	if o.Status != models.OrderStatusPending {
+		s.l.Errorf(ctx, "internal.order.service.Cancel: %v", order.ErrOrderNotPending)
+		return order.ErrOrderNotPending
 	}
 
-	return nil
+	return s.cancelOrder(ctx, o.Code, "", models.UserActor(in.UserID, models.OrderChangeSourceGRPC))
 }
 
-func (s *implService) Cancel(ctx context.Context, ID string) error {
-	o, err := s.repo.GetByID(ctx, ID)
+func (s *implService) Refund(ctx context.Context, in order.RefundOrderInput) (models.Order, error) {
+	if !s.refundsEnabled {
+		s.l.Warnf(ctx, "internal.order.service.Refund: %v", order.ErrRefundsDisabled)
+		return models.Order{}, order.ErrRefundsDisabled
+	}
+
+	o, err := s.repo.GetByID(ctx, in.ID)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			s.l.Warnf(ctx, "internal.order.service.Cancel: %v", order.ErrOrderNotFound)
-			return order.ErrOrderNotFound
+			s.l.Warnf(ctx, "internal.order.service.Refund: %v", order.ErrOrderNotFound)
+			return models.Order{}, order.ErrOrderNotFound
 		}
-		s.l.Errorf(ctx, "internal.order.service.Cancel.repo.GetByID:%v", err)
-		return err
+		s.l.Errorf(ctx, "internal.order.service.Refund.repo.GetByID: %v", err)
+		return models.Order{}, err
 	}
 
-	if o.Status != models.OrderStatusPending {
-		s.l.Errorf(ctx, "internal.order.service.Cancel: %v", order.ErrOrderNotPending)
-		return order.ErrOrderNotPending
+	if o.Status != models.OrderStatusCompleted && o.Status != models.OrderStatusPartiallyRefunded {
+		s.l.Errorf(ctx, "internal.order.service.Refund: %v", order.ErrOrderNotRefundable)
+		return models.Order{}, order.ErrOrderNotRefundable
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	// A refund still running for the order fails the request, instead of answering with its result
+	wfOpts := client.StartWorkflowOptions{
+		ID:                                       workflows.GetRefundOrderWorkflowID(o.Code),
+		TaskQueue:                                temporal.RefundOrderTaskQueue,
+		WorkflowExecutionErrorWhenAlreadyStarted: true,
+	}
+
+	itms := make([]workflows.RefundOrderItemInput, len(in.Items))
+	for i, itm := range in.Items {
+		itms[i] = workflows.RefundOrderItemInput{
+			TicketClassID: itm.TicketClassID,
+			Quantity:      itm.Quantity,
+		}
+	}
+
+	wfIn := workflows.RefundOrderWorkflowInput{
+		OrderCode: o.Code,
+		Reason:    in.Reason,
+		Items:     itms,
+		Actor:     models.OperatorActor(in.Operator, models.OrderChangeSourceGRPC),
 	}
 
-	if err := s.releaseTickets(ctx, o.Code); err != nil {
-		s.l.Errorf(ctx, "internal.order.service.Cancel.releaseTickets: %v", err)
+	wfRun, err := s.temporal.ExecuteWorkflow(ctx, wfOpts, workflows.RefundOrder, &wfIn)
+	if err != nil {
+		var asErr *serviceerror.WorkflowExecutionAlreadyStarted
+		if errors.As(err, &asErr) {
+			s.l.Warnf(ctx, "internal.order.service.Refund: refund of order %s already running: %v", o.Code, order.ErrOrderConflict)
+			return models.Order{}, order.ErrOrderConflict
+		}
+		s.l.Errorf(ctx, "failed to start refund order workflow: %v", err)
+		return models.Order{}, err
 	}
 
-	_, err = s.repo.Update(ctx, o.ID.Hex(), repo.UpdateOrderOption{
-		Status: models.OrderStatusCancelled,
+	var wfRes workflows.RefundOrderWorkflowResult
+	if err := wfRun.Get(ctx, &wfRes); err != nil {
+		s.l.Errorf(ctx, "refund order workflow failed: %v", err)
+		var appErr *temporalSdk.ApplicationError
+		if errors.As(err, &appErr) {
+			switch appErr.Type() {
+			case activities.ErrTypeInvalidRefund:
+				return models.Order{}, order.ErrInvalidRefundItems
+			case activities.ErrTypeOrderConflict:
+				return models.Order{}, order.ErrOrderConflict
+			case activities.ErrTypeInvalidOrderTransition:
+				return models.Order{}, order.ErrInvalidStatusTransition
+			}
+		}
+		return models.Order{}, order.ErrOrderRefundFailed
+	}
+
+	return *wfRes.Order, nil
+}
+
+func (s *implService) GetCheckoutStatus(ctx context.Context, code string) (models.CheckoutStatus, error) {
+	resp, err := s.temporal.QueryWorkflow(ctx, workflows.GetCreateOrderWorkflowID(code), "", workflows.QueryNameCheckoutStatus)
+	if err == nil {
+		var st models.CheckoutStatus
+		if err := resp.Get(&st); err != nil {
+			s.l.Errorf(ctx, "internal.order.service.GetCheckoutStatus.resp.Get: %v", err)
+			return models.CheckoutStatus{}, err
+		}
+		return st, nil
+	}
+
+	var nfErr *serviceerror.NotFound
+	if !errors.As(err, &nfErr) {
+		s.l.Errorf(ctx, "internal.order.service.GetCheckoutStatus.temporal.QueryWorkflow: %v", err)
+		return models.CheckoutStatus{}, err
+	}
+
+	// Workflows past their retention period can no longer be queried, derive the status from the order
+	o, err := s.repo.GetOne(ctx, repo.GetOneOrderOption{
+		FilterOrder: order.FilterOrder{
+			Code: code,
+		},
 	})
 	if err != nil {
-		s.l.Errorf(ctx, "Failed to update order status to cancelled for %s: %v", o.Code, err)
-		return order.ErrOrderCancellationFailed
+		if err == mongo.ErrNoDocuments {
+			return models.CheckoutStatus{}, order.ErrOrderNotFound
+		}
+		s.l.Errorf(ctx, "internal.order.service.GetCheckoutStatus.repo.GetOne: %v", err)
+		return models.CheckoutStatus{}, err
+	}
+
+	itms, err := s.repo.ListItemByOrderID(ctx, o.ID.Hex())
+	if err != nil {
+		s.l.Errorf(ctx, "internal.order.service.GetCheckoutStatus.repo.ListItemByOrderID: %v", err)
+		return models.CheckoutStatus{}, err
 	}
 
-	if o.SessionID != "" {
-		if err := s.publishCheckoutFailedEvent(ctx, order.PubCheckoutFailedEventInput{
-			SessionID: o.SessionID,
-			UserID:    o.UserID,
-			EventID:   o.EventID,
-		}); err != nil {
-			s.l.Warnf(ctx, "Failed to publish checkout cancelled event for order %s: %v", o.Code, err)
+	st := models.CheckoutStatus{
+		OrderCode:           o.Code,
+		State:               checkoutStateFromOrder(o),
+		AvailabilityChecked: true,
+		OrderPersisted:      true,
+		Reserved:            true,
+		PaymentUrlReady:     true,
+		Order:               &o,
+		OrderItems:          itms,
+		Error:               o.FailureReason,
+	}
+
+	if st.State == models.CheckoutStateAwaitingPayment {
+		pmtResp, err := s.pmtSvc.GetPaymentUrlByIdempotencyKey(ctx, &payment.GetPaymentUrlByIdempotencyKeyRequest{
+			IdempotencyKey: generatePaymentIdempotencyKey(o.Code, string(o.PaymentMethod)),
+		})
+		if err != nil {
+			s.l.Errorf(ctx, "internal.order.service.GetCheckoutStatus.pmtSvc.GetPaymentUrlByIdempotencyKey: %v", err)
+			return models.CheckoutStatus{}, err
 		}
+		st.PaymentUrl = pmtResp.PaymentUrl
 	}
 
-	return nil
+	return st, nil
 }
 
 func (s *implService) GetMany(ctx context.Context, in order.GetManyOrderInput) (order.GetManyOrderOutput, error) {
+	in.Pag.Adjust()
+
 	os, pag, err := s.repo.GetMany(ctx, repo.GetManyOrderOption(in))
 	if err != nil {
+		if err == order.ErrInvalidCursor {
+			s.l.Warnf(ctx, "internal.order.service.GetMany: %v", err)
+			return order.GetManyOrderOutput{}, err
+		}
 		s.l.Errorf(ctx, "internal.order.service.GetMany.repo.GetMany: %v", err)
 		return order.GetManyOrderOutput{}, err
 	}
@@ -330,12 +434,39 @@ func (s *implService) GetOne(ctx context.Context, in order.GetOneOrderInput) (mo
 	return o, nil
 }
 
-func (s *implService) List(ctx context.Context, in order.ListOrderInput) ([]models.Order, error) {
-	os, err := s.repo.List(ctx, repo.ListOrderOption(in))
+func (s *implService) List(ctx context.Context, in order.ListOrderInput) (order.ListOrderOutput, error) {
+	if in.Limit < 1 || in.Limit > paginator.MaxLimit {
+		in.Limit = paginator.MaxLimit
+	}
+
+	var after repo.ListOrderCursor
+	if in.Cursor != "" {
+		var err error
+		if after, err = repo.DecodeListCursor(in.Cursor); err != nil {
+			s.l.Warnf(ctx, "internal.order.service.List: %v", err)
+			return order.ListOrderOutput{}, err
+		}
+	}
+
+	// One more order than the limit tells whether the listing goes on
+	os, err := s.repo.List(ctx, repo.ListOrderOption{
+		FilterOrder: in.FilterOrder,
+		After:       after,
+		Limit:       in.Limit + 1,
+	})
 	if err != nil {
 		s.l.Errorf(ctx, "internal.order.service.List.repo.List: %v", err)
-		return nil, err
+		return order.ListOrderOutput{}, err
+	}
+
+	out := order.ListOrderOutput{Orders: os}
+	if int64(len(os)) > in.Limit {
+		out.Orders = os[:in.Limit]
+		if out.Next, err = repo.EncodeListCursor(out.Orders[in.Limit-1]); err != nil {
+			s.l.Errorf(ctx, "internal.order.service.List.repo.EncodeListCursor: %v", err)
+			return order.ListOrderOutput{}, err
+		}
 	}
 
-	return os, nil
+	return out, nil
 }
@@ -2,30 +2,109 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/vogiaan1904/ticketbottle-order/internal/activities"
+	"github.com/vogiaan1904/ticketbottle-order/internal/infra/temporal"
 	"github.com/vogiaan1904/ticketbottle-order/internal/models"
 	"github.com/vogiaan1904/ticketbottle-order/internal/order"
-	"github.com/vogiaan1904/ticketbottle-order/pkg/grpc/inventory"
+	"github.com/vogiaan1904/ticketbottle-order/internal/workflows"
+	"go.temporal.io/sdk/client"
+	temporalSdk "go.temporal.io/sdk/temporal"
 )
 
 func generatePaymentIdempotencyKey(orderCode string, provider string) string {
 	return fmt.Sprintf("%s:%s", orderCode, provider)
 }
 
-func (s *implService) releaseTickets(ctx context.Context, code string) error {
-	_, err := s.invSvc.Release(ctx, &inventory.ReleaseRequest{
+// checkoutStateFromOrder tells how far the checkout of a persisted order got
+func checkoutStateFromOrder(o models.Order) models.CheckoutState {
+	switch o.Status {
+	case models.OrderStatusPending:
+		return models.CheckoutStateAwaitingPayment
+	case models.OrderStatusPaymentReview:
+		return models.CheckoutStateProcessingPayment
+	case models.OrderStatusCancelled, models.OrderStatusPaymentFailed, models.OrderStatusTimeout:
+		return models.CheckoutStateFailed
+	default:
+		return models.CheckoutStateCompleted
+	}
+}
+
+// cancelOrder runs the CancelOrder workflow and waits until the cancellation is committed, for at
+// most 30 seconds. The workflow keeps retrying after that.
+func (s *implService) cancelOrder(ctx context.Context, code string, reason string, actor models.OrderActor) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	wfOpts := client.StartWorkflowOptions{
+		ID:        workflows.GetCancelOrderWorkflowID(code),
+		TaskQueue: temporal.CancelOrderTaskQueue,
+	}
+
+	wfIn := workflows.CancelOrderWorkflowInput{
 		OrderCode: code,
-	})
+		Reason:    reason,
+		Actor:     actor,
+	}
+
+	wfRun, err := s.temporal.ExecuteWorkflow(ctx, wfOpts, workflows.CancelOrder, &wfIn)
 	if err != nil {
-		s.l.Errorf(ctx, "Failed to release tickets for order %s: %v", code, err)
-		return err
+		s.l.Errorf(ctx, "failed to start cancel order workflow: %v", err)
+		return order.ErrOrderCancellationFailed
+	}
+
+	if _, err := s.awaitOrderCancelled(ctx, wfRun); err != nil {
+		s.l.Errorf(ctx, "cancel order workflow failed: %v", err)
+
+		var appErr *temporalSdk.ApplicationError
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			// The workflow keeps retrying, the order will be cancelled once the failing service recovers
+			return order.ErrOrderCancellationInProgress
+		case errors.As(err, &appErr) && appErr.Type() == activities.ErrTypePaymentAlreadyCompleted:
+			return order.ErrOrderAlreadyPaid
+		case errors.As(err, &appErr) && appErr.Type() == workflows.ErrTypeOrderNotPending:
+			return order.ErrOrderNotPending
+		case errors.As(err, &appErr) && appErr.Type() == activities.ErrTypeOrderConflict:
+			return order.ErrOrderConflict
+		case errors.As(err, &appErr) && appErr.Type() == activities.ErrTypeInvalidOrderTransition:
+			return order.ErrInvalidStatusTransition
+		default:
+			return order.ErrOrderCancellationFailed
+		}
 	}
 
-	s.l.Infof(ctx, "Successfully released tickets for order %s", code)
 	return nil
 }
 
+// awaitOrderCancelled waits until the CancelOrder workflow has committed the CANCELLED status,
+// without waiting for the event publishing that follows.
+func (s *implService) awaitOrderCancelled(ctx context.Context, wfRun client.WorkflowRun) (workflows.CancelOrderWorkflowResult, error) {
+	var res workflows.CancelOrderWorkflowResult
+
+	hdl, err := s.temporal.UpdateWorkflow(ctx, client.UpdateWorkflowOptions{
+		WorkflowID:   wfRun.GetID(),
+		RunID:        wfRun.GetRunID(),
+		UpdateName:   workflows.UpdateNameOrderCancelled,
+		WaitForStage: client.WorkflowUpdateStageCompleted,
+	})
+	if err == nil {
+		if err = hdl.Get(ctx, &res); err == nil {
+			return res, nil
+		}
+	}
+
+	// The workflow may have finished before the update was delivered, use its own result instead
+	if wfErr := wfRun.Get(ctx, &res); wfErr != nil {
+		return res, wfErr
+	}
+
+	return res, nil
+}
+
 func (s *implService) validateCheckoutToken(ctx context.Context, in order.CreateOrderInput) (models.CheckoutTokenClaim, error) {
 	if in.CheckoutToken == "" {
 		return models.CheckoutTokenClaim{}, order.ErrInvalidCheckoutToken
@@ -42,3 +121,28 @@ func (s *implService) validateCheckoutToken(ctx context.Context, in order.Create
 
 	return p.CheckoutTokenClaim, nil
 }
+
+// awaitPaymentUrl waits until the CreateOrder workflow has produced the payment URL.
+// The workflow keeps running afterwards, so its result is read through an update instead of wfRun.Get.
+func (s *implService) awaitPaymentUrl(ctx context.Context, wfRun client.WorkflowRun) (workflows.CreateOrderWorkflowResult, error) {
+	var res workflows.CreateOrderWorkflowResult
+
+	hdl, err := s.temporal.UpdateWorkflow(ctx, client.UpdateWorkflowOptions{
+		WorkflowID:   wfRun.GetID(),
+		RunID:        wfRun.GetRunID(),
+		UpdateName:   workflows.UpdateNamePaymentUrl,
+		WaitForStage: client.WorkflowUpdateStageCompleted,
+	})
+	if err == nil {
+		if err = hdl.Get(ctx, &res); err == nil {
+			return res, nil
+		}
+	}
+
+	// The workflow may have failed before the update was delivered, surface its own error instead
+	if wfErr := wfRun.Get(ctx, nil); wfErr != nil {
+		return res, wfErr
+	}
+
+	return res, err
+}
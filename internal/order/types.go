@@ -16,9 +16,13 @@ type CreateOrderInput struct {
 	RedirectUrl   string
 	PaymentMethod models.PaymentMethod
 	Items         []OrderItemInput
+	// Async returns as soon as the checkout started, progress is read through GetCheckoutStatus
+	Async bool
 }
 
 type CreateOrderOutput struct {
+	OrderCode  string
+	State      models.CheckoutState
 	Order      *models.Order
 	OrderItems []models.OrderItem
 	PaymentUrl string
@@ -39,22 +43,52 @@ type FilterOrder struct {
 
 type GetManyOrderInput struct {
 	FilterOrder
-	Pag paginator.PaginatorQuery
+	Pag paginator.CursorQuery
 }
 
 type GetManyOrderOutput struct {
 	Orders []models.Order
-	Pag    paginator.Paginator
+	Pag    paginator.CursorPaginator
 }
 
 type ListOrderInput struct {
 	FilterOrder
+	// Cursor is the Next cursor of a previous call, empty starts from the newest order
+	Cursor string
+	// Limit is capped at paginator.MaxLimit, zero asks for the cap
+	Limit int64
+}
+
+type ListOrderOutput struct {
+	Orders []models.Order
+	// Next continues the listing, empty when no order is left
+	Next string
 }
 
 type GetOneOrderInput struct {
 	FilterOrder
 }
 
+type CancelOrderInput struct {
+	ID string
+	// UserID is the customer cancelling the order, it must own the order and is recorded in the status history
+	UserID string
+}
+
+type RefundOrderInput struct {
+	ID     string
+	Reason string
+	// Items to refund, an empty list refunds everything that is still refundable
+	Items []RefundOrderItemInput
+	// Operator issues the refund, recorded in the status history
+	Operator string
+}
+
+type RefundOrderItemInput struct {
+	TicketClassID string
+	Quantity      int32
+}
+
 type ReservedTicket struct {
 	OrderCode     string
 	TicketClassID string
@@ -0,0 +1,173 @@
+package relay
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/vogiaan1904/ticketbottle-order/internal/models"
+	outboxRepo "github.com/vogiaan1904/ticketbottle-order/internal/outbox/repository"
+	"github.com/vogiaan1904/ticketbottle-order/pkg/logger"
+)
+
+const (
+	// leaseTTL is how long a relay keeps publishing after it last renewed its lease, another
+	// replica takes over once it expires
+	leaseTTL = 15 * time.Second
+	// leaseRenewInterval is how often the lease is renewed while a batch is published, well below
+	// leaseTTL so a slow batch does not let another replica publish the same messages
+	leaseRenewInterval = 5 * time.Second
+
+	minBackoff = time.Second
+	maxBackoff = 5 * time.Minute
+)
+
+type Config struct {
+	PollInterval time.Duration
+	BatchSize    int
+	// MaxAttempts is how many times a message is tried before it is moved to DEAD, 0 retries forever
+	MaxAttempts int
+}
+
+// Relay publishes pending outbox messages to Kafka. Only the replica holding the lease publishes,
+// and messages sharing a key are published in the order they were written: a key whose oldest
+// message is waiting for a retry holds back the rest of that key, but not the other keys. A message
+// still failing after MaxAttempts is moved to DEAD and releases its key.
+type Relay struct {
+	repo  outboxRepo.Repository
+	prod  sarama.SyncProducer
+	l     logger.Logger
+	cfg   Config
+	owner string
+}
+
+func New(repo outboxRepo.Repository, prod sarama.SyncProducer, l logger.Logger, cfg Config) *Relay {
+	host, _ := os.Hostname()
+
+	return &Relay{
+		repo:  repo,
+		prod:  prod,
+		l:     l,
+		cfg:   cfg,
+		owner: fmt.Sprintf("%s-%d", host, os.Getpid()),
+	}
+}
+
+// Run polls the outbox until ctx is done
+func (r *Relay) Run(ctx context.Context) {
+	t := time.NewTicker(r.cfg.PollInterval)
+	defer t.Stop()
+
+	for {
+		if err := r.poll(ctx); err != nil && ctx.Err() == nil {
+			r.l.Errorf(ctx, "outbox.relay.Run.poll: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
+func (r *Relay) poll(ctx context.Context) error {
+	ok, err := r.repo.AcquireLease(ctx, r.owner, leaseTTL)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return nil
+	}
+
+	ms, err := r.repo.ListReady(ctx, int64(r.cfg.BatchSize))
+	if err != nil {
+		return err
+	}
+
+	renewedAt := time.Now()
+	blocked := make(map[string]bool)
+	for _, m := range ms {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		// Messages without a key are not ordered, a failed one holds nothing back
+		if m.Key != "" && blocked[m.Key] {
+			continue
+		}
+
+		// Stop the batch once another replica took the lease over
+		if time.Since(renewedAt) >= leaseRenewInterval {
+			ok, err := r.repo.AcquireLease(ctx, r.owner, leaseTTL)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return nil
+			}
+			renewedAt = time.Now()
+		}
+
+		if err := r.publish(ctx, m); err != nil {
+			blocked[m.Key] = true
+		}
+	}
+
+	return nil
+}
+
+func (r *Relay) publish(ctx context.Context, m models.OutboxMessage) error {
+	msg := &sarama.ProducerMessage{
+		Topic:   m.Topic,
+		Value:   sarama.ByteEncoder(m.Payload),
+		Headers: make([]sarama.RecordHeader, 0, len(m.Headers)),
+	}
+	if m.Key != "" {
+		msg.Key = sarama.StringEncoder(m.Key)
+	}
+	for k, v := range m.Headers {
+		msg.Headers = append(msg.Headers, sarama.RecordHeader{Key: []byte(k), Value: []byte(v)})
+	}
+
+	if _, _, err := r.prod.SendMessage(msg); err != nil {
+		r.l.Errorf(ctx, "outbox.relay.publish.prod.SendMessage: %v", err)
+
+		attempts := m.Attempts + 1
+		dead := r.cfg.MaxAttempts > 0 && attempts >= r.cfg.MaxAttempts
+		if dead {
+			r.l.Errorf(ctx, "Outbox message %s to %s moved to DEAD after %d attempts: %v", m.ID.Hex(), m.Topic, attempts, err)
+		}
+
+		if mErr := r.repo.MarkFailed(ctx, m.ID.Hex(), outboxRepo.MarkFailedOutboxMessageOption{
+			Error:         err.Error(),
+			NextAttemptAt: time.Now().Add(backoff(attempts)),
+			Dead:          dead,
+		}); mErr != nil {
+			r.l.Errorf(ctx, "outbox.relay.publish.repo.MarkFailed: %v", mErr)
+		}
+		return err
+	}
+
+	// A message published but not marked sent is published again on the next poll, consumers
+	// must tolerate the duplicate
+	if err := r.repo.MarkSent(ctx, m.ID.Hex()); err != nil {
+		r.l.Errorf(ctx, "outbox.relay.publish.repo.MarkSent: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// backoff doubles the wait after every failed attempt, up to maxBackoff
+func backoff(attempt int) time.Duration {
+	d := minBackoff
+	for i := 1; i < attempt && d < maxBackoff; i++ {
+		d *= 2
+	}
+	if d > maxBackoff {
+		d = maxBackoff
+	}
+	return d
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/vogiaan1904/ticketbottle-order/internal/models"
+)
+
+type Repository interface {
+	// Create joins the Mongo transaction carried by ctx, if any
+	Create(ctx context.Context, opt CreateOutboxMessageOption) (models.OutboxMessage, error)
+	// ListReady returns the pending messages that are due, oldest first. Messages of a key whose oldest
+	// message waits for a retry are left out, so a failing key does not hold back the others.
+	ListReady(ctx context.Context, limit int64) ([]models.OutboxMessage, error)
+	MarkSent(ctx context.Context, ID string) error
+	MarkFailed(ctx context.Context, ID string, opt MarkFailedOutboxMessageOption) error
+	// AcquireLease takes or renews the relay lease for owner, it reports false while another owner holds it
+	AcquireLease(ctx context.Context, owner string, ttl time.Duration) (bool, error)
+}
@@ -0,0 +1,17 @@
+package repository
+
+import "time"
+
+type CreateOutboxMessageOption struct {
+	Topic   string
+	Key     string
+	Payload []byte
+	Headers map[string]string
+}
+
+type MarkFailedOutboxMessageOption struct {
+	Error         string
+	NextAttemptAt time.Time
+	// Dead moves the message to DEAD instead of scheduling another attempt
+	Dead bool
+}
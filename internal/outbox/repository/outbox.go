@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/vogiaan1904/ticketbottle-order/internal/models"
+	"github.com/vogiaan1904/ticketbottle-order/pkg/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	outboxCollection      = "outbox"
+	outboxLeaseCollection = "outbox_leases"
+
+	relayLeaseID = "relay"
+)
+
+func (r *implRepository) getOutboxCollection() mongo.Collection {
+	return r.db.Collection(outboxCollection)
+}
+
+func (r *implRepository) getOutboxLeaseCollection() mongo.Collection {
+	return r.db.Collection(outboxLeaseCollection)
+}
+
+func (r *implRepository) Create(ctx context.Context, opt CreateOutboxMessageOption) (models.OutboxMessage, error) {
+	col := r.getOutboxCollection()
+
+	m := r.buildOutboxMessageModel(opt)
+	if _, err := col.InsertOne(ctx, m); err != nil {
+		r.l.Errorf(ctx, "outbox.repository.OutboxRepository.Create: %v", err)
+		return models.OutboxMessage{}, err
+	}
+
+	return m, nil
+}
+
+func (r *implRepository) ListReady(ctx context.Context, limit int64) ([]models.OutboxMessage, error) {
+	col := r.getOutboxCollection()
+
+	now := r.clock()
+	blocked, err := r.listBlockedKeys(ctx, now)
+	if err != nil {
+		r.l.Errorf(ctx, "outbox.repository.OutboxRepository.ListReady.listBlockedKeys: %v", err)
+		return nil, err
+	}
+
+	fil := bson.M{
+		"status":          models.OutboxStatusPending,
+		"next_attempt_at": bson.M{"$lte": now},
+	}
+	if len(blocked) > 0 {
+		fil["key"] = bson.M{"$nin": blocked}
+	}
+
+	cur, err := col.Find(ctx, fil, options.Find().
+		SetSort(bson.D{
+			{Key: "created_at", Value: 1},
+			{Key: "_id", Value: 1},
+		}).
+		SetLimit(limit))
+	if err != nil {
+		r.l.Errorf(ctx, "outbox.repository.OutboxRepository.ListReady: %v", err)
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var ms []models.OutboxMessage
+	if err := cur.All(ctx, &ms); err != nil {
+		r.l.Errorf(ctx, "outbox.repository.OutboxRepository.ListReady: %v", err)
+		return nil, err
+	}
+
+	return ms, nil
+}
+
+// listBlockedKeys returns the keys whose oldest pending message waits for a retry. Only the oldest
+// message of a key is ever attempted, so a key is blocked exactly when one of its messages is not due.
+// Messages without a key are not ordered and never block each other.
+func (r *implRepository) listBlockedKeys(ctx context.Context, now time.Time) ([]string, error) {
+	col := r.getOutboxCollection()
+
+	cur, err := col.Aggregate(ctx, bson.A{
+		bson.M{"$match": bson.M{
+			"status":          models.OutboxStatusPending,
+			"next_attempt_at": bson.M{"$gt": now},
+			"key":             bson.M{"$ne": ""},
+		}},
+		bson.M{"$group": bson.M{"_id": "$key"}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var rows []struct {
+		Key string `bson:"_id"`
+	}
+	if err := cur.All(ctx, &rows); err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, len(rows))
+	for i, row := range rows {
+		keys[i] = row.Key
+	}
+
+	return keys, nil
+}
+
+func (r *implRepository) MarkSent(ctx context.Context, ID string) error {
+	col := r.getOutboxCollection()
+
+	objID, err := primitive.ObjectIDFromHex(ID)
+	if err != nil {
+		r.l.Errorf(ctx, "outbox.repository.OutboxRepository.MarkSent: %v", err)
+		return err
+	}
+
+	now := r.clock()
+	_, err = col.UpdateOne(ctx, bson.M{"_id": objID}, bson.M{
+		"$set": bson.M{
+			"status":     models.OutboxStatusSent,
+			"sent_at":    now,
+			"updated_at": now,
+		},
+		"$unset": bson.M{"last_error": ""},
+	})
+	if err != nil {
+		r.l.Errorf(ctx, "outbox.repository.OutboxRepository.MarkSent: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *implRepository) MarkFailed(ctx context.Context, ID string, opt MarkFailedOutboxMessageOption) error {
+	col := r.getOutboxCollection()
+
+	objID, err := primitive.ObjectIDFromHex(ID)
+	if err != nil {
+		r.l.Errorf(ctx, "outbox.repository.OutboxRepository.MarkFailed: %v", err)
+		return err
+	}
+
+	set := bson.M{
+		"last_error":      opt.Error,
+		"next_attempt_at": opt.NextAttemptAt,
+		"updated_at":      r.clock(),
+	}
+	if opt.Dead {
+		set["status"] = models.OutboxStatusDead
+	}
+
+	_, err = col.UpdateOne(ctx, bson.M{"_id": objID}, bson.M{
+		"$set": set,
+		"$inc": bson.M{"attempts": 1},
+	})
+	if err != nil {
+		r.l.Errorf(ctx, "outbox.repository.OutboxRepository.MarkFailed: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *implRepository) AcquireLease(ctx context.Context, owner string, ttl time.Duration) (bool, error) {
+	col := r.getOutboxLeaseCollection()
+
+	now := r.clock()
+	fil := bson.M{
+		"_id": relayLeaseID,
+		"$or": bson.A{
+			bson.M{"owner": owner},
+			bson.M{"expires_at": bson.M{"$lte": now}},
+		},
+	}
+
+	// The upsert only inserts when no lease exists yet, a lease held by another owner makes it
+	// collide on _id instead
+	_, err := col.UpdateOne(ctx, fil, bson.M{
+		"$set": bson.M{
+			"owner":      owner,
+			"expires_at": now.Add(ttl),
+		},
+	}, options.Update().SetUpsert(true))
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		r.l.Errorf(ctx, "outbox.repository.OutboxRepository.AcquireLease: %v", err)
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"github.com/vogiaan1904/ticketbottle-order/internal/models"
+)
+
+func (r *implRepository) buildOutboxMessageModel(opt CreateOutboxMessageOption) models.OutboxMessage {
+	now := r.clock()
+	m := models.OutboxMessage{
+		ID:            r.db.NewObjectID(),
+		Topic:         opt.Topic,
+		Key:           opt.Key,
+		Payload:       opt.Payload,
+		Headers:       opt.Headers,
+		Status:        models.OutboxStatusPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	return m
+}
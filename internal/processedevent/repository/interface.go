@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+type Repository interface {
+	// Claim records that key is being processed. It reports false when key was already processed,
+	// or is being processed and its claim has not expired.
+	Claim(ctx context.Context, key string, opt ClaimOption) (bool, error)
+	// Complete marks a claimed key processed until ttl elapses
+	Complete(ctx context.Context, key string, ttl time.Duration) error
+	// Release drops the claim on a key whose processing failed, so a redelivery runs it again
+	Release(ctx context.Context, key string) error
+}
@@ -0,0 +1,10 @@
+package repository
+
+import "time"
+
+type ClaimOption struct {
+	// LockTTL is how long the claim holds before another consumer may take the key over
+	LockTTL time.Duration
+	// TTL is how long the record is kept when it is never completed
+	TTL time.Duration
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/vogiaan1904/ticketbottle-order/internal/models"
+	"github.com/vogiaan1904/ticketbottle-order/pkg/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const processedEventCollection = "processed_events"
+
+func (r *implRepository) getProcessedEventCollection() mongo.Collection {
+	return r.db.Collection(processedEventCollection)
+}
+
+func (r *implRepository) Claim(ctx context.Context, key string, opt ClaimOption) (bool, error) {
+	col := r.getProcessedEventCollection()
+
+	now := r.clock()
+	fil := bson.M{
+		"_id": key,
+		"$or": bson.A{
+			bson.M{
+				"status":       models.ProcessedEventStatusProcessing,
+				"locked_until": bson.M{"$lte": now},
+			},
+			// Expired records live on until the TTL monitor removes them
+			bson.M{"expires_at": bson.M{"$lte": now}},
+		},
+	}
+
+	// The upsert only inserts when the key is new, a live record makes it collide on _id instead
+	_, err := col.UpdateOne(ctx, fil, bson.M{
+		"$set": bson.M{
+			"status":       models.ProcessedEventStatusProcessing,
+			"locked_until": now.Add(opt.LockTTL),
+			"expires_at":   now.Add(opt.TTL),
+			"created_at":   now,
+			"updated_at":   now,
+		},
+	}, options.Update().SetUpsert(true))
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		r.l.Errorf(ctx, "processedevent.repository.ProcessedEventRepository.Claim: %v", err)
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *implRepository) Complete(ctx context.Context, key string, ttl time.Duration) error {
+	col := r.getProcessedEventCollection()
+
+	now := r.clock()
+	_, err := col.UpdateOne(ctx, bson.M{"_id": key}, bson.M{
+		"$set": bson.M{
+			"status":     models.ProcessedEventStatusDone,
+			"expires_at": now.Add(ttl),
+			"updated_at": now,
+		},
+	})
+	if err != nil {
+		r.l.Errorf(ctx, "processedevent.repository.ProcessedEventRepository.Complete: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *implRepository) Release(ctx context.Context, key string) error {
+	col := r.getProcessedEventCollection()
+
+	// An expired lock lets the next Claim take the key over
+	now := r.clock()
+	_, err := col.UpdateOne(ctx, bson.M{
+		"_id":    key,
+		"status": models.ProcessedEventStatusProcessing,
+	}, bson.M{
+		"$set": bson.M{
+			"locked_until": now,
+			"updated_at":   now,
+		},
+	})
+	if err != nil {
+		r.l.Errorf(ctx, "processedevent.repository.ProcessedEventRepository.Release: %v", err)
+		return err
+	}
+
+	return nil
+}
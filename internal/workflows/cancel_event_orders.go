@@ -0,0 +1,254 @@
+package workflows
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/vogiaan1904/ticketbottle-order/internal/activities"
+	"github.com/vogiaan1904/ticketbottle-order/internal/infra/temporal"
+	"github.com/vogiaan1904/ticketbottle-order/internal/models"
+	repo "github.com/vogiaan1904/ticketbottle-order/internal/order/repository"
+	"go.temporal.io/sdk/workflow"
+)
+
+const (
+	// eventOrdersPageSize is how many orders one run handles before continuing as new, which keeps
+	// the history of events with many orders bounded
+	eventOrdersPageSize = 100
+
+	// DefaultEventOrdersConcurrency and DefaultEventOrdersBatchInterval limit the rate orders are
+	// cancelled or refunded at: that many child workflows at once, then a pause
+	DefaultEventOrdersConcurrency   = 10
+	DefaultEventOrdersBatchInterval = time.Second
+
+	// Orders whose status changed while they were handled, such as a payment completing right before
+	// the cancellation, are looked at again this many times
+	eventOrdersRecheckRounds   = 10
+	eventOrdersRecheckInterval = time.Minute
+)
+
+type CancelEventOrdersWorkflowInput struct {
+	EventID       string
+	Reason        string
+	Concurrency   int
+	BatchInterval time.Duration
+	// Progress is carried over when the workflow continues as new
+	Progress *EventOrdersProgress
+}
+
+// EventOrdersProgress is the result of CancelEventOrders, also read through the
+// QueryNameEventOrdersProgress query while it runs
+type EventOrdersProgress struct {
+	EventID string
+	// Cursor is where the next page starts
+	Cursor    repo.ListOrderCursor
+	Pages     int
+	Listed    int
+	Cancelled int
+	Refunded  int
+	// Skipped orders needed nothing, they were already cancelled, failed or refunded
+	Skipped int
+	// Recheck lists the orders to look at again once every page is handled
+	Recheck []string
+	// Failed lists the orders that could not be cancelled or refunded
+	Failed []string
+	Swept  bool
+	Done   bool
+}
+
+type eventOrderOutcome int
+
+const (
+	eventOrderSkipped eventOrderOutcome = iota
+	eventOrderCancelled
+	eventOrderRefunded
+	eventOrderRecheck
+	eventOrderFailed
+)
+
+func GetCancelEventOrdersWorkflowID(eventID string) string {
+	return fmt.Sprintf("CancelEventOrders:%s", eventID)
+}
+
+// CancelEventOrders cancels or refunds every order of a cancelled event
+// 1. List Orders - One page of the event's orders per run, then continue as new with the cursor
+// 2. Handle Orders - A batch of child workflows at a time, with a pause between batches
+//   - PENDING: CancelOrder, which cancels the payment and releases the tickets
+//   - COMPLETED, PARTIALLY_REFUNDED: RefundOrder for everything still refundable
+//   - PAYMENT_REVIEW: waits for the operator, rechecked later
+//   - Anything else is skipped
+//
+// 3. Recheck - Orders that changed status while handled are handled again once every page is done
+//
+// The progress is exposed through the QueryNameEventOrdersProgress query.
+func CancelEventOrders(ctx workflow.Context, in *CancelEventOrdersWorkflowInput) (*EventOrdersProgress, error) {
+	logger := workflow.GetLogger(ctx)
+
+	p := in.Progress
+	if p == nil {
+		p = &EventOrdersProgress{EventID: in.EventID}
+	}
+	logger.Info("Starting cancel event orders workflow", "eventID", in.EventID, "page", p.Pages+1)
+
+	err := workflow.SetQueryHandler(ctx, QueryNameEventOrdersProgress, func() (EventOrdersProgress, error) {
+		return *p, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = workflow.WithActivityOptions(ctx, getCancelEventOrdersActivityOptions())
+
+	// 1. List the next page and 2. handle its orders
+	if !p.Swept {
+		page, err := listEventOrders(ctx, in.EventID, p.Cursor, eventOrdersPageSize)
+		if err != nil {
+			logger.Error("Failed to list event orders", "error", err, "eventID", in.EventID)
+			return nil, err
+		}
+		p.Pages++
+		p.Listed += len(page.Orders)
+
+		handleEventOrders(ctx, in, p, page.Orders)
+
+		p.Cursor = page.Next
+		if !p.Cursor.IsZero() {
+			next := *in
+			next.Progress = p
+			return nil, workflow.NewContinueAsNewError(ctx, CancelEventOrders, &next)
+		}
+		p.Swept = true
+	}
+
+	// 3. Recheck orders whose status changed while they were handled
+	for round := 0; round < eventOrdersRecheckRounds && len(p.Recheck) > 0; round++ {
+		if err := workflow.Sleep(ctx, eventOrdersRecheckInterval); err != nil {
+			return nil, err
+		}
+
+		codes := p.Recheck
+		p.Recheck = nil
+
+		os := make([]activities.EventOrder, 0, len(codes))
+		for _, code := range codes {
+			o, err := validateOrder(ctx, code)
+			if err != nil {
+				logger.Error("Failed to get order", "error", err, "orderCode", code)
+				p.Failed = append(p.Failed, code)
+				continue
+			}
+			os = append(os, activities.EventOrder{Code: o.Code, Status: o.Status})
+		}
+
+		handleEventOrders(ctx, in, p, os)
+	}
+
+	if len(p.Recheck) > 0 {
+		logger.Warn("Orders still changing after the last recheck", "eventID", in.EventID, "orders", p.Recheck)
+		p.Failed = append(p.Failed, p.Recheck...)
+		p.Recheck = nil
+	}
+	p.Done = true
+
+	logger.Info("Event orders handled", "eventID", in.EventID,
+		"cancelled", p.Cancelled, "refunded", p.Refunded, "skipped", p.Skipped, "failed", len(p.Failed))
+	return p, nil
+}
+
+// handleEventOrders handles os in batches of in.Concurrency and records the outcomes in p
+func handleEventOrders(ctx workflow.Context, in *CancelEventOrdersWorkflowInput, p *EventOrdersProgress, os []activities.EventOrder) {
+	conc := in.Concurrency
+	if conc <= 0 {
+		conc = DefaultEventOrdersConcurrency
+	}
+	interval := in.BatchInterval
+	if interval <= 0 {
+		interval = DefaultEventOrdersBatchInterval
+	}
+
+	for start := 0; start < len(os); start += conc {
+		batch := os[start:min(start+conc, len(os))]
+
+		outcomes := make([]eventOrderOutcome, len(batch))
+		wg := workflow.NewWaitGroup(ctx)
+		for i, o := range batch {
+			wg.Add(1)
+			workflow.Go(ctx, func(ctx workflow.Context) {
+				defer wg.Done()
+				outcomes[i] = handleEventOrder(ctx, o, in.Reason)
+			})
+		}
+		wg.Wait(ctx)
+
+		for i, oc := range outcomes {
+			switch oc {
+			case eventOrderCancelled:
+				p.Cancelled++
+			case eventOrderRefunded:
+				p.Refunded++
+			case eventOrderRecheck:
+				p.Recheck = append(p.Recheck, batch[i].Code)
+			case eventOrderFailed:
+				p.Failed = append(p.Failed, batch[i].Code)
+			default:
+				p.Skipped++
+			}
+		}
+
+		_ = workflow.Sleep(ctx, interval)
+	}
+}
+
+func handleEventOrder(ctx workflow.Context, o activities.EventOrder, reason string) eventOrderOutcome {
+	logger := workflow.GetLogger(ctx)
+
+	switch o.Status {
+	case models.OrderStatusPending:
+		cwCtx := workflow.WithChildOptions(ctx, workflow.ChildWorkflowOptions{
+			WorkflowID: GetCancelOrderWorkflowID(o.Code),
+			TaskQueue:  temporal.CancelOrderTaskQueue,
+		})
+		err := workflow.ExecuteChildWorkflow(cwCtx, CancelOrder, &CancelOrderWorkflowInput{
+			OrderCode: o.Code,
+			Reason:    reason,
+			Actor:     models.SystemActor(models.OrderChangeSourceKafka),
+		}).Get(ctx, nil)
+		switch {
+		case err == nil:
+			return eventOrderCancelled
+		case isOrderNotPending(err), isPaymentAlreadyCompleted(err), isWorkflowAlreadyStarted(err):
+			// Paid or cancelled in the meantime
+			return eventOrderRecheck
+		default:
+			logger.Error("Failed to cancel order", "error", err, "orderCode", o.Code)
+			return eventOrderFailed
+		}
+
+	case models.OrderStatusCompleted, models.OrderStatusPartiallyRefunded:
+		cwCtx := workflow.WithChildOptions(ctx, workflow.ChildWorkflowOptions{
+			WorkflowID: GetRefundOrderWorkflowID(o.Code),
+			TaskQueue:  temporal.RefundOrderTaskQueue,
+		})
+		err := workflow.ExecuteChildWorkflow(cwCtx, RefundOrder, &RefundOrderWorkflowInput{
+			OrderCode: o.Code,
+			Reason:    reason,
+			Actor:     models.SystemActor(models.OrderChangeSourceKafka),
+		}).Get(ctx, nil)
+		switch {
+		case err == nil:
+			return eventOrderRefunded
+		case isWorkflowAlreadyStarted(err):
+			// A refund requested by the customer is running, the rest is refunded once it finished
+			return eventOrderRecheck
+		default:
+			logger.Error("Failed to refund order", "error", err, "orderCode", o.Code)
+			return eventOrderFailed
+		}
+
+	case models.OrderStatusPaymentReview:
+		return eventOrderRecheck
+
+	default:
+		return eventOrderSkipped
+	}
+}
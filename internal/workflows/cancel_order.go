@@ -0,0 +1,139 @@
+package workflows
+
+import (
+	"fmt"
+
+	"github.com/vogiaan1904/ticketbottle-order/internal/models"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+type CancelOrderWorkflowInput struct {
+	OrderCode string
+	// Reason is stored as the failure reason of the order, empty for a customer cancellation
+	Reason string
+	// Actor is recorded with the status change in the order history
+	Actor models.OrderActor
+}
+
+type CancelOrderWorkflowResult struct {
+	Order *models.Order
+}
+
+func GetCancelOrderWorkflowID(oCode string) string {
+	return fmt.Sprintf("CancelOrder:%s", oCode)
+}
+
+// CancelOrder cancels a pending order, every step is retried until it succeeds
+// 1. Validate Order - Order must be PENDING (an already CANCELLED order is a no-op)
+// 2. Cancel Payment - The payment URL can no longer be paid, an already paid order is not cancelled
+// 3. Release Tickets - Give the reservation back to inventory
+// 4. Update Order Status - Mark the order CANCELLED and write checkout.failed with it
+// 5. Publish checkout.failed to free the waitroom slot (executions started before step 4 wrote it)
+//
+// The caller is answered through the UpdateNameOrderCancelled update as soon as the status is committed.
+func CancelOrder(ctx workflow.Context, in *CancelOrderWorkflowInput) (*CancelOrderWorkflowResult, error) {
+	logger := workflow.GetLogger(ctx)
+	logger.Info("Starting cancel order workflow", "orderCode", in.OrderCode)
+
+	var res *CancelOrderWorkflowResult
+	var cancelErr error
+
+	err := workflow.SetUpdateHandler(ctx, UpdateNameOrderCancelled, func(ctx workflow.Context) (*CancelOrderWorkflowResult, error) {
+		if err := workflow.Await(ctx, func() bool { return res != nil || cancelErr != nil }); err != nil {
+			return nil, err
+		}
+		if cancelErr != nil {
+			return nil, cancelErr
+		}
+		return res, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = workflow.WithActivityOptions(ctx, getCancelOrderActivityOptions())
+
+	o, published, err := cancelOrder(ctx, in.OrderCode, in.Reason, in.Actor)
+	if err != nil {
+		cancelErr = err
+		_ = workflow.Await(ctx, func() bool { return workflow.AllHandlersFinished(ctx) })
+		return nil, err
+	}
+	res = &CancelOrderWorkflowResult{Order: o}
+
+	// 5. Publish checkout failed event to free waitroom slot, done by step 4 since the transition change
+	if !published {
+		if err := publishCheckoutFailed(ctx, o.SessionID, o.UserID, o.EventID); err != nil {
+			logger.Warn("Failed to publish checkout failed event", "error", err, "sessionID", o.SessionID)
+		}
+	}
+
+	_ = workflow.Await(ctx, func() bool { return workflow.AllHandlersFinished(ctx) })
+
+	logger.Info("Order cancelled successfully", "orderCode", in.OrderCode)
+	return res, nil
+}
+
+// cancelOrder runs the steps up to the status transition. It reports true when the checkout event
+// was already published, by the transition itself or by an earlier cancellation.
+func cancelOrder(ctx workflow.Context, oCode string, reason string, actor models.OrderActor) (*models.Order, bool, error) {
+	logger := workflow.GetLogger(ctx)
+
+	// 1. Validate order
+	o, err := validateOrder(ctx, oCode)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if o.Status == models.OrderStatusCancelled {
+		logger.Info("Order already cancelled", "orderCode", oCode)
+		return o, true, nil
+	}
+
+	if o.Status != models.OrderStatusPending {
+		logger.Warn("Order is not pending", "orderCode", oCode, "status", o.Status)
+		return nil, false, temporal.NewNonRetryableApplicationError(ErrOrderNotPending.Error(), ErrTypeOrderNotPending, ErrOrderNotPending)
+	}
+
+	// 2. Cancel payment intent
+	if err := cancelPayment(ctx, o.PaymentID); err != nil {
+		logger.Error("Failed to cancel payment", "error", err, "orderCode", oCode)
+		return nil, false, err
+	}
+
+	// 3. Release tickets
+	if err := releaseInventory(ctx, oCode); err != nil {
+		logger.Error("Failed to release inventory", "error", err, "orderCode", oCode)
+		return nil, false, err
+	}
+
+	// 4. Update order status to CANCELLED
+	if workflow.GetVersion(ctx, ChangeIDCancelOrderTransition, workflow.DefaultVersion, 1) == workflow.DefaultVersion {
+		if err := updateOrderStatus(ctx, o.ID.Hex(), models.OrderStatusCancelled); err != nil {
+			logger.Error("Failed to update order status", "error", err, "orderCode", oCode)
+			if isInvalidOrderTransition(err) {
+				return nil, false, temporal.NewNonRetryableApplicationError(ErrOrderNotPending.Error(), ErrTypeOrderNotPending, ErrOrderNotPending)
+			}
+			return nil, false, err
+		}
+		o.Status = models.OrderStatusCancelled
+
+		return o, false, nil
+	}
+
+	applied, err := transitionOrder(ctx, o.ID.Hex(), models.OrderStatusPending, models.OrderStatusCancelled, reason, actor)
+	if err != nil {
+		logger.Error("Failed to update order status", "error", err, "orderCode", oCode)
+		return nil, false, err
+	}
+	if !applied {
+		logger.Warn("Order left PENDING before it could be cancelled", "orderCode", oCode)
+		return nil, false, temporal.NewNonRetryableApplicationError(ErrOrderNotPending.Error(), ErrTypeOrderNotPending, ErrOrderNotPending)
+	}
+	o.Status = models.OrderStatusCancelled
+	o.FailureReason = reason
+
+	// The transition published checkout.failed with the status
+	return o, true, nil
+}
@@ -2,6 +2,7 @@ package workflows
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/vogiaan1904/ticketbottle-order/internal/models"
 	"go.temporal.io/sdk/workflow"
@@ -14,10 +15,39 @@ func GetConfirmOrderWorkflowID(oCode string) string {
 type ConfirmOrderWorkflowInput struct {
 	OrderCode string
 	Status    models.OrderStatus
+	// Payment is verified against the order before it is completed, nil skips the verification
+	Payment *PaymentDetails
+	// Actor is recorded with the completion in the order history, the decisions of an operator are
+	// recorded as theirs
+	Actor models.OrderActor
+}
+
+type ConfirmOrderWorkflowResult struct {
+	// Status is the order status the workflow left the order in
+	Status models.OrderStatus
+	// LatePaymentRefunded is set when the order had already been closed and the payment was refunded
+	LatePaymentRefunded bool
+}
+
+// completedResult and refundedResult turn the error of a step ending the workflow into its result
+func completedResult(err error) (*ConfirmOrderWorkflowResult, error) {
+	if err != nil {
+		return nil, err
+	}
+	return &ConfirmOrderWorkflowResult{Status: models.OrderStatusCompleted}, nil
+}
+
+func refundedResult(err error) (*ConfirmOrderWorkflowResult, error) {
+	if err != nil {
+		return nil, err
+	}
+	return &ConfirmOrderWorkflowResult{Status: models.OrderStatusRefunded}, nil
 }
 
 // ProcessPostPaymentOrder handles the post-payment phase of order processing
-func ConfirmOrder(ctx workflow.Context, in *ConfirmOrderWorkflowInput) error {
+// A step that still fails after its retries opens an intervention and waits for an operator,
+// who either retries the step or refunds the order.
+func ConfirmOrder(ctx workflow.Context, in *ConfirmOrderWorkflowInput) (*ConfirmOrderWorkflowResult, error) {
 	logger := workflow.GetLogger(ctx)
 	logger.Info("Starting confirm order workflow", "orderCode", in.OrderCode)
 
@@ -26,38 +56,284 @@ func ConfirmOrder(ctx workflow.Context, in *ConfirmOrderWorkflowInput) error {
 	// 1. Validate order
 	o, err := validateOrder(ctx, in.OrderCode)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if o.Status != models.OrderStatusPending {
 		logger.Warn("Order already processed", "orderCode", in.OrderCode, "status", o.Status)
 		if o.Status == models.OrderStatusCompleted {
-			return nil
+			return &ConfirmOrderWorkflowResult{Status: o.Status}, nil
+		}
+		if o.IsClosedUnpaid() && in.Payment != nil &&
+			workflow.GetVersion(ctx, ChangeIDConfirmOrderRefundLatePayment, workflow.DefaultVersion, 1) >= 1 {
+			return refundLatePayment(ctx, o, in.Payment)
+		}
+		return nil, ErrOrderAlreadyProcessed
+	}
+
+	if workflow.GetVersion(ctx, ChangeIDConfirmOrderIntervention, workflow.DefaultVersion, 1) == workflow.DefaultVersion {
+		return completedResult(confirmOrderWithoutIntervention(ctx, o))
+	}
+
+	// 2. Verify payment, a mismatch holds the order in PAYMENT_REVIEW until an operator decides
+	from := models.OrderStatusPending
+	actor := in.Actor
+	if workflow.GetVersion(ctx, ChangeIDConfirmOrderVerifyPayment, workflow.DefaultVersion, 1) >= 1 && in.Payment != nil {
+		if err := recordOrderPayment(ctx, o.ID.Hex(), in.Payment); err != nil {
+			return nil, err
+		}
+
+		if mmErr := verifyPayment(o, in.Payment); mmErr != nil {
+			sig, err := reviewPayment(ctx, o, mmErr)
+			if err != nil {
+				return nil, err
+			}
+			if sig.Action == models.InterventionActionRefund {
+				return refundedResult(refundUnconfirmedOrder(ctx, o, models.InterventionStepVerifyPayment, &sig))
+			}
+			from = models.OrderStatusPaymentReview
+			actor = models.OperatorActor(sig.Operator, models.OrderChangeSourceGRPC)
+		}
+	}
+
+	// 3. Confirm inventory
+	sig, err := runWithIntervention(ctx, o.Code, models.InterventionStepConfirmInventory, func(ctx workflow.Context) error {
+		return confirmInventory(ctx, in.OrderCode)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if sig != nil {
+		return refundedResult(refundUnconfirmedOrder(ctx, o, models.InterventionStepConfirmInventory, sig))
+	}
+
+	// 4. Update order status to COMPLETED, together with the checkout completed event
+	v := workflow.GetVersion(ctx, ChangeIDConfirmOrderTransition, workflow.DefaultVersion, 1)
+	sig, err = runWithIntervention(ctx, o.Code, models.InterventionStepCompleteOrder, func(ctx workflow.Context) error {
+		if v == workflow.DefaultVersion {
+			return updateOrderStatus(ctx, o.ID.Hex(), models.OrderStatusCompleted)
+		}
+
+		applied, err := transitionOrder(ctx, o.ID.Hex(), from, models.OrderStatusCompleted, "", actor)
+		if err != nil {
+			return err
+		}
+		if !applied {
+			return ErrInvalidOrderStatus
 		}
-		return ErrOrderAlreadyProcessed
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if sig != nil {
+		return refundedResult(refundUnconfirmedOrder(ctx, o, models.InterventionStepCompleteOrder, sig))
+	}
+
+	// 5. Publish checkout completed event to free waitroom slot, done by step 4 since the transition change
+	if v == workflow.DefaultVersion {
+		if err := publishCheckoutCompleted(ctx, o.SessionID, o.UserID, o.EventID); err != nil {
+			logger.Warn("Failed to publish checkout completed event", "error", err, "sessionID", o.SessionID)
+			return nil, err
+		}
+	}
+
+	logger.Info("Order confirmed successfully", "orderCode", in.OrderCode)
+	return &ConfirmOrderWorkflowResult{Status: models.OrderStatusCompleted}, nil
+}
+
+// refundLatePayment refunds a payment that completed after its order was cancelled, failed or timed
+// out. The tickets are already back in inventory, so the order keeps its status and only records the
+// payment.
+func refundLatePayment(ctx workflow.Context, o *models.Order, pmt *PaymentDetails) (*ConfirmOrderWorkflowResult, error) {
+	logger := workflow.GetLogger(ctx)
+	logger.Warn("Payment completed after the order was closed, refunding it", "orderCode", o.Code, "status", o.Status)
+
+	if err := recordOrderPayment(ctx, o.ID.Hex(), pmt); err != nil {
+		return nil, err
+	}
+
+	// One refund per payment, whichever workflow run asks for it
+	key := pmt.PaymentID
+	if key == "" {
+		key = pmt.TransactionID
+	}
+	if key == "" {
+		key = workflow.GetInfo(ctx).WorkflowExecution.RunID
 	}
 
-	// 2. Confirm inventory
-	if err := confirmInventory(ctx, in.OrderCode); err != nil {
+	reason := fmt.Sprintf("Payment completed after the order was %s", strings.ToLower(string(o.Status)))
+	if _, err := refundPayment(ctx, o.Code, "late-payment:"+key, pmt.AmountCents, reason); err != nil {
+		logger.Error("Failed to refund late payment", "error", err, "orderCode", o.Code)
+		return nil, err
+	}
+
+	logger.Info("Late payment refunded", "orderCode", o.Code, "amount", pmt.AmountCents)
+	return &ConfirmOrderWorkflowResult{Status: o.Status, LatePaymentRefunded: true}, nil
+}
+
+// confirmOrderWithoutIntervention finishes executions started before interventions existed,
+// which fail instead of waiting for an operator
+func confirmOrderWithoutIntervention(ctx workflow.Context, o *models.Order) error {
+	logger := workflow.GetLogger(ctx)
+
+	if err := confirmInventory(ctx, o.Code); err != nil {
 		logger.Error("Failed to confirm inventory", "error", err)
-		// This is a critical error - we need manual intervention
-		// TODO: Implement manual intervention task creation or alerting
 		return err
 	}
 
-	// 3. Update order status to COMPLETED
 	if err := updateOrderStatus(ctx, o.ID.Hex(), models.OrderStatusCompleted); err != nil {
-		// This is a critical error - we need manual intervention
-		// TODO: Implement manual intervention task creation or alerting
 		return err
 	}
 
-	// 4. Publish checkout completed event to free waitroom slot
 	if err := publishCheckoutCompleted(ctx, o.SessionID, o.UserID, o.EventID); err != nil {
 		logger.Warn("Failed to publish checkout completed event", "error", err, "sessionID", o.SessionID)
 		return err
 	}
 
-	logger.Info("Order confirmed successfully", "orderCode", in.OrderCode)
+	logger.Info("Order confirmed successfully", "orderCode", o.Code)
+	return nil
+}
+
+// verifyPayment checks the payment against the order. Currency and provider are only checked when
+// the payment service reports them.
+func verifyPayment(o *models.Order, pmt *PaymentDetails) error {
+	if pmt.AmountCents != o.TotalAmount {
+		return fmt.Errorf("%w: paid %d, expected %d", ErrPaymentAmountMismatch, pmt.AmountCents, o.TotalAmount)
+	}
+
+	if pmt.Currency != "" && !strings.EqualFold(pmt.Currency, o.Currency) {
+		return fmt.Errorf("%w: paid in %s, expected %s", ErrPaymentCurrencyMismatch, pmt.Currency, o.Currency)
+	}
+
+	if pmt.Provider != "" && o.PaymentMethod != "" && !strings.EqualFold(pmt.Provider, string(o.PaymentMethod)) {
+		return fmt.Errorf("%w: paid with %s, expected %s", ErrPaymentMethodMismatch, pmt.Provider, o.PaymentMethod)
+	}
+
+	return nil
+}
+
+// reviewPayment moves the order to PAYMENT_REVIEW and waits for an operator to either accept the
+// payment or refund it
+func reviewPayment(ctx workflow.Context, o *models.Order, mmErr error) (InterventionResolvedSignal, error) {
+	logger := workflow.GetLogger(ctx)
+	logger.Error("Payment does not match order, waiting for review", "error", mmErr, "orderCode", o.Code)
+
+	applied, err := transitionOrder(ctx, o.ID.Hex(), models.OrderStatusPending, models.OrderStatusPaymentReview, mmErr.Error(),
+		models.SystemActor(models.OrderChangeSourceWorkflow))
+	if err != nil {
+		return InterventionResolvedSignal{}, err
+	}
+	if !applied {
+		return InterventionResolvedSignal{}, ErrInvalidOrderStatus
+	}
+	o.Status = models.OrderStatusPaymentReview
+	o.FailureReason = mmErr.Error()
+
+	itv, err := createIntervention(ctx, o.Code, models.InterventionStepVerifyPayment, mmErr)
+	if err != nil {
+		logger.Error("Failed to create intervention", "error", err, "orderCode", o.Code)
+		return InterventionResolvedSignal{}, err
+	}
+
+	sig := awaitIntervention(ctx, itv.ID.Hex())
+	logger.Info("Intervention resolved", "interventionID", sig.InterventionID, "action", sig.Action, "operator", sig.Operator)
+
+	return sig, nil
+}
+
+// runWithIntervention runs a step until it succeeds, opening an intervention every time it fails.
+// It returns the operator's decision when they chose to refund instead of retrying.
+func runWithIntervention(ctx workflow.Context, oCode string, step models.InterventionStep, fn func(workflow.Context) error) (*InterventionResolvedSignal, error) {
+	logger := workflow.GetLogger(ctx)
+
+	for {
+		stepErr := fn(ctx)
+		if stepErr == nil {
+			return nil, nil
+		}
+		logger.Error("Step failed, waiting for manual intervention", "error", stepErr, "orderCode", oCode, "step", step)
+
+		itv, err := createIntervention(ctx, oCode, step, stepErr)
+		if err != nil {
+			logger.Error("Failed to create intervention", "error", err, "orderCode", oCode)
+			return nil, stepErr
+		}
+
+		sig := awaitIntervention(ctx, itv.ID.Hex())
+		logger.Info("Intervention resolved", "interventionID", sig.InterventionID, "action", sig.Action, "operator", sig.Operator)
+
+		if sig.Action == models.InterventionActionRefund {
+			return &sig, nil
+		}
+	}
+}
+
+// refundUnconfirmedOrder refunds a paid order that could not be confirmed
+// 1. Create Refund - Ledger entry for every ticket of the order
+// 2. Refund Payment - pivot, the ledger entry is voided when it fails
+// 3. Complete Refund and mark the order REFUNDED
+// 4. Give tickets back - release the reservation, or return them when they were already confirmed
+// 5. Publish order.refunded and checkout.failed
+func refundUnconfirmedOrder(ctx workflow.Context, o *models.Order, step models.InterventionStep, sig *InterventionResolvedSignal) error {
+	logger := workflow.GetLogger(ctx)
+	logger.Info("Refunding unconfirmed order", "orderCode", o.Code, "operator", sig.Operator)
+
+	reason := sig.Note
+	if reason == "" {
+		reason = fmt.Sprintf("Order could not be confirmed at step %s", step)
+	}
+
+	// 1. Create refund ledger entry
+	rf, err := createOrderRefund(ctx, o, &RefundOrderWorkflowInput{
+		OrderCode: o.Code,
+		Reason:    reason,
+	})
+	if err != nil {
+		return err
+	}
+	rfID := rf.ID.Hex()
+
+	// 2. Refund payment
+	rfResp, err := refundPayment(ctx, o.Code, rfID, rf.Amount, reason)
+	if err != nil {
+		logger.Error("Failed to refund payment", "error", err, "orderCode", o.Code)
+		if flErr := failOrderRefund(ctx, rfID); flErr != nil {
+			logger.Error("Failed to void refund", "error", flErr, "refundID", rfID)
+		}
+		return err
+	}
+
+	// 3. Complete refund and mark the order refunded
+	if err := completeOrderRefund(ctx, rfID, rfResp.RefundId); err != nil {
+		return err
+	}
+
+	uo, err := syncOrderRefunds(ctx, o.ID.Hex(), models.OperatorActor(sig.Operator, models.OrderChangeSourceGRPC), reason)
+	if err != nil {
+		return err
+	}
+
+	// 4. Give tickets back to inventory
+	if step == models.InterventionStepConfirmInventory || step == models.InterventionStepVerifyPayment {
+		err = releaseInventory(ctx, o.Code)
+	} else {
+		err = returnInventory(ctx, o.Code, rfID, rf.Items)
+	}
+	if err != nil {
+		logger.Error("Failed to give tickets back to inventory", "error", err, "orderCode", o.Code)
+		return err
+	}
+
+	// 5. Publish events
+	if err := publishOrderRefunded(ctx, uo, o.Status, rf); err != nil {
+		logger.Warn("Failed to publish order refunded event", "error", err, "orderCode", o.Code)
+	}
+
+	if err := publishCheckoutFailed(ctx, o.SessionID, o.UserID, o.EventID); err != nil {
+		logger.Warn("Failed to publish checkout failed event", "error", err, "sessionID", o.SessionID)
+	}
+
+	logger.Info("Unconfirmed order refunded", "orderCode", o.Code, "amount", rf.Amount)
 	return nil
 }
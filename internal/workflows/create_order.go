@@ -3,6 +3,7 @@ package workflows
 import (
 	"fmt"
 
+	"github.com/vogiaan1904/ticketbottle-order/internal/infra/temporal"
 	"github.com/vogiaan1904/ticketbottle-order/internal/models"
 	"github.com/vogiaan1904/ticketbottle-order/pkg/util"
 	"go.temporal.io/sdk/workflow"
@@ -48,22 +49,110 @@ func GetCreateOrderWorkflowID(oCode string) string {
 // Workflow handles transactional saga:
 // 1. Check Availability - Verify sufficient inventory
 // 2. Create Order - Persist order record (saga begins)
-// 3. Create Order Items - Persist order items (saga tracked)
+// 3. Create Order Items - Persist order items (saga tracked), in the same activity as the order so
+// an order is never left without its items
 // 4. Reserve Tickets - Lock inventory for 15 min (saga tracked)
-// 5. Create Payment Intent - Generate payment URL
-// Compensation on failure: Release inventory -> Delete items -> Delete order
+// 5. Create Payment Intent - Generate payment URL (saga tracked)
+// 6. Attach Payment - Store the payment intent ID on the order
+// Compensation on failure: Cancel payment -> (Release inventory || (Delete items -> Delete order)), run in parallel
+//
+// Once the payment URL is ready it is returned through the UpdateNamePaymentUrl update, order.created
+// is published, and the workflow keeps running until a payment signal arrives or PaymentTimeout elapses:
+// 7. Payment completed - Confirm the order through the ConfirmOrder child workflow
+// 8. Payment failed / timeout - Fail the order through the FailOrder child workflow.
+// If the payment intent turns out to be paid already, the order is confirmed instead. A payment
+// reported once the order was failed is refunded through ConfirmOrder.
+//
+// The progress of every step is exposed through the QueryNameCheckoutStatus query.
 func CreateOrder(ctx workflow.Context, in *CreateOrderWorkflowInput) (*CreateOrderWorkflowResult, error) {
 	logger := workflow.GetLogger(ctx)
 	logger.Info("Starting create order workflow", "orderCode", in.OrderCode)
 
+	var res *CreateOrderWorkflowResult
+	var setupErr error
+
+	st := &models.CheckoutStatus{
+		OrderCode: in.OrderCode,
+		State:     models.CheckoutStateCreating,
+	}
+	err := workflow.SetQueryHandler(ctx, QueryNameCheckoutStatus, func() (models.CheckoutStatus, error) {
+		return *st, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = workflow.SetUpdateHandler(ctx, UpdateNamePaymentUrl, func(ctx workflow.Context) (*CreateOrderWorkflowResult, error) {
+		if err := workflow.Await(ctx, func() bool { return res != nil || setupErr != nil }); err != nil {
+			return nil, err
+		}
+		if setupErr != nil {
+			return nil, setupErr
+		}
+		return res, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res, setupErr = placeOrder(ctx, in, st)
+	if setupErr != nil {
+		st.State = models.CheckoutStateFailed
+		st.Error = setupErr.Error()
+		// The saga rolled the order back
+		st.Order, st.OrderItems = nil, nil
+		// Let in-flight payment URL updates observe the failure before the workflow completes
+		_ = workflow.Await(ctx, func() bool { return workflow.AllHandlersFinished(ctx) })
+		return nil, setupErr
+	}
+	st.State = models.CheckoutStateAwaitingPayment
+	st.PaymentUrlReady = true
+	st.PaymentUrl = res.PaymentUrl
+
+	// Publish order created event once the saga can no longer roll the order back
+	if workflow.GetVersion(ctx, ChangeIDCreateOrderCreatedEvent, workflow.DefaultVersion, 1) >= 1 {
+		actCtx := workflow.WithActivityOptions(ctx, getCreateOrderActivityOptions())
+		if err := publishOrderCreated(actCtx, res.Order.ID.Hex()); err != nil {
+			logger.Warn("Failed to publish order created event", "error", err, "orderCode", res.Order.Code)
+		}
+	}
+
+	// Executions started before the payment phase moved into CreateOrder end with the payment URL
+	if workflow.GetVersion(ctx, ChangeIDCreateOrderAwaitPayment, workflow.DefaultVersion, 1) == workflow.DefaultVersion {
+		return res, nil
+	}
+
+	res, err = awaitPayment(ctx, res, st)
+	if err != nil {
+		st.State = models.CheckoutStateFailed
+		st.Error = err.Error()
+		return nil, err
+	}
+	if res.Order.Status == models.OrderStatusCompleted {
+		st.State = models.CheckoutStateCompleted
+	} else {
+		st.State = models.CheckoutStateFailed
+		st.Error = res.Order.FailureReason
+	}
+
+	return res, nil
+}
+
+// placeOrder runs the saga up to the payment URL, recording each finished step on st
+func placeOrder(ctx workflow.Context, in *CreateOrderWorkflowInput, st *models.CheckoutStatus) (_ *CreateOrderWorkflowResult, err error) {
+	logger := workflow.GetLogger(ctx)
+
 	var compensations Compensations
-	var err error
 
 	defer func() {
 		if err != nil {
 			logger.Error("Workflow failed, running compensations", "error", err)
 			disconnectedCtx, _ := workflow.NewDisconnectedContext(ctx)
-			compensations.Compensate(disconnectedCtx, false)
+			inParallel := workflow.GetVersion(disconnectedCtx, ChangeIDCreateOrderParallelCompensation, workflow.DefaultVersion, 1) >= 1
+			if cmpErr := compensations.Compensate(disconnectedCtx, inParallel); cmpErr != nil {
+				logger.Error("Compensations failed, manual cleanup required", "error", cmpErr)
+				err = escalateCompensationError(err, cmpErr)
+			}
 		}
 	}()
 
@@ -77,21 +166,33 @@ func CreateOrder(ctx workflow.Context, in *CreateOrderWorkflowInput) (*CreateOrd
 	if !available {
 		return nil, ErrInsufficientInventory
 	}
+	st.AvailabilityChecked = true
 
-	// 2. Create order
-	o, err := createOrder(ctx, in)
-	if err != nil {
-		return nil, err
-	}
-	oID := o.ID.Hex()
-	compensations.AddCompensation(oActs.DeleteOrder, oID)
+	// 2. Create order and 3. Create order items, in one activity since the atomic persist change
+	var o *models.Order
+	var itms []models.OrderItem
+	var delOrd CompensationID
+	if workflow.GetVersion(ctx, ChangeIDCreateOrderAtomicPersist, workflow.DefaultVersion, 1) == workflow.DefaultVersion {
+		if o, err = createOrder(ctx, in); err != nil {
+			return nil, err
+		}
+		delOrd = compensations.AddCompensation(oActs.DeleteOrder, o.ID.Hex())
 
-	// 3. Create order items
-	itms, err := createOrderItems(ctx, oID, in.Items)
-	if err != nil {
-		return nil, err
+		if itms, err = createOrderItems(ctx, o.ID.Hex(), in.Items); err != nil {
+			return nil, err
+		}
+	} else {
+		if o, itms, err = createOrderWithItems(ctx, in); err != nil {
+			return nil, err
+		}
+		delOrd = compensations.AddCompensation(oActs.DeleteOrder, o.ID.Hex())
 	}
-	compensations.AddCompensation(oActs.DeleteOrderItems, oID)
+	oID := o.ID.Hex()
+	delItms := compensations.AddCompensation(oActs.DeleteOrderItems, oID)
+	compensations.AddDependency(delOrd, delItms)
+	st.OrderPersisted = true
+	st.Order = o
+	st.OrderItems = itms
 
 	// 4. Reserve inventory
 	expAt := util.TimeToISO8601Str(workflow.Now(ctx).Add(PaymentTimeout))
@@ -99,7 +200,8 @@ func CreateOrder(ctx workflow.Context, in *CreateOrderWorkflowInput) (*CreateOrd
 	if err != nil {
 		return nil, err
 	}
-	compensations.AddCompensation(iActs.ReleaseInventory, o.Code)
+	relInv := compensations.AddCompensation(iActs.ReleaseInventory, o.Code)
+	st.Reserved = true
 
 	// 5. Create payment intent
 	pmtResp, err := processPayment(ctx, in)
@@ -107,9 +209,183 @@ func CreateOrder(ctx workflow.Context, in *CreateOrderWorkflowInput) (*CreateOrd
 		return nil, err
 	}
 
+	if workflow.GetVersion(ctx, ChangeIDCreateOrderPaymentID, workflow.DefaultVersion, 1) >= 1 {
+		cnlPmt := compensations.AddCompensation(pActs.CancelPayment, pmtResp.PaymentId)
+		// Keep the order and its tickets when the customer already paid, so the failure is escalated instead
+		compensations.AddDependency(relInv, cnlPmt)
+		compensations.AddDependency(delItms, cnlPmt)
+
+		// 6. Attach payment intent to the order
+		err = updateOrderPaymentID(ctx, oID, pmtResp.PaymentId)
+		if err != nil {
+			return nil, err
+		}
+		o.PaymentID = pmtResp.PaymentId
+	}
+
 	return &CreateOrderWorkflowResult{
 		PaymentUrl: pmtResp.PaymentUrl,
 		Order:      o,
 		OrderItems: itms,
 	}, nil
 }
+
+// awaitPayment blocks until the payment service reports an outcome or the payment window closes
+func awaitPayment(ctx workflow.Context, res *CreateOrderWorkflowResult, st *models.CheckoutStatus) (*CreateOrderWorkflowResult, error) {
+	logger := workflow.GetLogger(ctx)
+	o := res.Order
+
+	var outcome models.OrderStatus
+	var completedSig PaymentCompletedSignal
+	var failedSig PaymentFailedSignal
+
+	timerCtx, cancelTimer := workflow.WithCancel(ctx)
+	defer cancelTimer()
+
+	completedCh := workflow.GetSignalChannel(ctx, SignalNamePaymentCompleted)
+	failedCh := workflow.GetSignalChannel(ctx, SignalNamePaymentFailed)
+
+	sel := workflow.NewSelector(ctx)
+	sel.AddReceive(completedCh, func(c workflow.ReceiveChannel, _ bool) {
+		c.Receive(ctx, &completedSig)
+		outcome = models.OrderStatusCompleted
+	})
+	sel.AddReceive(failedCh, func(c workflow.ReceiveChannel, _ bool) {
+		c.Receive(ctx, &failedSig)
+		outcome = models.OrderStatusPaymentFailed
+	})
+	sel.AddFuture(workflow.NewTimer(timerCtx, PaymentTimeout), func(f workflow.Future) {
+		outcome = models.OrderStatusTimeout
+	})
+	sel.Select(ctx)
+
+	logger.Info("Payment phase finished", "orderCode", o.Code, "outcome", outcome)
+	st.State = models.CheckoutStateProcessingPayment
+
+	if outcome == models.OrderStatusCompleted {
+		return confirmPaidOrder(ctx, res, completedSig.Payment)
+	}
+
+	reason := failedSig.Reason
+	actor := models.SystemActor(models.OrderChangeSourceKafka)
+	if outcome == models.OrderStatusTimeout {
+		reason = ErrPaymentTimeout.Error()
+		actor = models.SystemActor(models.OrderChangeSourceWorkflow)
+	}
+
+	cwCtx := workflow.WithChildOptions(ctx, workflow.ChildWorkflowOptions{
+		WorkflowID: GetFailOrderWorkflowID(o.Code),
+		TaskQueue:  temporal.FailOrderTaskQueue,
+	})
+	var failRes *FailOrderWorkflowResult
+	err := workflow.ExecuteChildWorkflow(cwCtx, FailOrder, &FailOrderWorkflowInput{
+		OrderCode: o.Code,
+		Status:    outcome,
+		Reason:    reason,
+		Actor:     actor,
+	}).Get(ctx, &failRes)
+	latePayments := workflow.GetVersion(ctx, ChangeIDCreateOrderLatePayment, workflow.DefaultVersion, 1) >= 1
+	if isPaymentAlreadyCompleted(err) {
+		// The customer paid right before the intent was cancelled, the completion signal is on its way
+		logger.Warn("Payment completed before it could be cancelled, confirming order", "orderCode", o.Code, "outcome", outcome)
+		if !latePayments {
+			return confirmPaidOrder(ctx, res, nil)
+		}
+
+		pmt, ok := awaitPaidPayment(ctx, completedCh)
+		if !ok {
+			logger.Warn("Payment completion signal did not arrive, leaving the order to the consumer", "orderCode", o.Code)
+			return res, nil
+		}
+		return confirmPaidOrder(ctx, res, pmt)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if failRes.Applied {
+		o.Status = outcome
+		o.FailureReason = reason
+	}
+
+	// Executions started before late payments were handled drop the signals still queued
+	if !latePayments {
+		return res, nil
+	}
+
+	return confirmLatePayments(ctx, res, completedCh, failedCh)
+}
+
+// awaitPaidPayment waits up to PaidPaymentSignalTimeout for the completion signal of an order found
+// paid while it was being failed. It reports false when no signal came, the order is then left
+// PENDING and the consumer runs ConfirmOrder with the payment once payment.completed arrives.
+func awaitPaidPayment(ctx workflow.Context, completedCh workflow.ReceiveChannel) (*PaymentDetails, bool) {
+	var sig PaymentCompletedSignal
+	if ok, _ := completedCh.ReceiveWithTimeout(ctx, PaidPaymentSignalTimeout, &sig); !ok {
+		return nil, false
+	}
+
+	return sig.Payment, true
+}
+
+// confirmLatePayments runs ConfirmOrder for every payment that completed while the order was being
+// failed. The order is closed by then, so ConfirmOrder refunds the payment. Late failures change nothing.
+func confirmLatePayments(ctx workflow.Context, res *CreateOrderWorkflowResult, completedCh, failedCh workflow.ReceiveChannel) (*CreateOrderWorkflowResult, error) {
+	logger := workflow.GetLogger(ctx)
+	o := res.Order
+
+	for {
+		var sig PaymentFailedSignal
+		if !failedCh.ReceiveAsync(&sig) {
+			break
+		}
+		logger.Info("Ignoring payment failure reported after the payment phase", "orderCode", o.Code, "reason", sig.Reason)
+	}
+
+	for {
+		var sig PaymentCompletedSignal
+		if !completedCh.ReceiveAsync(&sig) {
+			break
+		}
+		logger.Warn("Payment completed after the payment phase finished", "orderCode", o.Code, "status", o.Status)
+
+		// ConfirmOrder needs the payment to refund it
+		if sig.Payment == nil {
+			logger.Error("Late payment signal carries no payment, it must be refunded by hand", "orderCode", o.Code)
+			continue
+		}
+
+		if _, err := confirmPaidOrder(ctx, res, sig.Payment); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+// confirmPaidOrder runs ConfirmOrder, verifying the order against pmt when it is known
+func confirmPaidOrder(ctx workflow.Context, res *CreateOrderWorkflowResult, pmt *PaymentDetails) (*CreateOrderWorkflowResult, error) {
+	o := res.Order
+
+	cwCtx := workflow.WithChildOptions(ctx, workflow.ChildWorkflowOptions{
+		WorkflowID: GetConfirmOrderWorkflowID(o.Code),
+		TaskQueue:  temporal.ConfirmOrderTaskQueue,
+	})
+	var cfRes *ConfirmOrderWorkflowResult
+	err := workflow.ExecuteChildWorkflow(cwCtx, ConfirmOrder, &ConfirmOrderWorkflowInput{
+		OrderCode: o.Code,
+		Status:    models.OrderStatusCompleted,
+		Payment:   pmt,
+		Actor:     models.SystemActor(models.OrderChangeSourceKafka),
+	}).Get(ctx, &cfRes)
+	if err != nil {
+		return nil, err
+	}
+
+	// Executions of ConfirmOrder that returned no result always completed the order
+	o.Status = models.OrderStatusCompleted
+	if cfRes != nil && cfRes.Status != "" {
+		o.Status = cfRes.Status
+	}
+
+	return res, nil
+}
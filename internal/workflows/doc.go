@@ -0,0 +1,31 @@
+// Package workflows holds the Temporal workflows of the order service.
+//
+// # Evolving workflows
+//
+// Workflows are replayed from their event history whenever a worker picks them up again, so a
+// deploy must not change the commands (activities, timers, child workflows, markers) an execution
+// that is already running has recorded. CreateOrder stays open for the whole payment window and
+// ConfirmOrder can wait days for an operator, so any change to them meets running executions.
+//
+// To add, remove or reorder a step:
+//
+//  1. Declare a change ID in versions.go, named after the workflow and the change.
+//  2. Branch on workflow.GetVersion at the point where the change starts, keeping the old code
+//     for workflow.DefaultVersion (or the previous version) and the new code for the new version.
+//  3. Changing the same spot again raises maxSupported on the same change ID (1 becomes 2) and
+//     adds a branch; do not create a new ID for it.
+//  4. Export histories of running executions (temporal workflow show --output json) and run
+//     make replay before deploying. A nondeterminism error means a branch is missing.
+//  5. Once no execution older than the change is open anymore, raise minSupported to the new
+//     version and delete the old branch. Keep the GetVersion call itself.
+//
+// For example, executions started before step 6 of CreateOrder existed skip it on replay:
+//
+//	if workflow.GetVersion(ctx, ChangeIDCreateOrderPaymentID, workflow.DefaultVersion, 1) >= 1 {
+//		err = updateOrderPaymentID(ctx, oID, pmtResp.PaymentId)
+//	}
+//
+// Changes that do not emit commands are safe without a version: activity implementations,
+// activity options, logging, query and update handlers, and code paths no running execution
+// has reached yet. New workflows such as CancelOrder or FailOrder need no version until they change.
+package workflows
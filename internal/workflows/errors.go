@@ -2,12 +2,23 @@ package workflows
 
 import "errors"
 
+// ErrTypeCompensationFailed is the application error type of a workflow whose rollback did not fully succeed
+const ErrTypeCompensationFailed = "COMPENSATION_FAILED"
+
+// ErrTypeOrderNotPending is the application error type returned when an order can no longer be cancelled
+const ErrTypeOrderNotPending = "ORDER_NOT_PENDING"
+
 var (
-	ErrOrderNotFound          = errors.New("order not found")
-	ErrOrderAlreadyProcessed  = errors.New("order already completed or cancelled")
-	ErrInventoryReserveFailed = errors.New("failed to reserve inventory")
-	ErrPaymentFailed          = errors.New("payment processing failed")
-	ErrPaymentTimeout         = errors.New("payment timeout exceeded")
-	ErrInvalidOrderStatus     = errors.New("invalid order status for operation")
-	ErrInsufficientInventory  = errors.New("insufficient inventory")
+	ErrOrderNotFound           = errors.New("order not found")
+	ErrOrderAlreadyProcessed   = errors.New("order already completed or cancelled")
+	ErrInventoryReserveFailed  = errors.New("failed to reserve inventory")
+	ErrPaymentFailed           = errors.New("payment processing failed")
+	ErrPaymentTimeout          = errors.New("payment timeout exceeded")
+	ErrInvalidOrderStatus      = errors.New("invalid order status for operation")
+	ErrInsufficientInventory   = errors.New("insufficient inventory")
+	ErrOrderNotRefundable      = errors.New("order is not refundable")
+	ErrOrderNotPending         = errors.New("order is not pending")
+	ErrPaymentAmountMismatch   = errors.New("payment amount does not match order amount")
+	ErrPaymentCurrencyMismatch = errors.New("payment currency does not match order currency")
+	ErrPaymentMethodMismatch   = errors.New("payment provider does not match order payment method")
 )
@@ -0,0 +1,92 @@
+package workflows
+
+import (
+	"fmt"
+
+	"github.com/vogiaan1904/ticketbottle-order/internal/models"
+	"go.temporal.io/sdk/workflow"
+)
+
+type FailOrderWorkflowInput struct {
+	OrderCode string
+	// Status is the failure status, PAYMENT_FAILED or TIMEOUT
+	Status models.OrderStatus
+	Reason string
+	// Actor is recorded with the status change in the order history
+	Actor models.OrderActor
+}
+
+type FailOrderWorkflowResult struct {
+	// Applied is false when the order had already left PENDING and was left untouched
+	Applied bool
+	Order   *models.Order
+}
+
+func GetFailOrderWorkflowID(oCode string) string {
+	return fmt.Sprintf("FailOrder:%s", oCode)
+}
+
+// FailOrder moves a pending order whose payment failed or timed out to a terminal status
+// 1. Validate Order - Only a PENDING order is failed, anything else is left untouched
+// 2. Cancel Payment - The payment URL can no longer be paid, an already paid order is not failed
+// 3. Update Order Status - Guarded PENDING -> failure status transition, records the reason and
+// writes checkout.failed with it
+// 4. Release Tickets - Retried with backoff until inventory accepts it
+// 5. Publish checkout.failed to free the waitroom slot (executions started before step 3 wrote it)
+func FailOrder(ctx workflow.Context, in *FailOrderWorkflowInput) (*FailOrderWorkflowResult, error) {
+	logger := workflow.GetLogger(ctx)
+	logger.Info("Starting fail order workflow", "orderCode", in.OrderCode, "status", in.Status)
+
+	ctx = workflow.WithActivityOptions(ctx, getFailOrderActivityOptions())
+
+	// 1. Validate order
+	o, err := validateOrder(ctx, in.OrderCode)
+	if err != nil {
+		return nil, err
+	}
+
+	if o.Status != models.OrderStatusPending {
+		logger.Warn("Order already processed", "orderCode", in.OrderCode, "status", o.Status)
+		return &FailOrderWorkflowResult{Applied: false, Order: o}, nil
+	}
+
+	// 2. Cancel payment intent
+	if err := cancelPayment(ctx, o.PaymentID); err != nil {
+		logger.Error("Failed to cancel payment", "error", err, "orderCode", in.OrderCode)
+		return nil, err
+	}
+
+	// 3. Update order status, only while the order is still PENDING, together with the checkout failed event
+	v := workflow.GetVersion(ctx, ChangeIDFailOrderTransition, workflow.DefaultVersion, 1)
+	var applied bool
+	if v == workflow.DefaultVersion {
+		applied, err = failPendingOrder(ctx, o.ID.Hex(), in.Status, in.Reason, in.Actor)
+	} else {
+		applied, err = transitionOrder(ctx, o.ID.Hex(), models.OrderStatusPending, in.Status, in.Reason, in.Actor)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !applied {
+		logger.Warn("Order left PENDING before it could be failed", "orderCode", in.OrderCode)
+		return &FailOrderWorkflowResult{Applied: false, Order: o}, nil
+	}
+	o.Status = in.Status
+	o.FailureReason = in.Reason
+
+	// 4. Release tickets
+	if err := releaseInventory(ctx, in.OrderCode); err != nil {
+		logger.Error("Failed to release inventory", "error", err, "orderCode", in.OrderCode)
+		return nil, err
+	}
+
+	// 5. Publish checkout failed event to free waitroom slot, done by step 3 since the transition change
+	if v == workflow.DefaultVersion {
+		if err := publishCheckoutFailed(ctx, o.SessionID, o.UserID, o.EventID); err != nil {
+			logger.Warn("Failed to publish checkout failed event", "error", err, "sessionID", o.SessionID)
+		}
+	}
+
+	logger.Info("Order failed", "orderCode", in.OrderCode, "status", in.Status)
+	return &FailOrderWorkflowResult{Applied: true, Order: o}, nil
+}
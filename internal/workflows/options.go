@@ -42,3 +42,54 @@ func getCompensationActivityOptions() workflow.ActivityOptions {
 		},
 	}
 }
+
+// Cancellation steps are retried until they succeed, a cancelled order must never keep its tickets
+func getCancelOrderActivityOptions() workflow.ActivityOptions {
+	return workflow.ActivityOptions{
+		StartToCloseTimeout: time.Minute * 2,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    time.Second,
+			BackoffCoefficient: 2.0,
+			MaximumInterval:    time.Minute * 5,
+			MaximumAttempts:    0,
+		},
+	}
+}
+
+// Failing an order is retried until it succeeds, a failed order must never keep its tickets
+func getFailOrderActivityOptions() workflow.ActivityOptions {
+	return workflow.ActivityOptions{
+		StartToCloseTimeout: time.Minute * 2,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    time.Second,
+			BackoffCoefficient: 2.0,
+			MaximumInterval:    time.Minute * 5,
+			MaximumAttempts:    0,
+		},
+	}
+}
+
+func getRefundOrderActivityOptions() workflow.ActivityOptions {
+	return workflow.ActivityOptions{
+		StartToCloseTimeout: time.Minute * 2,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    time.Second * 2,
+			BackoffCoefficient: 2.0,
+			MaximumInterval:    time.Minute * 2,
+			MaximumAttempts:    10,
+		},
+	}
+}
+
+// Listing and rechecking the orders of a cancelled event is retried until it succeeds
+func getCancelEventOrdersActivityOptions() workflow.ActivityOptions {
+	return workflow.ActivityOptions{
+		StartToCloseTimeout: time.Minute * 2,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    time.Second,
+			BackoffCoefficient: 2.0,
+			MaximumInterval:    time.Minute * 5,
+			MaximumAttempts:    0,
+		},
+	}
+}
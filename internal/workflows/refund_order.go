@@ -0,0 +1,142 @@
+package workflows
+
+import (
+	"fmt"
+
+	"github.com/vogiaan1904/ticketbottle-order/internal/models"
+	"go.temporal.io/sdk/workflow"
+)
+
+type RefundOrderWorkflowInput struct {
+	OrderCode string
+	Reason    string
+	// Items to refund, an empty list refunds everything that is still refundable
+	Items []RefundOrderItemInput
+	// Actor is recorded with the status change in the order history
+	Actor models.OrderActor
+}
+
+type RefundOrderItemInput struct {
+	TicketClassID string
+	Quantity      int32
+}
+
+type RefundOrderWorkflowResult struct {
+	Order           *models.Order
+	Refund          *models.OrderRefund
+	PaymentRefundID string
+}
+
+// refundRolledBackReason is recorded in the order history when a failed refund is rolled back, only
+// by executions started before ChangeIDRefundOrderSyncAfterPayment
+const refundRolledBackReason = "Refund failed and was rolled back"
+
+func GetRefundOrderWorkflowID(oCode string) string {
+	return fmt.Sprintf("RefundOrder:%s", oCode)
+}
+
+// RefundOrder refunds a paid order, fully or per item, with saga pattern
+// 1. Validate Order - Order must be COMPLETED or PARTIALLY_REFUNDED
+// 2. Create Refund - Write a PENDING entry to the refund ledger (saga tracked)
+// 3. Refund Payment - Ask the payment service for the refund (pivot)
+// 4. Complete Refund - Mark the ledger entry SUCCEEDED (retried, not compensated)
+// 5. Apply Refund - Derive item quantities, refunded amount and status from the ledger (retried, not compensated)
+// 6. Return Tickets - Give only the refunded quantity back to inventory (retried, not compensated)
+// 7. Publish order.refunded
+// Compensation on refund failure: Void ledger entry. The order is only changed once the money is on
+// its way back, so it never shows a refund that did not happen.
+func RefundOrder(ctx workflow.Context, in *RefundOrderWorkflowInput) (_ *RefundOrderWorkflowResult, err error) {
+	logger := workflow.GetLogger(ctx)
+	logger.Info("Starting refund order workflow", "orderCode", in.OrderCode)
+
+	var compensations Compensations
+
+	defer func() {
+		if err != nil {
+			logger.Error("Workflow failed, running compensations", "error", err)
+			disconnectedCtx, _ := workflow.NewDisconnectedContext(ctx)
+			if cmpErr := compensations.Compensate(disconnectedCtx, true); cmpErr != nil {
+				logger.Error("Compensations failed, manual cleanup required", "error", cmpErr)
+				err = escalateCompensationError(err, cmpErr)
+			}
+		}
+	}()
+
+	ctx = workflow.WithActivityOptions(ctx, getRefundOrderActivityOptions())
+
+	// 1. Validate order
+	o, err := validateOrder(ctx, in.OrderCode)
+	if err != nil {
+		return nil, err
+	}
+
+	if o.Status != models.OrderStatusCompleted && o.Status != models.OrderStatusPartiallyRefunded {
+		logger.Warn("Order is not refundable", "orderCode", in.OrderCode, "status", o.Status)
+		return nil, ErrOrderNotRefundable
+	}
+	oID := o.ID.Hex()
+	prevStatus := o.Status
+
+	// 2. Create refund ledger entry
+	rf, err := createOrderRefund(ctx, o, in)
+	if err != nil {
+		return nil, err
+	}
+	rfID := rf.ID.Hex()
+
+	// Executions started before the change applied the refund to the order ahead of the payment
+	// refund and rolled it back on failure
+	if workflow.GetVersion(ctx, ChangeIDRefundOrderSyncAfterPayment, workflow.DefaultVersion, 1) == workflow.DefaultVersion {
+		syncRf := compensations.AddCompensation(oActs.SyncOrderRefunds, oID,
+			models.SystemActor(models.OrderChangeSourceWorkflow), refundRolledBackReason)
+		failRf := compensations.AddCompensation(oActs.FailOrderRefund, rfID)
+		compensations.AddDependency(syncRf, failRf)
+
+		if o, err = syncOrderRefunds(ctx, oID, in.Actor, in.Reason); err != nil {
+			return nil, err
+		}
+	} else {
+		compensations.AddCompensation(oActs.FailOrderRefund, rfID)
+	}
+
+	// 3. Refund payment
+	rfResp, err := refundPayment(ctx, o.Code, rfID, rf.Amount, in.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	// The money is on its way back, from here on every step is retried instead of compensated
+	compensations = Compensations{}
+
+	// 4. Complete refund ledger entry
+	if cplErr := completeOrderRefund(ctx, rfID, rfResp.RefundId); cplErr != nil {
+		logger.Error("Failed to complete refund", "error", cplErr, "refundID", rfID)
+		return nil, cplErr
+	}
+
+	// 5. Apply refund to the order and its items
+	if workflow.GetVersion(ctx, ChangeIDRefundOrderSyncAfterPayment, workflow.DefaultVersion, 1) >= 1 {
+		if o, err = syncOrderRefunds(ctx, oID, in.Actor, in.Reason); err != nil {
+			logger.Error("Failed to apply refund to the order", "error", err, "orderCode", in.OrderCode)
+			return nil, err
+		}
+	}
+
+	// 6. Return refunded tickets to inventory
+	if rtnErr := returnInventory(ctx, o.Code, rfID, rf.Items); rtnErr != nil {
+		logger.Error("Failed to return tickets to inventory", "error", rtnErr, "orderCode", o.Code)
+		return nil, rtnErr
+	}
+
+	// 7. Publish order refunded event
+	if pubErr := publishOrderRefunded(ctx, o, prevStatus, rf); pubErr != nil {
+		logger.Warn("Failed to publish order refunded event", "error", pubErr, "orderCode", o.Code)
+	}
+
+	logger.Info("Order refunded successfully", "orderCode", in.OrderCode, "amount", rf.Amount, "status", o.Status)
+	return &RefundOrderWorkflowResult{
+		Order:           o,
+		Refund:          rf,
+		PaymentRefundID: rfResp.RefundId,
+	}, nil
+}
@@ -1,8 +1,15 @@
 package workflows
 
 import (
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
 	"time"
 
+	"github.com/vogiaan1904/ticketbottle-order/internal/models"
+	"go.temporal.io/sdk/temporal"
 	"go.temporal.io/sdk/workflow"
 )
 
@@ -11,37 +18,240 @@ const (
 	// 5 minutes for payment + 1 minute buffer for callback processing
 	PaymentTimeout = 6 * time.Minute
 
+	// PaidPaymentSignalTimeout is how long CreateOrder waits for the completion signal of an order
+	// whose payment intent could not be cancelled because it was already paid
+	PaidPaymentSignalTimeout = 5 * time.Minute
+
 	// SignalNamePaymentCompleted is the signal name for payment completion
 	SignalNamePaymentCompleted = "payment-completed"
 
 	// SignalNamePaymentFailed is the signal name for payment failure
 	SignalNamePaymentFailed = "payment-failed"
+
+	// UpdateNamePaymentUrl is the update name used to wait for the payment URL of a CreateOrder workflow
+	UpdateNamePaymentUrl = "payment-url"
+
+	// SignalNameInterventionResolved is the signal name for an operator's decision on an intervention
+	SignalNameInterventionResolved = "intervention-resolved"
+
+	// UpdateNameOrderCancelled is the update name used to wait until a CancelOrder workflow committed the cancellation
+	UpdateNameOrderCancelled = "order-cancelled"
+
+	// QueryNameCheckoutStatus is the query name used to read the progress of a CreateOrder workflow
+	QueryNameCheckoutStatus = "checkout-status"
+
+	// QueryNameEventOrdersProgress is the query name used to read the progress of a CancelEventOrders workflow
+	QueryNameEventOrdersProgress = "event-orders-progress"
 )
 
+type PaymentCompletedSignal struct {
+	OrderCode string
+	// Payment is nil in signals sent before the payment details were passed on
+	Payment *PaymentDetails
+}
+
+// PaymentDetails is the payment reported by the payment service, verified against the order by ConfirmOrder
+type PaymentDetails struct {
+	PaymentID     string
+	TransactionID string
+	AmountCents   int64
+	Currency      string
+	Provider      string
+	PaidAt        time.Time
+}
+
+type PaymentFailedSignal struct {
+	OrderCode string
+	Reason    string
+}
+
+type InterventionResolvedSignal struct {
+	InterventionID string
+	Action         models.InterventionAction
+	Operator       string
+	Note           string
+}
+
+// CompensationID identifies a compensation registered on Compensations
+type CompensationID int
+
+// Compensations runs the undo steps of a saga. By default a compensation runs after every
+// compensation registered later than itself (reverse order) in sequential mode, and right away in
+// parallel mode; AddDependency orders compensations explicitly in both modes.
 type Compensations struct {
 	compensations []any
 	arguments     [][]any
+	dependencies  [][]CompensationID
+}
+
+// CompensationFailure describes a compensation that did not succeed
+type CompensationFailure struct {
+	Activity string
+	Error    string
+	// Skipped is set when the compensation did not run because one it depends on failed
+	Skipped bool
 }
 
-func (s *Compensations) AddCompensation(activity any, parameters ...any) {
+// CompensationError is returned by Compensate when some compensations did not succeed
+type CompensationError struct {
+	Failures []CompensationFailure
+}
+
+func (e *CompensationError) Error() string {
+	msgs := make([]string, len(e.Failures))
+	for i, f := range e.Failures {
+		msgs[i] = fmt.Sprintf("%s: %s", f.Activity, f.Error)
+	}
+	return fmt.Sprintf("%d compensation(s) failed: %s", len(e.Failures), strings.Join(msgs, "; "))
+}
+
+// escalateCompensationError reports a saga that could not be fully undone. The workflow error keeps
+// the original failure as its cause and carries the failed compensations as details, so it can be
+// told apart from a clean rollback.
+func escalateCompensationError(cause error, cmpErr error) error {
+	var failures []CompensationFailure
+	var ce *CompensationError
+	if errors.As(cmpErr, &ce) {
+		failures = ce.Failures
+	}
+	return temporal.NewNonRetryableApplicationError(cmpErr.Error(), ErrTypeCompensationFailed, cause, failures)
+}
+
+func (s *Compensations) AddCompensation(activity any, parameters ...any) CompensationID {
 	s.compensations = append(s.compensations, activity)
 	s.arguments = append(s.arguments, parameters)
+	s.dependencies = append(s.dependencies, nil)
+	return CompensationID(len(s.compensations) - 1)
 }
 
-func (s Compensations) Compensate(ctx workflow.Context, inParallel bool) {
-	logger := workflow.GetLogger(ctx)
+// AddDependency makes compensation id wait for dependsOn to finish, and skips it when dependsOn fails.
+// It panics on unknown IDs or when the dependency would form a cycle.
+func (s *Compensations) AddDependency(id CompensationID, dependsOn CompensationID) {
+	if !s.valid(id) || !s.valid(dependsOn) {
+		panic(fmt.Sprintf("compensations: unknown compensation %d or %d", id, dependsOn))
+	}
+	if id == dependsOn || s.dependsOn(dependsOn, id) {
+		panic(fmt.Sprintf("compensations: dependency %d -> %d forms a cycle", id, dependsOn))
+	}
+	s.dependencies[id] = append(s.dependencies[id], dependsOn)
+}
+
+// Compensate runs every registered compensation and returns a *CompensationError listing
+// the ones that still failed after retries
+func (s Compensations) Compensate(ctx workflow.Context, inParallel bool) error {
+	ctx = workflow.WithActivityOptions(ctx, getCompensationActivityOptions())
+
+	order := s.order()
+	results := make([]error, len(s.compensations))
 
 	if !inParallel {
-		for i := len(s.compensations) - 1; i >= 0; i-- {
-			errCompensation := workflow.ExecuteActivity(
-				workflow.WithActivityOptions(ctx, getCompensationActivityOptions()),
-				s.compensations[i],
-				s.arguments[i]...,
-			).Get(ctx, nil)
-
-			if errCompensation != nil {
-				logger.Error("Executing compensation failed", "Error", errCompensation)
+		for _, id := range order {
+			results[id] = s.run(ctx, id, results)
+		}
+	} else {
+		futures := make([]workflow.Future, len(s.compensations))
+		settables := make([]workflow.Settable, len(s.compensations))
+		for i := range s.compensations {
+			futures[i], settables[i] = workflow.NewFuture(ctx)
+		}
+
+		for _, id := range order {
+			workflow.Go(ctx, func(ctx workflow.Context) {
+				for _, dep := range s.dependencies[id] {
+					_ = futures[dep].Get(ctx, nil)
+				}
+				results[id] = s.run(ctx, id, results)
+				settables[id].Set(nil, nil)
+			})
+		}
+
+		for _, f := range futures {
+			_ = f.Get(ctx, nil)
+		}
+	}
+
+	var failures []CompensationFailure
+	for _, id := range order {
+		if results[id] == nil {
+			continue
+		}
+		failures = append(failures, CompensationFailure{
+			Activity: activityName(s.compensations[id]),
+			Error:    results[id].Error(),
+			Skipped:  results[id] == errCompensationSkipped,
+		})
+	}
+
+	if len(failures) > 0 {
+		return &CompensationError{Failures: failures}
+	}
+
+	return nil
+}
+
+var errCompensationSkipped = errors.New("skipped because a compensation it depends on failed")
+
+func (s Compensations) run(ctx workflow.Context, id CompensationID, results []error) error {
+	for _, dep := range s.dependencies[id] {
+		if results[dep] != nil {
+			return errCompensationSkipped
+		}
+	}
+
+	err := workflow.ExecuteActivity(ctx, s.compensations[id], s.arguments[id]...).Get(ctx, nil)
+	if err != nil {
+		workflow.GetLogger(ctx).Error("Executing compensation failed", "Activity", activityName(s.compensations[id]), "Error", err)
+	}
+
+	return err
+}
+
+// order returns the compensations in execution order: dependencies first, otherwise latest registered first
+func (s Compensations) order() []CompensationID {
+	done := make([]bool, len(s.compensations))
+	order := make([]CompensationID, 0, len(s.compensations))
+
+	for len(order) < len(s.compensations) {
+		for id := CompensationID(len(s.compensations) - 1); id >= 0; id-- {
+			if done[id] || !s.ready(id, done) {
+				continue
 			}
+			done[id] = true
+			order = append(order, id)
+			break
 		}
 	}
+
+	return order
+}
+
+func (s Compensations) ready(id CompensationID, done []bool) bool {
+	for _, dep := range s.dependencies[id] {
+		if !done[dep] {
+			return false
+		}
+	}
+	return true
+}
+
+func (s Compensations) dependsOn(id CompensationID, target CompensationID) bool {
+	for _, dep := range s.dependencies[id] {
+		if dep == target || s.dependsOn(dep, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s Compensations) valid(id CompensationID) bool {
+	return id >= 0 && int(id) < len(s.compensations)
+}
+
+func activityName(activity any) string {
+	name := runtime.FuncForPC(reflect.ValueOf(activity).Pointer()).Name()
+	name = strings.TrimSuffix(name, "-fm")
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
 }
@@ -1,6 +1,9 @@
 package workflows
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/vogiaan1904/ticketbottle-order/internal/activities"
 	"github.com/vogiaan1904/ticketbottle-order/internal/models"
 	repo "github.com/vogiaan1904/ticketbottle-order/internal/order/repository"
@@ -39,37 +42,61 @@ func checkAvailability(ctx workflow.Context, in *CreateOrderWorkflowInput) (bool
 }
 
 func createOrder(ctx workflow.Context, in *CreateOrderWorkflowInput) (*models.Order, error) {
-	opt := repo.CreateOrderOption{
-		SessionID:    in.SessionID,
-		Code:         in.OrderCode,
-		UserID:       in.UserID,
-		Email:        in.Email,
-		Phone:        in.Phone,
-		UserFullName: in.UserFullName,
-		EventID:      in.EventID,
-		Currency:     in.Currency,
-		Status:       models.OrderStatusPending,
-		TotalAmount:  in.TotalAmount,
-	}
-
 	var o *models.Order
-	err := workflow.ExecuteActivity(ctx, oActs.CreateOrder, opt).Get(ctx, &o)
+	err := workflow.ExecuteActivity(ctx, oActs.CreateOrder, newCreateOrderOption(in)).Get(ctx, &o)
 	return o, err
 }
 
 func createOrderItems(ctx workflow.Context, oID string, ins []CreateOrderItemInput) ([]models.OrderItem, error) {
+	var itms []models.OrderItem
+	err := workflow.ExecuteActivity(ctx, oActs.CreateOrderItems, oID, newCreateOrderItemOptions(oID, ins)).Get(ctx, &itms)
+	return itms, err
+}
+
+// createOrderWithItems persists the order and its items in one activity
+func createOrderWithItems(ctx workflow.Context, in *CreateOrderWorkflowInput) (*models.Order, []models.OrderItem, error) {
+	var res *activities.CreateOrderWithItemsResult
+	err := workflow.ExecuteActivity(ctx, oActs.CreateOrderWithItems, activities.CreateOrderWithItemsInput{
+		Order: newCreateOrderOption(in),
+		Items: newCreateOrderItemOptions("", in.Items),
+	}).Get(ctx, &res)
+	if err != nil {
+		return nil, nil, err
+	}
+	return res.Order, res.Items, nil
+}
+
+func newCreateOrderOption(in *CreateOrderWorkflowInput) repo.CreateOrderOption {
+	return repo.CreateOrderOption{
+		SessionID:     in.SessionID,
+		Code:          in.OrderCode,
+		UserID:        in.UserID,
+		Email:         in.Email,
+		Phone:         in.Phone,
+		UserFullName:  in.UserFullName,
+		EventID:       in.EventID,
+		Currency:      in.Currency,
+		PaymentMethod: models.PaymentMethod(in.PaymentProvider),
+		Status:        models.OrderStatusPending,
+		TotalAmount:   in.TotalAmount,
+		Actor:         models.UserActor(in.UserID, models.OrderChangeSourceGRPC),
+	}
+}
+
+func newCreateOrderItemOptions(oID string, ins []CreateOrderItemInput) []repo.CreateOrderItemOption {
 	opts := make([]repo.CreateOrderItemOption, len(ins))
 	for i, itm := range ins {
 		opts[i] = repo.CreateOrderItemOption{
 			OrderID:         oID,
 			TicketClassID:   itm.TicketClassID,
 			TicketClassName: itm.TicketClassName,
+			PriceAtPurchase: itm.PriceAtPurchase,
+			Quantity:        itm.Quantity,
+			TotalAmount:     itm.TotalAmount,
 		}
 	}
-	var itms []models.OrderItem
 
-	err := workflow.ExecuteActivity(ctx, oActs.CreateOrderItems, oID, opts).Get(ctx, &itms)
-	return itms, err
+	return opts
 }
 
 func reserveInventory(ctx workflow.Context, oCode string, expAt string, ins []CreateOrderItemInput) error {
@@ -85,6 +112,11 @@ func reserveInventory(ctx workflow.Context, oCode string, expAt string, ins []Cr
 	return err
 }
 
+func releaseInventory(ctx workflow.Context, oCode string) error {
+	err := workflow.ExecuteActivity(ctx, iActs.ReleaseInventory, oCode).Get(ctx, nil)
+	return err
+}
+
 func updateOrderStatus(ctx workflow.Context, oID string, status models.OrderStatus) error {
 	err := workflow.ExecuteActivity(ctx, oActs.UpdateOrderStatus, oID, status).Get(ctx, nil)
 	return err
@@ -106,6 +138,173 @@ func processPayment(ctx workflow.Context, in *CreateOrderWorkflowInput) (*paymen
 	return resp, err
 }
 
+func failPendingOrder(ctx workflow.Context, oID string, status models.OrderStatus, reason string, actor models.OrderActor) (bool, error) {
+	var applied bool
+	err := workflow.ExecuteActivity(ctx, oActs.FailPendingOrder, activities.FailPendingOrderInput{
+		OrderID: oID,
+		Status:  status,
+		Reason:  reason,
+		Actor:   actor,
+	}).Get(ctx, &applied)
+	return applied, err
+}
+
+// transitionOrder moves the order from one status to another together with its checkout event
+func transitionOrder(ctx workflow.Context, oID string, from, to models.OrderStatus, reason string, actor models.OrderActor) (bool, error) {
+	var applied bool
+	err := workflow.ExecuteActivity(ctx, oActs.TransitionOrder, activities.TransitionOrderInput{
+		OrderID:       oID,
+		From:          from,
+		To:            to,
+		FailureReason: reason,
+		Actor:         actor,
+	}).Get(ctx, &applied)
+	return applied, err
+}
+
+func recordOrderPayment(ctx workflow.Context, oID string, pmt *PaymentDetails) error {
+	err := workflow.ExecuteActivity(ctx, oActs.RecordOrderPayment, activities.RecordOrderPaymentInput{
+		OrderID:       oID,
+		PaymentID:     pmt.PaymentID,
+		TransactionID: pmt.TransactionID,
+		PaidAt:        pmt.PaidAt,
+	}).Get(ctx, nil)
+	return err
+}
+
+func updateOrderPaymentID(ctx workflow.Context, oID string, pmtID string) error {
+	err := workflow.ExecuteActivity(ctx, oActs.UpdateOrderPaymentID, oID, pmtID).Get(ctx, nil)
+	return err
+}
+
+func cancelPayment(ctx workflow.Context, pmtID string) error {
+	err := workflow.ExecuteActivity(ctx, pActs.CancelPayment, pmtID).Get(ctx, nil)
+	return err
+}
+
+// isPaymentAlreadyCompleted reports whether a payment cancellation failed because the customer already paid
+func isPaymentAlreadyCompleted(err error) bool {
+	var appErr *temporal.ApplicationError
+	return errors.As(err, &appErr) && appErr.Type() == activities.ErrTypePaymentAlreadyCompleted
+}
+
+func isOrderNotPending(err error) bool {
+	var appErr *temporal.ApplicationError
+	return errors.As(err, &appErr) && appErr.Type() == ErrTypeOrderNotPending
+}
+
+// isInvalidOrderTransition reports whether an order update was refused because the order cannot move
+// to the requested status anymore
+func isInvalidOrderTransition(err error) bool {
+	var appErr *temporal.ApplicationError
+	return errors.As(err, &appErr) && appErr.Type() == activities.ErrTypeInvalidOrderTransition
+}
+
+func isWorkflowAlreadyStarted(err error) bool {
+	return temporal.IsWorkflowExecutionAlreadyStartedError(err)
+}
+
+func listEventOrders(ctx workflow.Context, eventID string, after repo.ListOrderCursor, limit int64) (*activities.ListEventOrdersResult, error) {
+	var res *activities.ListEventOrdersResult
+	err := workflow.ExecuteActivity(ctx, oActs.ListEventOrders, activities.ListEventOrdersInput{
+		EventID: eventID,
+		After:   after,
+		Limit:   limit,
+	}).Get(ctx, &res)
+	return res, err
+}
+
+func createOrderRefund(ctx workflow.Context, o *models.Order, in *RefundOrderWorkflowInput) (*models.OrderRefund, error) {
+	itms := make([]activities.RefundItemInput, len(in.Items))
+	for i, itm := range in.Items {
+		itms[i] = activities.RefundItemInput{
+			TicketClassID: itm.TicketClassID,
+			Quantity:      itm.Quantity,
+		}
+	}
+
+	var rf *models.OrderRefund
+	err := workflow.ExecuteActivity(ctx, oActs.CreateOrderRefund, activities.CreateOrderRefundInput{
+		OrderID:        o.ID.Hex(),
+		OrderCode:      o.Code,
+		Reason:         in.Reason,
+		Items:          itms,
+		IdempotencyKey: workflow.GetInfo(ctx).WorkflowExecution.RunID,
+	}).Get(ctx, &rf)
+	return rf, err
+}
+
+func syncOrderRefunds(ctx workflow.Context, oID string, actor models.OrderActor, reason string) (*models.Order, error) {
+	var o *models.Order
+	err := workflow.ExecuteActivity(ctx, oActs.SyncOrderRefunds, oID, actor, reason).Get(ctx, &o)
+	return o, err
+}
+
+func completeOrderRefund(ctx workflow.Context, rfID string, pmtRfID string) error {
+	err := workflow.ExecuteActivity(ctx, oActs.CompleteOrderRefund, rfID, pmtRfID).Get(ctx, nil)
+	return err
+}
+
+func refundPayment(ctx workflow.Context, oCode string, rfID string, amt int64, reason string) (*payment.RefundPaymentResponse, error) {
+	var resp *payment.RefundPaymentResponse
+	err := workflow.ExecuteActivity(ctx, pActs.RefundPayment,
+		activities.RefundPaymentInput{
+			OrderCode:      oCode,
+			AmountCents:    amt,
+			Reason:         reason,
+			IdempotencyKey: fmt.Sprintf("refund:%s", rfID),
+		},
+	).Get(ctx, &resp)
+	return resp, err
+}
+
+func returnInventory(ctx workflow.Context, oCode string, rfID string, itms []models.OrderRefundItem) error {
+	rtnItms := make([]*inventory.ReturnItem, len(itms))
+	for i, itm := range itms {
+		rtnItms[i] = &inventory.ReturnItem{
+			TicketClassId: itm.TicketClassID,
+			Quantity:      itm.Quantity,
+		}
+	}
+
+	err := workflow.ExecuteActivity(ctx, iActs.ReturnInventory, oCode, rtnItms, rfID).Get(ctx, nil)
+	return err
+}
+
+func createIntervention(ctx workflow.Context, oCode string, step models.InterventionStep, stepErr error) (*models.Intervention, error) {
+	info := workflow.GetInfo(ctx)
+
+	var itv *models.Intervention
+	err := workflow.ExecuteActivity(ctx, itvActs.CreateIntervention,
+		activities.CreateInterventionInput{
+			OrderCode:  oCode,
+			Step:       step,
+			Error:      stepErr.Error(),
+			WorkflowID: info.WorkflowExecution.ID,
+			RunID:      info.WorkflowExecution.RunID,
+		},
+	).Get(ctx, &itv)
+	return itv, err
+}
+
+// awaitIntervention blocks until an operator resolves the given intervention
+func awaitIntervention(ctx workflow.Context, itvID string) InterventionResolvedSignal {
+	ch := workflow.GetSignalChannel(ctx, SignalNameInterventionResolved)
+	for {
+		var sig InterventionResolvedSignal
+		ch.Receive(ctx, &sig)
+		if sig.InterventionID == itvID {
+			return sig
+		}
+		workflow.GetLogger(ctx).Warn("Ignoring decision for another intervention", "interventionID", sig.InterventionID)
+	}
+}
+
+func failOrderRefund(ctx workflow.Context, rfID string) error {
+	err := workflow.ExecuteActivity(ctx, oActs.FailOrderRefund, rfID).Get(ctx, nil)
+	return err
+}
+
 func confirmInventory(ctx workflow.Context, oCode string) error {
 	err := workflow.ExecuteActivity(ctx, iActs.ConfirmInventory, oCode).Get(ctx, nil)
 	return err
@@ -124,3 +323,49 @@ func publishCheckoutCompleted(ctx workflow.Context, ssID, userID, eventID string
 		}).Get(ctx, nil)
 	return err
 }
+
+func publishCheckoutFailed(ctx workflow.Context, ssID, userID, eventID string) error {
+	if ssID == "" {
+		return nil
+	}
+
+	err := workflow.ExecuteActivity(ctx, epActs.PublishCheckoutFailed,
+		activities.PublishCheckoutFailedInput{
+			SessionID: ssID,
+			UserID:    userID,
+			EventID:   eventID,
+		}).Get(ctx, nil)
+	return err
+}
+
+func publishOrderCreated(ctx workflow.Context, oID string) error {
+	err := workflow.ExecuteActivity(ctx, epActs.PublishOrderCreated, oID).Get(ctx, nil)
+	return err
+}
+
+// publishOrderRefunded publishes the refund of o, which moved from prevStatus to its current status
+func publishOrderRefunded(ctx workflow.Context, o *models.Order, prevStatus models.OrderStatus, rf *models.OrderRefund) error {
+	itms := make([]activities.RefundItemInput, len(rf.Items))
+	for i, itm := range rf.Items {
+		itms[i] = activities.RefundItemInput{
+			TicketClassID: itm.TicketClassID,
+			Quantity:      itm.Quantity,
+		}
+	}
+
+	err := workflow.ExecuteActivity(ctx, epActs.PublishOrderRefunded,
+		activities.PublishOrderRefundedInput{
+			PreviousStatus: string(prevStatus),
+			OrderID:        o.ID.Hex(),
+			OrderCode:      o.Code,
+			RefundID:       rf.ID.Hex(),
+			UserID:         o.UserID,
+			EventID:        o.EventID,
+			Status:         string(o.Status),
+			AmountCents:    rf.Amount,
+			Currency:       o.Currency,
+			Reason:         rf.Reason,
+			Items:          itms,
+		}).Get(ctx, nil)
+	return err
+}
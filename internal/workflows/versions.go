@@ -0,0 +1,45 @@
+package workflows
+
+// Change IDs passed to workflow.GetVersion. Each one marks a change to a workflow that would
+// otherwise break the replay of executions started before it was deployed.
+const (
+	// ChangeIDCreateOrderPaymentID attaches the payment intent to the order and cancels it on rollback
+	ChangeIDCreateOrderPaymentID = "create-order-payment-id"
+
+	// ChangeIDCreateOrderParallelCompensation rolls the saga back in parallel instead of in reverse order
+	ChangeIDCreateOrderParallelCompensation = "create-order-parallel-compensation"
+
+	// ChangeIDCreateOrderAwaitPayment keeps CreateOrder running until the payment outcome is known
+	ChangeIDCreateOrderAwaitPayment = "create-order-await-payment"
+
+	// ChangeIDCreateOrderCreatedEvent publishes order.created once the order is placed
+	ChangeIDCreateOrderCreatedEvent = "create-order-created-event"
+
+	// ChangeIDCreateOrderAtomicPersist persists the order and its items in one activity
+	ChangeIDCreateOrderAtomicPersist = "create-order-atomic-persist"
+
+	// ChangeIDCreateOrderLatePayment confirms payments reported after the payment phase and verifies
+	// the payment of an order found paid while it was being failed
+	ChangeIDCreateOrderLatePayment = "create-order-late-payment"
+
+	// ChangeIDConfirmOrderIntervention waits for an operator instead of failing when a step cannot complete
+	ChangeIDConfirmOrderIntervention = "confirm-order-intervention"
+
+	// ChangeIDConfirmOrderVerifyPayment checks the payment against the order and holds a mismatch for review
+	ChangeIDConfirmOrderVerifyPayment = "confirm-order-verify-payment"
+
+	// ChangeIDConfirmOrderRefundLatePayment refunds a payment that completed after the order was closed
+	ChangeIDConfirmOrderRefundLatePayment = "confirm-order-refund-late-payment"
+
+	// ChangeIDConfirmOrderTransition completes the order and publishes checkout.completed in one activity
+	ChangeIDConfirmOrderTransition = "confirm-order-transition"
+
+	// ChangeIDCancelOrderTransition cancels the order and publishes checkout.failed in one activity
+	ChangeIDCancelOrderTransition = "cancel-order-transition"
+
+	// ChangeIDFailOrderTransition fails the order and publishes checkout.failed in one activity
+	ChangeIDFailOrderTransition = "fail-order-transition"
+
+	// ChangeIDRefundOrderSyncAfterPayment applies a refund to the order only after the payment refund succeeded
+	ChangeIDRefundOrderSyncAfterPayment = "refund-order-sync-after-payment"
+)
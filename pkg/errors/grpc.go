@@ -17,6 +17,14 @@ func NewGRPCError(code string, message string) *GRPCError {
 	}
 }
 
+// NewGRPCErrorWithCode creates an error returned with the given status code instead of InvalidArgument
+func NewGRPCErrorWithCode(code string, message string, grpcCode codes.Code) *GRPCError {
+	return &GRPCError{
+		Message:  fmt.Sprintf("%s - %s", code, message),
+		GrpcCode: grpcCode,
+	}
+}
+
 func (e GRPCError) Error() string {
 	return e.Message
 }
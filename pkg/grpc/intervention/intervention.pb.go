@@ -0,0 +1,747 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: intervention.proto
+
+package intervention
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type InterventionStatus int32
+
+const (
+	InterventionStatus_INTERVENTION_STATUS_UNSPECIFIED InterventionStatus = 0
+	InterventionStatus_INTERVENTION_STATUS_OPEN        InterventionStatus = 1
+	InterventionStatus_INTERVENTION_STATUS_CLAIMED     InterventionStatus = 2
+	InterventionStatus_INTERVENTION_STATUS_RESOLVED    InterventionStatus = 3
+)
+
+// Enum value maps for InterventionStatus.
+var (
+	InterventionStatus_name = map[int32]string{
+		0: "INTERVENTION_STATUS_UNSPECIFIED",
+		1: "INTERVENTION_STATUS_OPEN",
+		2: "INTERVENTION_STATUS_CLAIMED",
+		3: "INTERVENTION_STATUS_RESOLVED",
+	}
+	InterventionStatus_value = map[string]int32{
+		"INTERVENTION_STATUS_UNSPECIFIED": 0,
+		"INTERVENTION_STATUS_OPEN":        1,
+		"INTERVENTION_STATUS_CLAIMED":     2,
+		"INTERVENTION_STATUS_RESOLVED":    3,
+	}
+)
+
+func (x InterventionStatus) Enum() *InterventionStatus {
+	p := new(InterventionStatus)
+	*p = x
+	return p
+}
+
+func (x InterventionStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (InterventionStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_intervention_proto_enumTypes[0].Descriptor()
+}
+
+func (InterventionStatus) Type() protoreflect.EnumType {
+	return &file_intervention_proto_enumTypes[0]
+}
+
+func (x InterventionStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use InterventionStatus.Descriptor instead.
+func (InterventionStatus) EnumDescriptor() ([]byte, []int) {
+	return file_intervention_proto_rawDescGZIP(), []int{0}
+}
+
+type InterventionAction int32
+
+const (
+	InterventionAction_INTERVENTION_ACTION_UNSPECIFIED InterventionAction = 0
+	InterventionAction_INTERVENTION_ACTION_RETRY       InterventionAction = 1
+	InterventionAction_INTERVENTION_ACTION_REFUND      InterventionAction = 2
+)
+
+// Enum value maps for InterventionAction.
+var (
+	InterventionAction_name = map[int32]string{
+		0: "INTERVENTION_ACTION_UNSPECIFIED",
+		1: "INTERVENTION_ACTION_RETRY",
+		2: "INTERVENTION_ACTION_REFUND",
+	}
+	InterventionAction_value = map[string]int32{
+		"INTERVENTION_ACTION_UNSPECIFIED": 0,
+		"INTERVENTION_ACTION_RETRY":       1,
+		"INTERVENTION_ACTION_REFUND":      2,
+	}
+)
+
+func (x InterventionAction) Enum() *InterventionAction {
+	p := new(InterventionAction)
+	*p = x
+	return p
+}
+
+func (x InterventionAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (InterventionAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_intervention_proto_enumTypes[1].Descriptor()
+}
+
+func (InterventionAction) Type() protoreflect.EnumType {
+	return &file_intervention_proto_enumTypes[1]
+}
+
+func (x InterventionAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use InterventionAction.Descriptor instead.
+func (InterventionAction) EnumDescriptor() ([]byte, []int) {
+	return file_intervention_proto_rawDescGZIP(), []int{1}
+}
+
+type Intervention struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderCode     string                 `protobuf:"bytes,2,opt,name=order_code,json=orderCode,proto3" json:"order_code,omitempty"`
+	Step          string                 `protobuf:"bytes,3,opt,name=step,proto3" json:"step,omitempty"`
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	WorkflowId    string                 `protobuf:"bytes,5,opt,name=workflow_id,json=workflowId,proto3" json:"workflow_id,omitempty"`
+	RunId         string                 `protobuf:"bytes,6,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	Status        InterventionStatus     `protobuf:"varint,7,opt,name=status,proto3,enum=intervention.InterventionStatus" json:"status,omitempty"`
+	ClaimedBy     string                 `protobuf:"bytes,8,opt,name=claimed_by,json=claimedBy,proto3" json:"claimed_by,omitempty"`
+	ClaimedAt     string                 `protobuf:"bytes,9,opt,name=claimed_at,json=claimedAt,proto3" json:"claimed_at,omitempty"`
+	Action        InterventionAction     `protobuf:"varint,10,opt,name=action,proto3,enum=intervention.InterventionAction" json:"action,omitempty"`
+	ResolvedBy    string                 `protobuf:"bytes,11,opt,name=resolved_by,json=resolvedBy,proto3" json:"resolved_by,omitempty"`
+	ResolvedAt    string                 `protobuf:"bytes,12,opt,name=resolved_at,json=resolvedAt,proto3" json:"resolved_at,omitempty"`
+	Note          string                 `protobuf:"bytes,13,opt,name=note,proto3" json:"note,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,14,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     string                 `protobuf:"bytes,15,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Intervention) Reset() {
+	*x = Intervention{}
+	mi := &file_intervention_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Intervention) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Intervention) ProtoMessage() {}
+
+func (x *Intervention) ProtoReflect() protoreflect.Message {
+	mi := &file_intervention_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Intervention.ProtoReflect.Descriptor instead.
+func (*Intervention) Descriptor() ([]byte, []int) {
+	return file_intervention_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Intervention) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Intervention) GetOrderCode() string {
+	if x != nil {
+		return x.OrderCode
+	}
+	return ""
+}
+
+func (x *Intervention) GetStep() string {
+	if x != nil {
+		return x.Step
+	}
+	return ""
+}
+
+func (x *Intervention) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *Intervention) GetWorkflowId() string {
+	if x != nil {
+		return x.WorkflowId
+	}
+	return ""
+}
+
+func (x *Intervention) GetRunId() string {
+	if x != nil {
+		return x.RunId
+	}
+	return ""
+}
+
+func (x *Intervention) GetStatus() InterventionStatus {
+	if x != nil {
+		return x.Status
+	}
+	return InterventionStatus_INTERVENTION_STATUS_UNSPECIFIED
+}
+
+func (x *Intervention) GetClaimedBy() string {
+	if x != nil {
+		return x.ClaimedBy
+	}
+	return ""
+}
+
+func (x *Intervention) GetClaimedAt() string {
+	if x != nil {
+		return x.ClaimedAt
+	}
+	return ""
+}
+
+func (x *Intervention) GetAction() InterventionAction {
+	if x != nil {
+		return x.Action
+	}
+	return InterventionAction_INTERVENTION_ACTION_UNSPECIFIED
+}
+
+func (x *Intervention) GetResolvedBy() string {
+	if x != nil {
+		return x.ResolvedBy
+	}
+	return ""
+}
+
+func (x *Intervention) GetResolvedAt() string {
+	if x != nil {
+		return x.ResolvedAt
+	}
+	return ""
+}
+
+func (x *Intervention) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *Intervention) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Intervention) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+type ListInterventionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        *InterventionStatus    `protobuf:"varint,1,opt,name=status,proto3,enum=intervention.InterventionStatus,oneof" json:"status,omitempty"`
+	OrderCode     *string                `protobuf:"bytes,2,opt,name=order_code,json=orderCode,proto3,oneof" json:"order_code,omitempty"`
+	Limit         int64                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor        string                 `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInterventionsRequest) Reset() {
+	*x = ListInterventionsRequest{}
+	mi := &file_intervention_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInterventionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInterventionsRequest) ProtoMessage() {}
+
+func (x *ListInterventionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_intervention_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInterventionsRequest.ProtoReflect.Descriptor instead.
+func (*ListInterventionsRequest) Descriptor() ([]byte, []int) {
+	return file_intervention_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListInterventionsRequest) GetStatus() InterventionStatus {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return InterventionStatus_INTERVENTION_STATUS_UNSPECIFIED
+}
+
+func (x *ListInterventionsRequest) GetOrderCode() string {
+	if x != nil && x.OrderCode != nil {
+		return *x.OrderCode
+	}
+	return ""
+}
+
+func (x *ListInterventionsRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListInterventionsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type ListInterventionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Interventions []*Intervention        `protobuf:"bytes,1,rep,name=interventions,proto3" json:"interventions,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInterventionsResponse) Reset() {
+	*x = ListInterventionsResponse{}
+	mi := &file_intervention_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInterventionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInterventionsResponse) ProtoMessage() {}
+
+func (x *ListInterventionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_intervention_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInterventionsResponse.ProtoReflect.Descriptor instead.
+func (*ListInterventionsResponse) Descriptor() ([]byte, []int) {
+	return file_intervention_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListInterventionsResponse) GetInterventions() []*Intervention {
+	if x != nil {
+		return x.Interventions
+	}
+	return nil
+}
+
+func (x *ListInterventionsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type ClaimInterventionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Operator      string                 `protobuf:"bytes,2,opt,name=operator,proto3" json:"operator,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClaimInterventionRequest) Reset() {
+	*x = ClaimInterventionRequest{}
+	mi := &file_intervention_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClaimInterventionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClaimInterventionRequest) ProtoMessage() {}
+
+func (x *ClaimInterventionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_intervention_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClaimInterventionRequest.ProtoReflect.Descriptor instead.
+func (*ClaimInterventionRequest) Descriptor() ([]byte, []int) {
+	return file_intervention_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ClaimInterventionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ClaimInterventionRequest) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+type ClaimInterventionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Intervention  *Intervention          `protobuf:"bytes,1,opt,name=intervention,proto3" json:"intervention,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClaimInterventionResponse) Reset() {
+	*x = ClaimInterventionResponse{}
+	mi := &file_intervention_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClaimInterventionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClaimInterventionResponse) ProtoMessage() {}
+
+func (x *ClaimInterventionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_intervention_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClaimInterventionResponse.ProtoReflect.Descriptor instead.
+func (*ClaimInterventionResponse) Descriptor() ([]byte, []int) {
+	return file_intervention_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ClaimInterventionResponse) GetIntervention() *Intervention {
+	if x != nil {
+		return x.Intervention
+	}
+	return nil
+}
+
+type ResolveInterventionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Operator      string                 `protobuf:"bytes,2,opt,name=operator,proto3" json:"operator,omitempty"`
+	Action        InterventionAction     `protobuf:"varint,3,opt,name=action,proto3,enum=intervention.InterventionAction" json:"action,omitempty"`
+	Note          string                 `protobuf:"bytes,4,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveInterventionRequest) Reset() {
+	*x = ResolveInterventionRequest{}
+	mi := &file_intervention_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveInterventionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveInterventionRequest) ProtoMessage() {}
+
+func (x *ResolveInterventionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_intervention_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveInterventionRequest.ProtoReflect.Descriptor instead.
+func (*ResolveInterventionRequest) Descriptor() ([]byte, []int) {
+	return file_intervention_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ResolveInterventionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ResolveInterventionRequest) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+func (x *ResolveInterventionRequest) GetAction() InterventionAction {
+	if x != nil {
+		return x.Action
+	}
+	return InterventionAction_INTERVENTION_ACTION_UNSPECIFIED
+}
+
+func (x *ResolveInterventionRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+type ResolveInterventionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Intervention  *Intervention          `protobuf:"bytes,1,opt,name=intervention,proto3" json:"intervention,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveInterventionResponse) Reset() {
+	*x = ResolveInterventionResponse{}
+	mi := &file_intervention_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveInterventionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveInterventionResponse) ProtoMessage() {}
+
+func (x *ResolveInterventionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_intervention_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveInterventionResponse.ProtoReflect.Descriptor instead.
+func (*ResolveInterventionResponse) Descriptor() ([]byte, []int) {
+	return file_intervention_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ResolveInterventionResponse) GetIntervention() *Intervention {
+	if x != nil {
+		return x.Intervention
+	}
+	return nil
+}
+
+var File_intervention_proto protoreflect.FileDescriptor
+
+const file_intervention_proto_rawDesc = "" +
+	"\n" +
+	"\x12intervention.proto\x12\fintervention\"\xe5\x03\n" +
+	"\fIntervention\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"order_code\x18\x02 \x01(\tR\torderCode\x12\x12\n" +
+	"\x04step\x18\x03 \x01(\tR\x04step\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x12\x1f\n" +
+	"\vworkflow_id\x18\x05 \x01(\tR\n" +
+	"workflowId\x12\x15\n" +
+	"\x06run_id\x18\x06 \x01(\tR\x05runId\x128\n" +
+	"\x06status\x18\a \x01(\x0e2 .intervention.InterventionStatusR\x06status\x12\x1d\n" +
+	"\n" +
+	"claimed_by\x18\b \x01(\tR\tclaimedBy\x12\x1d\n" +
+	"\n" +
+	"claimed_at\x18\t \x01(\tR\tclaimedAt\x128\n" +
+	"\x06action\x18\n" +
+	" \x01(\x0e2 .intervention.InterventionActionR\x06action\x12\x1f\n" +
+	"\vresolved_by\x18\v \x01(\tR\n" +
+	"resolvedBy\x12\x1f\n" +
+	"\vresolved_at\x18\f \x01(\tR\n" +
+	"resolvedAt\x12\x12\n" +
+	"\x04note\x18\r \x01(\tR\x04note\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x0e \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x0f \x01(\tR\tupdatedAt\"\xc5\x01\n" +
+	"\x18ListInterventionsRequest\x12=\n" +
+	"\x06status\x18\x01 \x01(\x0e2 .intervention.InterventionStatusH\x00R\x06status\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"order_code\x18\x02 \x01(\tH\x01R\torderCode\x88\x01\x01\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x03R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x04 \x01(\tR\x06cursorB\t\n" +
+	"\a_statusB\r\n" +
+	"\v_order_code\"~\n" +
+	"\x19ListInterventionsResponse\x12@\n" +
+	"\rinterventions\x18\x01 \x03(\v2\x1a.intervention.InterventionR\rinterventions\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"F\n" +
+	"\x18ClaimInterventionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\boperator\x18\x02 \x01(\tR\boperator\"[\n" +
+	"\x19ClaimInterventionResponse\x12>\n" +
+	"\fintervention\x18\x01 \x01(\v2\x1a.intervention.InterventionR\fintervention\"\x96\x01\n" +
+	"\x1aResolveInterventionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\boperator\x18\x02 \x01(\tR\boperator\x128\n" +
+	"\x06action\x18\x03 \x01(\x0e2 .intervention.InterventionActionR\x06action\x12\x12\n" +
+	"\x04note\x18\x04 \x01(\tR\x04note\"]\n" +
+	"\x1bResolveInterventionResponse\x12>\n" +
+	"\fintervention\x18\x01 \x01(\v2\x1a.intervention.InterventionR\fintervention*\x9a\x01\n" +
+	"\x12InterventionStatus\x12#\n" +
+	"\x1fINTERVENTION_STATUS_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18INTERVENTION_STATUS_OPEN\x10\x01\x12\x1f\n" +
+	"\x1bINTERVENTION_STATUS_CLAIMED\x10\x02\x12 \n" +
+	"\x1cINTERVENTION_STATUS_RESOLVED\x10\x03*x\n" +
+	"\x12InterventionAction\x12#\n" +
+	"\x1fINTERVENTION_ACTION_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19INTERVENTION_ACTION_RETRY\x10\x01\x12\x1e\n" +
+	"\x1aINTERVENTION_ACTION_REFUND\x10\x022\xcd\x02\n" +
+	"\x13InterventionService\x12d\n" +
+	"\x11ListInterventions\x12&.intervention.ListInterventionsRequest\x1a'.intervention.ListInterventionsResponse\x12d\n" +
+	"\x11ClaimIntervention\x12&.intervention.ClaimInterventionRequest\x1a'.intervention.ClaimInterventionResponse\x12j\n" +
+	"\x13ResolveIntervention\x12(.intervention.ResolveInterventionRequest\x1a).intervention.ResolveInterventionResponseB>Z<github.com/vogiaan1904/ticketbottle-proto/proto/interventionb\x06proto3"
+
+var (
+	file_intervention_proto_rawDescOnce sync.Once
+	file_intervention_proto_rawDescData []byte
+)
+
+func file_intervention_proto_rawDescGZIP() []byte {
+	file_intervention_proto_rawDescOnce.Do(func() {
+		file_intervention_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_intervention_proto_rawDesc), len(file_intervention_proto_rawDesc)))
+	})
+	return file_intervention_proto_rawDescData
+}
+
+var file_intervention_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_intervention_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_intervention_proto_goTypes = []any{
+	(InterventionStatus)(0),             // 0: intervention.InterventionStatus
+	(InterventionAction)(0),             // 1: intervention.InterventionAction
+	(*Intervention)(nil),                // 2: intervention.Intervention
+	(*ListInterventionsRequest)(nil),    // 3: intervention.ListInterventionsRequest
+	(*ListInterventionsResponse)(nil),   // 4: intervention.ListInterventionsResponse
+	(*ClaimInterventionRequest)(nil),    // 5: intervention.ClaimInterventionRequest
+	(*ClaimInterventionResponse)(nil),   // 6: intervention.ClaimInterventionResponse
+	(*ResolveInterventionRequest)(nil),  // 7: intervention.ResolveInterventionRequest
+	(*ResolveInterventionResponse)(nil), // 8: intervention.ResolveInterventionResponse
+}
+var file_intervention_proto_depIdxs = []int32{
+	0,  // 0: intervention.Intervention.status:type_name -> intervention.InterventionStatus
+	1,  // 1: intervention.Intervention.action:type_name -> intervention.InterventionAction
+	0,  // 2: intervention.ListInterventionsRequest.status:type_name -> intervention.InterventionStatus
+	2,  // 3: intervention.ListInterventionsResponse.interventions:type_name -> intervention.Intervention
+	2,  // 4: intervention.ClaimInterventionResponse.intervention:type_name -> intervention.Intervention
+	1,  // 5: intervention.ResolveInterventionRequest.action:type_name -> intervention.InterventionAction
+	2,  // 6: intervention.ResolveInterventionResponse.intervention:type_name -> intervention.Intervention
+	3,  // 7: intervention.InterventionService.ListInterventions:input_type -> intervention.ListInterventionsRequest
+	5,  // 8: intervention.InterventionService.ClaimIntervention:input_type -> intervention.ClaimInterventionRequest
+	7,  // 9: intervention.InterventionService.ResolveIntervention:input_type -> intervention.ResolveInterventionRequest
+	4,  // 10: intervention.InterventionService.ListInterventions:output_type -> intervention.ListInterventionsResponse
+	6,  // 11: intervention.InterventionService.ClaimIntervention:output_type -> intervention.ClaimInterventionResponse
+	8,  // 12: intervention.InterventionService.ResolveIntervention:output_type -> intervention.ResolveInterventionResponse
+	10, // [10:13] is the sub-list for method output_type
+	7,  // [7:10] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_intervention_proto_init() }
+func file_intervention_proto_init() {
+	if File_intervention_proto != nil {
+		return
+	}
+	file_intervention_proto_msgTypes[1].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_intervention_proto_rawDesc), len(file_intervention_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_intervention_proto_goTypes,
+		DependencyIndexes: file_intervention_proto_depIdxs,
+		EnumInfos:         file_intervention_proto_enumTypes,
+		MessageInfos:      file_intervention_proto_msgTypes,
+	}.Build()
+	File_intervention_proto = out.File
+	file_intervention_proto_goTypes = nil
+	file_intervention_proto_depIdxs = nil
+}
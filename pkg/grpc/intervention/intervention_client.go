@@ -0,0 +1,19 @@
+package intervention
+
+import (
+	"log"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func NewInterventionClient(addr string) (InterventionServiceClient, func(), error) {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Println("gRpc Intervention client connection failed.", err)
+		return nil, nil, err
+	}
+
+	log.Println("gRpc Intervention client connection established.")
+	return NewInterventionServiceClient(conn), func() { conn.Close() }, nil
+}
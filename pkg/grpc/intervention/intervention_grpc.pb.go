@@ -0,0 +1,197 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: intervention.proto
+
+package intervention
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	InterventionService_ListInterventions_FullMethodName   = "/intervention.InterventionService/ListInterventions"
+	InterventionService_ClaimIntervention_FullMethodName   = "/intervention.InterventionService/ClaimIntervention"
+	InterventionService_ResolveIntervention_FullMethodName = "/intervention.InterventionService/ResolveIntervention"
+)
+
+// InterventionServiceClient is the client API for InterventionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type InterventionServiceClient interface {
+	ListInterventions(ctx context.Context, in *ListInterventionsRequest, opts ...grpc.CallOption) (*ListInterventionsResponse, error)
+	ClaimIntervention(ctx context.Context, in *ClaimInterventionRequest, opts ...grpc.CallOption) (*ClaimInterventionResponse, error)
+	ResolveIntervention(ctx context.Context, in *ResolveInterventionRequest, opts ...grpc.CallOption) (*ResolveInterventionResponse, error)
+}
+
+type interventionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewInterventionServiceClient(cc grpc.ClientConnInterface) InterventionServiceClient {
+	return &interventionServiceClient{cc}
+}
+
+func (c *interventionServiceClient) ListInterventions(ctx context.Context, in *ListInterventionsRequest, opts ...grpc.CallOption) (*ListInterventionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListInterventionsResponse)
+	err := c.cc.Invoke(ctx, InterventionService_ListInterventions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interventionServiceClient) ClaimIntervention(ctx context.Context, in *ClaimInterventionRequest, opts ...grpc.CallOption) (*ClaimInterventionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClaimInterventionResponse)
+	err := c.cc.Invoke(ctx, InterventionService_ClaimIntervention_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interventionServiceClient) ResolveIntervention(ctx context.Context, in *ResolveInterventionRequest, opts ...grpc.CallOption) (*ResolveInterventionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResolveInterventionResponse)
+	err := c.cc.Invoke(ctx, InterventionService_ResolveIntervention_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// InterventionServiceServer is the server API for InterventionService service.
+// All implementations must embed UnimplementedInterventionServiceServer
+// for forward compatibility.
+type InterventionServiceServer interface {
+	ListInterventions(context.Context, *ListInterventionsRequest) (*ListInterventionsResponse, error)
+	ClaimIntervention(context.Context, *ClaimInterventionRequest) (*ClaimInterventionResponse, error)
+	ResolveIntervention(context.Context, *ResolveInterventionRequest) (*ResolveInterventionResponse, error)
+	mustEmbedUnimplementedInterventionServiceServer()
+}
+
+// UnimplementedInterventionServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedInterventionServiceServer struct{}
+
+func (UnimplementedInterventionServiceServer) ListInterventions(context.Context, *ListInterventionsRequest) (*ListInterventionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListInterventions not implemented")
+}
+func (UnimplementedInterventionServiceServer) ClaimIntervention(context.Context, *ClaimInterventionRequest) (*ClaimInterventionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClaimIntervention not implemented")
+}
+func (UnimplementedInterventionServiceServer) ResolveIntervention(context.Context, *ResolveInterventionRequest) (*ResolveInterventionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveIntervention not implemented")
+}
+func (UnimplementedInterventionServiceServer) mustEmbedUnimplementedInterventionServiceServer() {}
+func (UnimplementedInterventionServiceServer) testEmbeddedByValue()                             {}
+
+// UnsafeInterventionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to InterventionServiceServer will
+// result in compilation errors.
+type UnsafeInterventionServiceServer interface {
+	mustEmbedUnimplementedInterventionServiceServer()
+}
+
+func RegisterInterventionServiceServer(s grpc.ServiceRegistrar, srv InterventionServiceServer) {
+	// If the following call pancis, it indicates UnimplementedInterventionServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&InterventionService_ServiceDesc, srv)
+}
+
+func _InterventionService_ListInterventions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListInterventionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InterventionServiceServer).ListInterventions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InterventionService_ListInterventions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InterventionServiceServer).ListInterventions(ctx, req.(*ListInterventionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InterventionService_ClaimIntervention_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClaimInterventionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InterventionServiceServer).ClaimIntervention(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InterventionService_ClaimIntervention_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InterventionServiceServer).ClaimIntervention(ctx, req.(*ClaimInterventionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InterventionService_ResolveIntervention_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveInterventionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InterventionServiceServer).ResolveIntervention(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InterventionService_ResolveIntervention_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InterventionServiceServer).ResolveIntervention(ctx, req.(*ResolveInterventionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// InterventionService_ServiceDesc is the grpc.ServiceDesc for InterventionService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var InterventionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "intervention.InterventionService",
+	HandlerType: (*InterventionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListInterventions",
+			Handler:    _InterventionService_ListInterventions_Handler,
+		},
+		{
+			MethodName: "ClaimIntervention",
+			Handler:    _InterventionService_ClaimIntervention_Handler,
+		},
+		{
+			MethodName: "ResolveIntervention",
+			Handler:    _InterventionService_ResolveIntervention_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "intervention.proto",
+}
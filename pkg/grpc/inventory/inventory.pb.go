@@ -838,6 +838,118 @@ func (x *ReleaseRequest) GetOrderCode() string {
 	return ""
 }
 
+type ReturnItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TicketClassId string                 `protobuf:"bytes,1,opt,name=ticket_class_id,json=ticketClassId,proto3" json:"ticket_class_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReturnItem) Reset() {
+	*x = ReturnItem{}
+	mi := &file_inventory_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReturnItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReturnItem) ProtoMessage() {}
+
+func (x *ReturnItem) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReturnItem.ProtoReflect.Descriptor instead.
+func (*ReturnItem) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ReturnItem) GetTicketClassId() string {
+	if x != nil {
+		return x.TicketClassId
+	}
+	return ""
+}
+
+func (x *ReturnItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type ReturnRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrderCode      string                 `protobuf:"bytes,1,opt,name=order_code,json=orderCode,proto3" json:"order_code,omitempty"`
+	Items          []*ReturnItem          `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	IdempotencyKey string                 `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ReturnRequest) Reset() {
+	*x = ReturnRequest{}
+	mi := &file_inventory_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReturnRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReturnRequest) ProtoMessage() {}
+
+func (x *ReturnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReturnRequest.ProtoReflect.Descriptor instead.
+func (*ReturnRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ReturnRequest) GetOrderCode() string {
+	if x != nil {
+		return x.OrderCode
+	}
+	return ""
+}
+
+func (x *ReturnRequest) GetItems() []*ReturnItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ReturnRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
 type GetAvailabilityRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TicketClassId string                 `protobuf:"bytes,1,opt,name=ticket_class_id,json=ticketClassId,proto3" json:"ticket_class_id,omitempty"`
@@ -847,7 +959,7 @@ type GetAvailabilityRequest struct {
 
 func (x *GetAvailabilityRequest) Reset() {
 	*x = GetAvailabilityRequest{}
-	mi := &file_inventory_proto_msgTypes[14]
+	mi := &file_inventory_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -859,7 +971,7 @@ func (x *GetAvailabilityRequest) String() string {
 func (*GetAvailabilityRequest) ProtoMessage() {}
 
 func (x *GetAvailabilityRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_proto_msgTypes[14]
+	mi := &file_inventory_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -872,7 +984,7 @@ func (x *GetAvailabilityRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetAvailabilityRequest.ProtoReflect.Descriptor instead.
 func (*GetAvailabilityRequest) Descriptor() ([]byte, []int) {
-	return file_inventory_proto_rawDescGZIP(), []int{14}
+	return file_inventory_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *GetAvailabilityRequest) GetTicketClassId() string {
@@ -891,7 +1003,7 @@ type GetAvailabilityResponse struct {
 
 func (x *GetAvailabilityResponse) Reset() {
 	*x = GetAvailabilityResponse{}
-	mi := &file_inventory_proto_msgTypes[15]
+	mi := &file_inventory_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -903,7 +1015,7 @@ func (x *GetAvailabilityResponse) String() string {
 func (*GetAvailabilityResponse) ProtoMessage() {}
 
 func (x *GetAvailabilityResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_proto_msgTypes[15]
+	mi := &file_inventory_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -916,7 +1028,7 @@ func (x *GetAvailabilityResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetAvailabilityResponse.ProtoReflect.Descriptor instead.
 func (*GetAvailabilityResponse) Descriptor() ([]byte, []int) {
-	return file_inventory_proto_rawDescGZIP(), []int{15}
+	return file_inventory_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *GetAvailabilityResponse) GetAvailableQuantity() int32 {
@@ -936,7 +1048,7 @@ type CheckAvailabilityItem struct {
 
 func (x *CheckAvailabilityItem) Reset() {
 	*x = CheckAvailabilityItem{}
-	mi := &file_inventory_proto_msgTypes[16]
+	mi := &file_inventory_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -948,7 +1060,7 @@ func (x *CheckAvailabilityItem) String() string {
 func (*CheckAvailabilityItem) ProtoMessage() {}
 
 func (x *CheckAvailabilityItem) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_proto_msgTypes[16]
+	mi := &file_inventory_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -961,7 +1073,7 @@ func (x *CheckAvailabilityItem) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CheckAvailabilityItem.ProtoReflect.Descriptor instead.
 func (*CheckAvailabilityItem) Descriptor() ([]byte, []int) {
-	return file_inventory_proto_rawDescGZIP(), []int{16}
+	return file_inventory_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *CheckAvailabilityItem) GetTicketClassId() string {
@@ -987,7 +1099,7 @@ type CheckAvailabilityRequest struct {
 
 func (x *CheckAvailabilityRequest) Reset() {
 	*x = CheckAvailabilityRequest{}
-	mi := &file_inventory_proto_msgTypes[17]
+	mi := &file_inventory_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -999,7 +1111,7 @@ func (x *CheckAvailabilityRequest) String() string {
 func (*CheckAvailabilityRequest) ProtoMessage() {}
 
 func (x *CheckAvailabilityRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_proto_msgTypes[17]
+	mi := &file_inventory_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1012,7 +1124,7 @@ func (x *CheckAvailabilityRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CheckAvailabilityRequest.ProtoReflect.Descriptor instead.
 func (*CheckAvailabilityRequest) Descriptor() ([]byte, []int) {
-	return file_inventory_proto_rawDescGZIP(), []int{17}
+	return file_inventory_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *CheckAvailabilityRequest) GetItems() []*CheckAvailabilityItem {
@@ -1031,7 +1143,7 @@ type CheckAvailabilityResponse struct {
 
 func (x *CheckAvailabilityResponse) Reset() {
 	*x = CheckAvailabilityResponse{}
-	mi := &file_inventory_proto_msgTypes[18]
+	mi := &file_inventory_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1043,7 +1155,7 @@ func (x *CheckAvailabilityResponse) String() string {
 func (*CheckAvailabilityResponse) ProtoMessage() {}
 
 func (x *CheckAvailabilityResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_proto_msgTypes[18]
+	mi := &file_inventory_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1056,7 +1168,7 @@ func (x *CheckAvailabilityResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CheckAvailabilityResponse.ProtoReflect.Descriptor instead.
 func (*CheckAvailabilityResponse) Descriptor() ([]byte, []int) {
-	return file_inventory_proto_rawDescGZIP(), []int{18}
+	return file_inventory_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *CheckAvailabilityResponse) GetAccept() bool {
@@ -1133,7 +1245,16 @@ const file_inventory_proto_rawDesc = "" +
 	"order_code\x18\x01 \x01(\tR\torderCode\"/\n" +
 	"\x0eReleaseRequest\x12\x1d\n" +
 	"\n" +
-	"order_code\x18\x01 \x01(\tR\torderCode\"@\n" +
+	"order_code\x18\x01 \x01(\tR\torderCode\"P\n" +
+	"\n" +
+	"ReturnItem\x12&\n" +
+	"\x0fticket_class_id\x18\x01 \x01(\tR\rticketClassId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\"\x80\x01\n" +
+	"\rReturnRequest\x12\x1d\n" +
+	"\n" +
+	"order_code\x18\x01 \x01(\tR\torderCode\x12'\n" +
+	"\x05items\x18\x02 \x03(\v2\x11.event.ReturnItemR\x05items\x12'\n" +
+	"\x0fidempotency_key\x18\x03 \x01(\tR\x0eidempotencyKey\"@\n" +
 	"\x16GetAvailabilityRequest\x12&\n" +
 	"\x0fticket_class_id\x18\x01 \x01(\tR\rticketClassId\"H\n" +
 	"\x17GetAvailabilityResponse\x12-\n" +
@@ -1144,7 +1265,7 @@ const file_inventory_proto_rawDesc = "" +
 	"\x18CheckAvailabilityRequest\x122\n" +
 	"\x05items\x18\x01 \x03(\v2\x1c.event.CheckAvailabilityItemR\x05items\"3\n" +
 	"\x19CheckAvailabilityResponse\x12\x16\n" +
-	"\x06accept\x18\x01 \x01(\bR\x06accept2\xa1\x06\n" +
+	"\x06accept\x18\x01 \x01(\bR\x06accept2\xd9\x06\n" +
 	"\x10InventoryService\x12V\n" +
 	"\x11CreateTicketClass\x12\x1f.event.CreateTicketClassRequest\x1a .event.CreateTicketClassResponse\x12V\n" +
 	"\x11UpdateTicketClass\x12\x1f.event.UpdateTicketClassRequest\x1a .event.UpdateTicketClassResponse\x12Y\n" +
@@ -1155,7 +1276,8 @@ const file_inventory_proto_rawDesc = "" +
 	"\x0fGetAvailability\x12\x1d.event.GetAvailabilityRequest\x1a\x1e.event.GetAvailabilityResponse\x128\n" +
 	"\aReserve\x12\x15.event.ReserveRequest\x1a\x16.google.protobuf.Empty\x128\n" +
 	"\aConfirm\x12\x15.event.ConfirmRequest\x1a\x16.google.protobuf.Empty\x128\n" +
-	"\aRelease\x12\x15.event.ReleaseRequest\x1a\x16.google.protobuf.EmptyB;Z9github.com/vogiaan1904/ticketbottle-proto/proto/inventoryb\x06proto3"
+	"\aRelease\x12\x15.event.ReleaseRequest\x1a\x16.google.protobuf.Empty\x126\n" +
+	"\x06Return\x12\x14.event.ReturnRequest\x1a\x16.google.protobuf.EmptyB;Z9github.com/vogiaan1904/ticketbottle-proto/proto/inventoryb\x06proto3"
 
 var (
 	file_inventory_proto_rawDescOnce sync.Once
@@ -1169,7 +1291,7 @@ func file_inventory_proto_rawDescGZIP() []byte {
 	return file_inventory_proto_rawDescData
 }
 
-var file_inventory_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_inventory_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
 var file_inventory_proto_goTypes = []any{
 	(*TicketClass)(nil),                 // 0: event.TicketClass
 	(*CreateTicketClassRequest)(nil),    // 1: event.CreateTicketClassRequest
@@ -1185,12 +1307,14 @@ var file_inventory_proto_goTypes = []any{
 	(*ReserveRequest)(nil),              // 11: event.ReserveRequest
 	(*ConfirmRequest)(nil),              // 12: event.ConfirmRequest
 	(*ReleaseRequest)(nil),              // 13: event.ReleaseRequest
-	(*GetAvailabilityRequest)(nil),      // 14: event.GetAvailabilityRequest
-	(*GetAvailabilityResponse)(nil),     // 15: event.GetAvailabilityResponse
-	(*CheckAvailabilityItem)(nil),       // 16: event.CheckAvailabilityItem
-	(*CheckAvailabilityRequest)(nil),    // 17: event.CheckAvailabilityRequest
-	(*CheckAvailabilityResponse)(nil),   // 18: event.CheckAvailabilityResponse
-	(*emptypb.Empty)(nil),               // 19: google.protobuf.Empty
+	(*ReturnItem)(nil),                  // 14: event.ReturnItem
+	(*ReturnRequest)(nil),               // 15: event.ReturnRequest
+	(*GetAvailabilityRequest)(nil),      // 16: event.GetAvailabilityRequest
+	(*GetAvailabilityResponse)(nil),     // 17: event.GetAvailabilityResponse
+	(*CheckAvailabilityItem)(nil),       // 18: event.CheckAvailabilityItem
+	(*CheckAvailabilityRequest)(nil),    // 19: event.CheckAvailabilityRequest
+	(*CheckAvailabilityResponse)(nil),   // 20: event.CheckAvailabilityResponse
+	(*emptypb.Empty)(nil),               // 21: google.protobuf.Empty
 }
 var file_inventory_proto_depIdxs = []int32{
 	0,  // 0: event.CreateTicketClassResponse.ticket_class:type_name -> event.TicketClass
@@ -1198,32 +1322,35 @@ var file_inventory_proto_depIdxs = []int32{
 	0,  // 2: event.FindOneTicketClassResponse.ticket_class:type_name -> event.TicketClass
 	0,  // 3: event.FindManyTicketClassResponse.ticket_classes:type_name -> event.TicketClass
 	10, // 4: event.ReserveRequest.items:type_name -> event.ReserveItem
-	16, // 5: event.CheckAvailabilityRequest.items:type_name -> event.CheckAvailabilityItem
-	1,  // 6: event.InventoryService.CreateTicketClass:input_type -> event.CreateTicketClassRequest
-	3,  // 7: event.InventoryService.UpdateTicketClass:input_type -> event.UpdateTicketClassRequest
-	5,  // 8: event.InventoryService.FindOneTicketClass:input_type -> event.FindOneTicketClassRequest
-	7,  // 9: event.InventoryService.FindManyTicketClass:input_type -> event.FindManyTicketClassRequest
-	9,  // 10: event.InventoryService.DeleteTicketClass:input_type -> event.DeleteTicketClassRequest
-	17, // 11: event.InventoryService.CheckAvailability:input_type -> event.CheckAvailabilityRequest
-	14, // 12: event.InventoryService.GetAvailability:input_type -> event.GetAvailabilityRequest
-	11, // 13: event.InventoryService.Reserve:input_type -> event.ReserveRequest
-	12, // 14: event.InventoryService.Confirm:input_type -> event.ConfirmRequest
-	13, // 15: event.InventoryService.Release:input_type -> event.ReleaseRequest
-	2,  // 16: event.InventoryService.CreateTicketClass:output_type -> event.CreateTicketClassResponse
-	4,  // 17: event.InventoryService.UpdateTicketClass:output_type -> event.UpdateTicketClassResponse
-	6,  // 18: event.InventoryService.FindOneTicketClass:output_type -> event.FindOneTicketClassResponse
-	8,  // 19: event.InventoryService.FindManyTicketClass:output_type -> event.FindManyTicketClassResponse
-	19, // 20: event.InventoryService.DeleteTicketClass:output_type -> google.protobuf.Empty
-	18, // 21: event.InventoryService.CheckAvailability:output_type -> event.CheckAvailabilityResponse
-	15, // 22: event.InventoryService.GetAvailability:output_type -> event.GetAvailabilityResponse
-	19, // 23: event.InventoryService.Reserve:output_type -> google.protobuf.Empty
-	19, // 24: event.InventoryService.Confirm:output_type -> google.protobuf.Empty
-	19, // 25: event.InventoryService.Release:output_type -> google.protobuf.Empty
-	16, // [16:26] is the sub-list for method output_type
-	6,  // [6:16] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	14, // 5: event.ReturnRequest.items:type_name -> event.ReturnItem
+	18, // 6: event.CheckAvailabilityRequest.items:type_name -> event.CheckAvailabilityItem
+	1,  // 7: event.InventoryService.CreateTicketClass:input_type -> event.CreateTicketClassRequest
+	3,  // 8: event.InventoryService.UpdateTicketClass:input_type -> event.UpdateTicketClassRequest
+	5,  // 9: event.InventoryService.FindOneTicketClass:input_type -> event.FindOneTicketClassRequest
+	7,  // 10: event.InventoryService.FindManyTicketClass:input_type -> event.FindManyTicketClassRequest
+	9,  // 11: event.InventoryService.DeleteTicketClass:input_type -> event.DeleteTicketClassRequest
+	19, // 12: event.InventoryService.CheckAvailability:input_type -> event.CheckAvailabilityRequest
+	16, // 13: event.InventoryService.GetAvailability:input_type -> event.GetAvailabilityRequest
+	11, // 14: event.InventoryService.Reserve:input_type -> event.ReserveRequest
+	12, // 15: event.InventoryService.Confirm:input_type -> event.ConfirmRequest
+	13, // 16: event.InventoryService.Release:input_type -> event.ReleaseRequest
+	15, // 17: event.InventoryService.Return:input_type -> event.ReturnRequest
+	2,  // 18: event.InventoryService.CreateTicketClass:output_type -> event.CreateTicketClassResponse
+	4,  // 19: event.InventoryService.UpdateTicketClass:output_type -> event.UpdateTicketClassResponse
+	6,  // 20: event.InventoryService.FindOneTicketClass:output_type -> event.FindOneTicketClassResponse
+	8,  // 21: event.InventoryService.FindManyTicketClass:output_type -> event.FindManyTicketClassResponse
+	21, // 22: event.InventoryService.DeleteTicketClass:output_type -> google.protobuf.Empty
+	20, // 23: event.InventoryService.CheckAvailability:output_type -> event.CheckAvailabilityResponse
+	17, // 24: event.InventoryService.GetAvailability:output_type -> event.GetAvailabilityResponse
+	21, // 25: event.InventoryService.Reserve:output_type -> google.protobuf.Empty
+	21, // 26: event.InventoryService.Confirm:output_type -> google.protobuf.Empty
+	21, // 27: event.InventoryService.Release:output_type -> google.protobuf.Empty
+	21, // 28: event.InventoryService.Return:output_type -> google.protobuf.Empty
+	18, // [18:29] is the sub-list for method output_type
+	7,  // [7:18] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_inventory_proto_init() }
@@ -1237,7 +1364,7 @@ func file_inventory_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_inventory_proto_rawDesc), len(file_inventory_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   19,
+			NumMessages:   21,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -22,14 +22,180 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type OrderActorType int32
+
+const (
+	OrderActorType_ORDER_ACTOR_TYPE_UNSPECIFIED OrderActorType = 0
+	OrderActorType_ORDER_ACTOR_TYPE_USER        OrderActorType = 1
+	OrderActorType_ORDER_ACTOR_TYPE_OPERATOR    OrderActorType = 2
+	OrderActorType_ORDER_ACTOR_TYPE_SYSTEM      OrderActorType = 3
+)
+
+// Enum value maps for OrderActorType.
+var (
+	OrderActorType_name = map[int32]string{
+		0: "ORDER_ACTOR_TYPE_UNSPECIFIED",
+		1: "ORDER_ACTOR_TYPE_USER",
+		2: "ORDER_ACTOR_TYPE_OPERATOR",
+		3: "ORDER_ACTOR_TYPE_SYSTEM",
+	}
+	OrderActorType_value = map[string]int32{
+		"ORDER_ACTOR_TYPE_UNSPECIFIED": 0,
+		"ORDER_ACTOR_TYPE_USER":        1,
+		"ORDER_ACTOR_TYPE_OPERATOR":    2,
+		"ORDER_ACTOR_TYPE_SYSTEM":      3,
+	}
+)
+
+func (x OrderActorType) Enum() *OrderActorType {
+	p := new(OrderActorType)
+	*p = x
+	return p
+}
+
+func (x OrderActorType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrderActorType) Descriptor() protoreflect.EnumDescriptor {
+	return file_order_proto_enumTypes[0].Descriptor()
+}
+
+func (OrderActorType) Type() protoreflect.EnumType {
+	return &file_order_proto_enumTypes[0]
+}
+
+func (x OrderActorType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrderActorType.Descriptor instead.
+func (OrderActorType) EnumDescriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{0}
+}
+
+type OrderChangeSource int32
+
+const (
+	OrderChangeSource_ORDER_CHANGE_SOURCE_UNSPECIFIED OrderChangeSource = 0
+	OrderChangeSource_ORDER_CHANGE_SOURCE_GRPC        OrderChangeSource = 1
+	OrderChangeSource_ORDER_CHANGE_SOURCE_KAFKA       OrderChangeSource = 2
+	OrderChangeSource_ORDER_CHANGE_SOURCE_WORKFLOW    OrderChangeSource = 3
+)
+
+// Enum value maps for OrderChangeSource.
+var (
+	OrderChangeSource_name = map[int32]string{
+		0: "ORDER_CHANGE_SOURCE_UNSPECIFIED",
+		1: "ORDER_CHANGE_SOURCE_GRPC",
+		2: "ORDER_CHANGE_SOURCE_KAFKA",
+		3: "ORDER_CHANGE_SOURCE_WORKFLOW",
+	}
+	OrderChangeSource_value = map[string]int32{
+		"ORDER_CHANGE_SOURCE_UNSPECIFIED": 0,
+		"ORDER_CHANGE_SOURCE_GRPC":        1,
+		"ORDER_CHANGE_SOURCE_KAFKA":       2,
+		"ORDER_CHANGE_SOURCE_WORKFLOW":    3,
+	}
+)
+
+func (x OrderChangeSource) Enum() *OrderChangeSource {
+	p := new(OrderChangeSource)
+	*p = x
+	return p
+}
+
+func (x OrderChangeSource) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrderChangeSource) Descriptor() protoreflect.EnumDescriptor {
+	return file_order_proto_enumTypes[1].Descriptor()
+}
+
+func (OrderChangeSource) Type() protoreflect.EnumType {
+	return &file_order_proto_enumTypes[1]
+}
+
+func (x OrderChangeSource) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrderChangeSource.Descriptor instead.
+func (OrderChangeSource) EnumDescriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{1}
+}
+
+type CheckoutState int32
+
+const (
+	CheckoutState_CHECKOUT_STATE_UNSPECIFIED        CheckoutState = 0
+	CheckoutState_CHECKOUT_STATE_CREATING           CheckoutState = 1
+	CheckoutState_CHECKOUT_STATE_AWAITING_PAYMENT   CheckoutState = 2
+	CheckoutState_CHECKOUT_STATE_PROCESSING_PAYMENT CheckoutState = 3
+	CheckoutState_CHECKOUT_STATE_COMPLETED          CheckoutState = 4
+	CheckoutState_CHECKOUT_STATE_FAILED             CheckoutState = 5
+)
+
+// Enum value maps for CheckoutState.
+var (
+	CheckoutState_name = map[int32]string{
+		0: "CHECKOUT_STATE_UNSPECIFIED",
+		1: "CHECKOUT_STATE_CREATING",
+		2: "CHECKOUT_STATE_AWAITING_PAYMENT",
+		3: "CHECKOUT_STATE_PROCESSING_PAYMENT",
+		4: "CHECKOUT_STATE_COMPLETED",
+		5: "CHECKOUT_STATE_FAILED",
+	}
+	CheckoutState_value = map[string]int32{
+		"CHECKOUT_STATE_UNSPECIFIED":        0,
+		"CHECKOUT_STATE_CREATING":           1,
+		"CHECKOUT_STATE_AWAITING_PAYMENT":   2,
+		"CHECKOUT_STATE_PROCESSING_PAYMENT": 3,
+		"CHECKOUT_STATE_COMPLETED":          4,
+		"CHECKOUT_STATE_FAILED":             5,
+	}
+)
+
+func (x CheckoutState) Enum() *CheckoutState {
+	p := new(CheckoutState)
+	*p = x
+	return p
+}
+
+func (x CheckoutState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CheckoutState) Descriptor() protoreflect.EnumDescriptor {
+	return file_order_proto_enumTypes[2].Descriptor()
+}
+
+func (CheckoutState) Type() protoreflect.EnumType {
+	return &file_order_proto_enumTypes[2]
+}
+
+func (x CheckoutState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CheckoutState.Descriptor instead.
+func (CheckoutState) EnumDescriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{2}
+}
+
 type OrderStatus int32
 
 const (
-	OrderStatus_ORDER_STATUS_UNSPECIFIED OrderStatus = 0
-	OrderStatus_ORDER_STATUS_PENDING     OrderStatus = 1
-	OrderStatus_ORDER_STATUS_COMPLETED   OrderStatus = 2
-	OrderStatus_ORDER_STATUS_CANCELED    OrderStatus = 3
-	OrderStatus_ORDER_STATUS_FAILED      OrderStatus = 4
+	OrderStatus_ORDER_STATUS_UNSPECIFIED        OrderStatus = 0
+	OrderStatus_ORDER_STATUS_PENDING            OrderStatus = 1
+	OrderStatus_ORDER_STATUS_COMPLETED          OrderStatus = 2
+	OrderStatus_ORDER_STATUS_CANCELED           OrderStatus = 3
+	OrderStatus_ORDER_STATUS_FAILED             OrderStatus = 4
+	OrderStatus_ORDER_STATUS_REFUNDED           OrderStatus = 5
+	OrderStatus_ORDER_STATUS_PARTIALLY_REFUNDED OrderStatus = 6
+	OrderStatus_ORDER_STATUS_PAYMENT_REVIEW     OrderStatus = 7
+	OrderStatus_ORDER_STATUS_TIMEOUT            OrderStatus = 8
 )
 
 // Enum value maps for OrderStatus.
@@ -40,13 +206,21 @@ var (
 		2: "ORDER_STATUS_COMPLETED",
 		3: "ORDER_STATUS_CANCELED",
 		4: "ORDER_STATUS_FAILED",
+		5: "ORDER_STATUS_REFUNDED",
+		6: "ORDER_STATUS_PARTIALLY_REFUNDED",
+		7: "ORDER_STATUS_PAYMENT_REVIEW",
+		8: "ORDER_STATUS_TIMEOUT",
 	}
 	OrderStatus_value = map[string]int32{
-		"ORDER_STATUS_UNSPECIFIED": 0,
-		"ORDER_STATUS_PENDING":     1,
-		"ORDER_STATUS_COMPLETED":   2,
-		"ORDER_STATUS_CANCELED":    3,
-		"ORDER_STATUS_FAILED":      4,
+		"ORDER_STATUS_UNSPECIFIED":        0,
+		"ORDER_STATUS_PENDING":            1,
+		"ORDER_STATUS_COMPLETED":          2,
+		"ORDER_STATUS_CANCELED":           3,
+		"ORDER_STATUS_FAILED":             4,
+		"ORDER_STATUS_REFUNDED":           5,
+		"ORDER_STATUS_PARTIALLY_REFUNDED": 6,
+		"ORDER_STATUS_PAYMENT_REVIEW":     7,
+		"ORDER_STATUS_TIMEOUT":            8,
 	}
 )
 
@@ -61,11 +235,11 @@ func (x OrderStatus) String() string {
 }
 
 func (OrderStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_order_proto_enumTypes[0].Descriptor()
+	return file_order_proto_enumTypes[3].Descriptor()
 }
 
 func (OrderStatus) Type() protoreflect.EnumType {
-	return &file_order_proto_enumTypes[0]
+	return &file_order_proto_enumTypes[3]
 }
 
 func (x OrderStatus) Number() protoreflect.EnumNumber {
@@ -74,27 +248,34 @@ func (x OrderStatus) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use OrderStatus.Descriptor instead.
 func (OrderStatus) EnumDescriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{0}
+	return file_order_proto_rawDescGZIP(), []int{3}
 }
 
 type Order struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	Id               string                 `protobuf:"bytes,10,opt,name=id,proto3" json:"id,omitempty"`
-	Code             string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
-	EventId          string                 `protobuf:"bytes,2,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
-	UserId           string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	UserFullname     string                 `protobuf:"bytes,12,opt,name=user_fullname,json=userFullname,proto3" json:"user_fullname,omitempty"`
-	UserEmail        string                 `protobuf:"bytes,13,opt,name=user_email,json=userEmail,proto3" json:"user_email,omitempty"`
-	UserPhone        string                 `protobuf:"bytes,14,opt,name=user_phone,json=userPhone,proto3" json:"user_phone,omitempty"`
-	TotalAmountCents int64                  `protobuf:"varint,4,opt,name=total_amount_cents,json=totalAmountCents,proto3" json:"total_amount_cents,omitempty"`
-	Currency         string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
-	Status           OrderStatus            `protobuf:"varint,6,opt,name=status,proto3,enum=order.OrderStatus" json:"status,omitempty"`
-	PaymentMethod    string                 `protobuf:"bytes,15,opt,name=payment_method,json=paymentMethod,proto3" json:"payment_method,omitempty"`
-	Items            []*OrderItem           `protobuf:"bytes,7,rep,name=items,proto3" json:"items,omitempty"`
-	CreatedAt        string                 `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt        string                 `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Id                  string                 `protobuf:"bytes,10,opt,name=id,proto3" json:"id,omitempty"`
+	Code                string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	EventId             string                 `protobuf:"bytes,2,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	UserId              string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UserFullname        string                 `protobuf:"bytes,12,opt,name=user_fullname,json=userFullname,proto3" json:"user_fullname,omitempty"`
+	UserEmail           string                 `protobuf:"bytes,13,opt,name=user_email,json=userEmail,proto3" json:"user_email,omitempty"`
+	UserPhone           string                 `protobuf:"bytes,14,opt,name=user_phone,json=userPhone,proto3" json:"user_phone,omitempty"`
+	TotalAmountCents    int64                  `protobuf:"varint,4,opt,name=total_amount_cents,json=totalAmountCents,proto3" json:"total_amount_cents,omitempty"`
+	Currency            string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	Status              OrderStatus            `protobuf:"varint,6,opt,name=status,proto3,enum=order.OrderStatus" json:"status,omitempty"`
+	PaymentMethod       string                 `protobuf:"bytes,15,opt,name=payment_method,json=paymentMethod,proto3" json:"payment_method,omitempty"`
+	Items               []*OrderItem           `protobuf:"bytes,7,rep,name=items,proto3" json:"items,omitempty"`
+	CreatedAt           string                 `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt           string                 `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	RefundedAmountCents int64                  `protobuf:"varint,16,opt,name=refunded_amount_cents,json=refundedAmountCents,proto3" json:"refunded_amount_cents,omitempty"`
+	RefundReason        string                 `protobuf:"bytes,17,opt,name=refund_reason,json=refundReason,proto3" json:"refund_reason,omitempty"`
+	NetAmountCents      int64                  `protobuf:"varint,18,opt,name=net_amount_cents,json=netAmountCents,proto3" json:"net_amount_cents,omitempty"`
+	FailureReason       string                 `protobuf:"bytes,19,opt,name=failure_reason,json=failureReason,proto3" json:"failure_reason,omitempty"`
+	PaidAt              string                 `protobuf:"bytes,20,opt,name=paid_at,json=paidAt,proto3" json:"paid_at,omitempty"`
+	PaymentId           string                 `protobuf:"bytes,21,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	TransactionId       string                 `protobuf:"bytes,22,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
 func (x *Order) Reset() {
@@ -225,13 +406,63 @@ func (x *Order) GetUpdatedAt() string {
 	return ""
 }
 
+func (x *Order) GetRefundedAmountCents() int64 {
+	if x != nil {
+		return x.RefundedAmountCents
+	}
+	return 0
+}
+
+func (x *Order) GetRefundReason() string {
+	if x != nil {
+		return x.RefundReason
+	}
+	return ""
+}
+
+func (x *Order) GetNetAmountCents() int64 {
+	if x != nil {
+		return x.NetAmountCents
+	}
+	return 0
+}
+
+func (x *Order) GetFailureReason() string {
+	if x != nil {
+		return x.FailureReason
+	}
+	return ""
+}
+
+func (x *Order) GetPaidAt() string {
+	if x != nil {
+		return x.PaidAt
+	}
+	return ""
+}
+
+func (x *Order) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *Order) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
 type OrderItem struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TicketClassId string                 `protobuf:"bytes,1,opt,name=ticket_class_id,json=ticketClassId,proto3" json:"ticket_class_id,omitempty"`
-	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	PriceCents    int64                  `protobuf:"varint,3,opt,name=price_cents,json=priceCents,proto3" json:"price_cents,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	TicketClassId    string                 `protobuf:"bytes,1,opt,name=ticket_class_id,json=ticketClassId,proto3" json:"ticket_class_id,omitempty"`
+	Quantity         int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	PriceCents       int64                  `protobuf:"varint,3,opt,name=price_cents,json=priceCents,proto3" json:"price_cents,omitempty"`
+	RefundedQuantity int32                  `protobuf:"varint,4,opt,name=refunded_quantity,json=refundedQuantity,proto3" json:"refunded_quantity,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *OrderItem) Reset() {
@@ -285,6 +516,13 @@ func (x *OrderItem) GetPriceCents() int64 {
 	return 0
 }
 
+func (x *OrderItem) GetRefundedQuantity() int32 {
+	if x != nil {
+		return x.RefundedQuantity
+	}
+	return 0
+}
+
 type CreateOrderItem struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TicketClassId string                 `protobuf:"bytes,1,opt,name=ticket_class_id,json=ticketClassId,proto3" json:"ticket_class_id,omitempty"`
@@ -349,6 +587,7 @@ type CreateOrderRequest struct {
 	Currency      string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
 	CheckoutToken string                 `protobuf:"bytes,9,opt,name=checkout_token,json=checkoutToken,proto3" json:"checkout_token,omitempty"`
 	RedirectUrl   string                 `protobuf:"bytes,10,opt,name=redirect_url,json=redirectUrl,proto3" json:"redirect_url,omitempty"`
+	Async         bool                   `protobuf:"varint,11,opt,name=async,proto3" json:"async,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -453,10 +692,19 @@ func (x *CreateOrderRequest) GetRedirectUrl() string {
 	return ""
 }
 
+func (x *CreateOrderRequest) GetAsync() bool {
+	if x != nil {
+		return x.Async
+	}
+	return false
+}
+
 type CreateOrderResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
 	PaymentUrl    string                 `protobuf:"bytes,2,opt,name=payment_url,json=paymentUrl,proto3" json:"payment_url,omitempty"`
+	OrderCode     string                 `protobuf:"bytes,3,opt,name=order_code,json=orderCode,proto3" json:"order_code,omitempty"`
+	CheckoutState CheckoutState          `protobuf:"varint,4,opt,name=checkout_state,json=checkoutState,proto3,enum=order.CheckoutState" json:"checkout_state,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -505,33 +753,44 @@ func (x *CreateOrderResponse) GetPaymentUrl() string {
 	return ""
 }
 
-type PaginationInfo struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
-	PageSize      int64                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	Count         int64                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
-	LastPage      int32                  `protobuf:"varint,4,opt,name=last_page,json=lastPage,proto3" json:"last_page,omitempty"`
-	Total         int64                  `protobuf:"varint,7,opt,name=total,proto3" json:"total,omitempty"`
-	HasNext       bool                   `protobuf:"varint,5,opt,name=has_next,json=hasNext,proto3" json:"has_next,omitempty"`
-	HasPrevious   bool                   `protobuf:"varint,6,opt,name=has_previous,json=hasPrevious,proto3" json:"has_previous,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *CreateOrderResponse) GetOrderCode() string {
+	if x != nil {
+		return x.OrderCode
+	}
+	return ""
 }
 
-func (x *PaginationInfo) Reset() {
-	*x = PaginationInfo{}
+func (x *CreateOrderResponse) GetCheckoutState() CheckoutState {
+	if x != nil {
+		return x.CheckoutState
+	}
+	return CheckoutState_CHECKOUT_STATE_UNSPECIFIED
+}
+
+type CheckoutProgress struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	AvailabilityChecked bool                   `protobuf:"varint,1,opt,name=availability_checked,json=availabilityChecked,proto3" json:"availability_checked,omitempty"`
+	OrderPersisted      bool                   `protobuf:"varint,2,opt,name=order_persisted,json=orderPersisted,proto3" json:"order_persisted,omitempty"`
+	Reserved            bool                   `protobuf:"varint,3,opt,name=reserved,proto3" json:"reserved,omitempty"`
+	PaymentUrlReady     bool                   `protobuf:"varint,4,opt,name=payment_url_ready,json=paymentUrlReady,proto3" json:"payment_url_ready,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *CheckoutProgress) Reset() {
+	*x = CheckoutProgress{}
 	mi := &file_order_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PaginationInfo) String() string {
+func (x *CheckoutProgress) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PaginationInfo) ProtoMessage() {}
+func (*CheckoutProgress) ProtoMessage() {}
 
-func (x *PaginationInfo) ProtoReflect() protoreflect.Message {
+func (x *CheckoutProgress) ProtoReflect() protoreflect.Message {
 	mi := &file_order_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -543,83 +802,60 @@ func (x *PaginationInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PaginationInfo.ProtoReflect.Descriptor instead.
-func (*PaginationInfo) Descriptor() ([]byte, []int) {
+// Deprecated: Use CheckoutProgress.ProtoReflect.Descriptor instead.
+func (*CheckoutProgress) Descriptor() ([]byte, []int) {
 	return file_order_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *PaginationInfo) GetPage() int32 {
-	if x != nil {
-		return x.Page
-	}
-	return 0
-}
-
-func (x *PaginationInfo) GetPageSize() int64 {
-	if x != nil {
-		return x.PageSize
-	}
-	return 0
-}
-
-func (x *PaginationInfo) GetCount() int64 {
-	if x != nil {
-		return x.Count
-	}
-	return 0
-}
-
-func (x *PaginationInfo) GetLastPage() int32 {
+func (x *CheckoutProgress) GetAvailabilityChecked() bool {
 	if x != nil {
-		return x.LastPage
+		return x.AvailabilityChecked
 	}
-	return 0
+	return false
 }
 
-func (x *PaginationInfo) GetTotal() int64 {
+func (x *CheckoutProgress) GetOrderPersisted() bool {
 	if x != nil {
-		return x.Total
+		return x.OrderPersisted
 	}
-	return 0
+	return false
 }
 
-func (x *PaginationInfo) GetHasNext() bool {
+func (x *CheckoutProgress) GetReserved() bool {
 	if x != nil {
-		return x.HasNext
+		return x.Reserved
 	}
 	return false
 }
 
-func (x *PaginationInfo) GetHasPrevious() bool {
+func (x *CheckoutProgress) GetPaymentUrlReady() bool {
 	if x != nil {
-		return x.HasPrevious
+		return x.PaymentUrlReady
 	}
 	return false
 }
 
-type GetManyOrdersRequest struct {
+type GetCheckoutStatusRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
-	PageSize      int64                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	Filter        *OrderFilter           `protobuf:"bytes,3,opt,name=filter,proto3,oneof" json:"filter,omitempty"`
+	OrderCode     string                 `protobuf:"bytes,1,opt,name=order_code,json=orderCode,proto3" json:"order_code,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetManyOrdersRequest) Reset() {
-	*x = GetManyOrdersRequest{}
+func (x *GetCheckoutStatusRequest) Reset() {
+	*x = GetCheckoutStatusRequest{}
 	mi := &file_order_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetManyOrdersRequest) String() string {
+func (x *GetCheckoutStatusRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetManyOrdersRequest) ProtoMessage() {}
+func (*GetCheckoutStatusRequest) ProtoMessage() {}
 
-func (x *GetManyOrdersRequest) ProtoReflect() protoreflect.Message {
+func (x *GetCheckoutStatusRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_order_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -631,55 +867,44 @@ func (x *GetManyOrdersRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetManyOrdersRequest.ProtoReflect.Descriptor instead.
-func (*GetManyOrdersRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetCheckoutStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetCheckoutStatusRequest) Descriptor() ([]byte, []int) {
 	return file_order_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *GetManyOrdersRequest) GetPage() int32 {
-	if x != nil {
-		return x.Page
-	}
-	return 0
-}
-
-func (x *GetManyOrdersRequest) GetPageSize() int64 {
-	if x != nil {
-		return x.PageSize
-	}
-	return 0
-}
-
-func (x *GetManyOrdersRequest) GetFilter() *OrderFilter {
+func (x *GetCheckoutStatusRequest) GetOrderCode() string {
 	if x != nil {
-		return x.Filter
+		return x.OrderCode
 	}
-	return nil
+	return ""
 }
 
-type OrderFilter struct {
+type GetCheckoutStatusResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        *string                `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3,oneof" json:"user_id,omitempty"`
-	EventId       *string                `protobuf:"bytes,2,opt,name=event_id,json=eventId,proto3,oneof" json:"event_id,omitempty"`
-	Status        *OrderStatus           `protobuf:"varint,3,opt,name=status,proto3,enum=order.OrderStatus,oneof" json:"status,omitempty"`
+	OrderCode     string                 `protobuf:"bytes,1,opt,name=order_code,json=orderCode,proto3" json:"order_code,omitempty"`
+	State         CheckoutState          `protobuf:"varint,2,opt,name=state,proto3,enum=order.CheckoutState" json:"state,omitempty"`
+	Progress      *CheckoutProgress      `protobuf:"bytes,3,opt,name=progress,proto3" json:"progress,omitempty"`
+	PaymentUrl    string                 `protobuf:"bytes,4,opt,name=payment_url,json=paymentUrl,proto3" json:"payment_url,omitempty"`
+	Order         *Order                 `protobuf:"bytes,5,opt,name=order,proto3" json:"order,omitempty"`
+	Error         string                 `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *OrderFilter) Reset() {
-	*x = OrderFilter{}
+func (x *GetCheckoutStatusResponse) Reset() {
+	*x = GetCheckoutStatusResponse{}
 	mi := &file_order_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OrderFilter) String() string {
+func (x *GetCheckoutStatusResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OrderFilter) ProtoMessage() {}
+func (*GetCheckoutStatusResponse) ProtoMessage() {}
 
-func (x *OrderFilter) ProtoReflect() protoreflect.Message {
+func (x *GetCheckoutStatusResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_order_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -691,55 +916,612 @@ func (x *OrderFilter) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OrderFilter.ProtoReflect.Descriptor instead.
-func (*OrderFilter) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetCheckoutStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetCheckoutStatusResponse) Descriptor() ([]byte, []int) {
 	return file_order_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *OrderFilter) GetUserId() string {
-	if x != nil && x.UserId != nil {
-		return *x.UserId
+func (x *GetCheckoutStatusResponse) GetOrderCode() string {
+	if x != nil {
+		return x.OrderCode
 	}
 	return ""
 }
 
-func (x *OrderFilter) GetEventId() string {
-	if x != nil && x.EventId != nil {
-		return *x.EventId
+func (x *GetCheckoutStatusResponse) GetState() CheckoutState {
+	if x != nil {
+		return x.State
 	}
-	return ""
+	return CheckoutState_CHECKOUT_STATE_UNSPECIFIED
 }
 
-func (x *OrderFilter) GetStatus() OrderStatus {
-	if x != nil && x.Status != nil {
-		return *x.Status
+func (x *GetCheckoutStatusResponse) GetProgress() *CheckoutProgress {
+	if x != nil {
+		return x.Progress
 	}
-	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+	return nil
 }
 
-type GetManyOrdersResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Orders        []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
-	Pagination    *PaginationInfo        `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
+func (x *GetCheckoutStatusResponse) GetPaymentUrl() string {
+	if x != nil {
+		return x.PaymentUrl
+	}
+	return ""
+}
+
+func (x *GetCheckoutStatusResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+func (x *GetCheckoutStatusResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type PaginationInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int64                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Count         int64                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	LastPage      int32                  `protobuf:"varint,4,opt,name=last_page,json=lastPage,proto3" json:"last_page,omitempty"`
+	Total         *int64                 `protobuf:"varint,7,opt,name=total,proto3,oneof" json:"total,omitempty"`
+	HasNext       bool                   `protobuf:"varint,5,opt,name=has_next,json=hasNext,proto3" json:"has_next,omitempty"`
+	HasPrevious   bool                   `protobuf:"varint,6,opt,name=has_previous,json=hasPrevious,proto3" json:"has_previous,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,8,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	PrevCursor    string                 `protobuf:"bytes,9,opt,name=prev_cursor,json=prevCursor,proto3" json:"prev_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PaginationInfo) Reset() {
+	*x = PaginationInfo{}
+	mi := &file_order_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PaginationInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaginationInfo) ProtoMessage() {}
+
+func (x *PaginationInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaginationInfo.ProtoReflect.Descriptor instead.
+func (*PaginationInfo) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PaginationInfo) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *PaginationInfo) GetPageSize() int64 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *PaginationInfo) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *PaginationInfo) GetLastPage() int32 {
+	if x != nil {
+		return x.LastPage
+	}
+	return 0
+}
+
+func (x *PaginationInfo) GetTotal() int64 {
+	if x != nil && x.Total != nil {
+		return *x.Total
+	}
+	return 0
+}
+
+func (x *PaginationInfo) GetHasNext() bool {
+	if x != nil {
+		return x.HasNext
+	}
+	return false
+}
+
+func (x *PaginationInfo) GetHasPrevious() bool {
+	if x != nil {
+		return x.HasPrevious
+	}
+	return false
+}
+
+func (x *PaginationInfo) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *PaginationInfo) GetPrevCursor() string {
+	if x != nil {
+		return x.PrevCursor
+	}
+	return ""
+}
+
+type GetManyOrdersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Deprecated: Marked as deprecated in order.proto.
+	Page          int32        `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int64        `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Filter        *OrderFilter `protobuf:"bytes,3,opt,name=filter,proto3,oneof" json:"filter,omitempty"`
+	Cursor        string       `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	IncludeTotal  bool         `protobuf:"varint,5,opt,name=include_total,json=includeTotal,proto3" json:"include_total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetManyOrdersRequest) Reset() {
+	*x = GetManyOrdersRequest{}
+	mi := &file_order_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetManyOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetManyOrdersRequest) ProtoMessage() {}
+
+func (x *GetManyOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetManyOrdersRequest.ProtoReflect.Descriptor instead.
+func (*GetManyOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{9}
+}
+
+// Deprecated: Marked as deprecated in order.proto.
+func (x *GetManyOrdersRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetManyOrdersRequest) GetPageSize() int64 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetManyOrdersRequest) GetFilter() *OrderFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *GetManyOrdersRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *GetManyOrdersRequest) GetIncludeTotal() bool {
+	if x != nil {
+		return x.IncludeTotal
+	}
+	return false
+}
+
+type OrderFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        *string                `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3,oneof" json:"user_id,omitempty"`
+	EventId       *string                `protobuf:"bytes,2,opt,name=event_id,json=eventId,proto3,oneof" json:"event_id,omitempty"`
+	Status        *OrderStatus           `protobuf:"varint,3,opt,name=status,proto3,enum=order.OrderStatus,oneof" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderFilter) Reset() {
+	*x = OrderFilter{}
+	mi := &file_order_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderFilter) ProtoMessage() {}
+
+func (x *OrderFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderFilter.ProtoReflect.Descriptor instead.
+func (*OrderFilter) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *OrderFilter) GetUserId() string {
+	if x != nil && x.UserId != nil {
+		return *x.UserId
+	}
+	return ""
+}
+
+func (x *OrderFilter) GetEventId() string {
+	if x != nil && x.EventId != nil {
+		return *x.EventId
+	}
+	return ""
+}
+
+func (x *OrderFilter) GetStatus() OrderStatus {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
+type GetManyOrdersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Orders        []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	Pagination    *PaginationInfo        `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetManyOrdersResponse) Reset() {
+	*x = GetManyOrdersResponse{}
+	mi := &file_order_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetManyOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetManyOrdersResponse) ProtoMessage() {}
+
+func (x *GetManyOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetManyOrdersResponse.ProtoReflect.Descriptor instead.
+func (*GetManyOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetManyOrdersResponse) GetOrders() []*Order {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+func (x *GetManyOrdersResponse) GetPagination() *PaginationInfo {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type GetOrderRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to FindOption:
+	//
+	//	*GetOrderRequest_Code
+	//	*GetOrderRequest_Id
+	FindOption    isGetOrderRequest_FindOption `protobuf_oneof:"find_option"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderRequest) Reset() {
+	*x = GetOrderRequest{}
+	mi := &file_order_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderRequest) ProtoMessage() {}
+
+func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetOrderRequest) GetFindOption() isGetOrderRequest_FindOption {
+	if x != nil {
+		return x.FindOption
+	}
+	return nil
+}
+
+func (x *GetOrderRequest) GetCode() string {
+	if x != nil {
+		if x, ok := x.FindOption.(*GetOrderRequest_Code); ok {
+			return x.Code
+		}
+	}
+	return ""
+}
+
+func (x *GetOrderRequest) GetId() string {
+	if x != nil {
+		if x, ok := x.FindOption.(*GetOrderRequest_Id); ok {
+			return x.Id
+		}
+	}
+	return ""
+}
+
+type isGetOrderRequest_FindOption interface {
+	isGetOrderRequest_FindOption()
+}
+
+type GetOrderRequest_Code struct {
+	Code string `protobuf:"bytes,1,opt,name=code,proto3,oneof"`
+}
+
+type GetOrderRequest_Id struct {
+	Id string `protobuf:"bytes,2,opt,name=id,proto3,oneof"`
+}
+
+func (*GetOrderRequest_Code) isGetOrderRequest_FindOption() {}
+
+func (*GetOrderRequest_Id) isGetOrderRequest_FindOption() {}
+
+type GetOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderResponse) Reset() {
+	*x = GetOrderResponse{}
+	mi := &file_order_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderResponse) ProtoMessage() {}
+
+func (x *GetOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderResponse.ProtoReflect.Descriptor instead.
+func (*GetOrderResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetOrderResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type ListOrdersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filter        *OrderFilter           `protobuf:"bytes,1,opt,name=filter,proto3,oneof" json:"filter,omitempty"`
+	Limit         int64                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor        string                 `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrdersRequest) Reset() {
+	*x = ListOrdersRequest{}
+	mi := &file_order_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrdersRequest) ProtoMessage() {}
+
+func (x *ListOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrdersRequest.ProtoReflect.Descriptor instead.
+func (*ListOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListOrdersRequest) GetFilter() *OrderFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ListOrdersRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListOrdersRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type ListOrdersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Orders        []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrdersResponse) Reset() {
+	*x = ListOrdersResponse{}
+	mi := &file_order_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrdersResponse) ProtoMessage() {}
+
+func (x *ListOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrdersResponse.ProtoReflect.Descriptor instead.
+func (*ListOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListOrdersResponse) GetOrders() []*Order {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+func (x *ListOrdersResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
 
-func (x *GetManyOrdersResponse) Reset() {
-	*x = GetManyOrdersResponse{}
-	mi := &file_order_proto_msgTypes[8]
+type CancelOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelOrderRequest) Reset() {
+	*x = CancelOrderRequest{}
+	mi := &file_order_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetManyOrdersResponse) String() string {
+func (x *CancelOrderRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetManyOrdersResponse) ProtoMessage() {}
+func (*CancelOrderRequest) ProtoMessage() {}
 
-func (x *GetManyOrdersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[8]
+func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -750,51 +1532,48 @@ func (x *GetManyOrdersResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetManyOrdersResponse.ProtoReflect.Descriptor instead.
-func (*GetManyOrdersResponse) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use CancelOrderRequest.ProtoReflect.Descriptor instead.
+func (*CancelOrderRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *GetManyOrdersResponse) GetOrders() []*Order {
+func (x *CancelOrderRequest) GetId() string {
 	if x != nil {
-		return x.Orders
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-func (x *GetManyOrdersResponse) GetPagination() *PaginationInfo {
+func (x *CancelOrderRequest) GetUserId() string {
 	if x != nil {
-		return x.Pagination
+		return x.UserId
 	}
-	return nil
+	return ""
 }
 
-type GetOrderRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Types that are valid to be assigned to FindOption:
-	//
-	//	*GetOrderRequest_Code
-	//	*GetOrderRequest_Id
-	FindOption    isGetOrderRequest_FindOption `protobuf_oneof:"find_option"`
+type RefundOrderItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TicketClassId string                 `protobuf:"bytes,1,opt,name=ticket_class_id,json=ticketClassId,proto3" json:"ticket_class_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetOrderRequest) Reset() {
-	*x = GetOrderRequest{}
-	mi := &file_order_proto_msgTypes[9]
+func (x *RefundOrderItem) Reset() {
+	*x = RefundOrderItem{}
+	mi := &file_order_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetOrderRequest) String() string {
+func (x *RefundOrderItem) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOrderRequest) ProtoMessage() {}
+func (*RefundOrderItem) ProtoMessage() {}
 
-func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[9]
+func (x *RefundOrderItem) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -805,74 +1584,115 @@ func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOrderRequest.ProtoReflect.Descriptor instead.
-func (*GetOrderRequest) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use RefundOrderItem.ProtoReflect.Descriptor instead.
+func (*RefundOrderItem) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *GetOrderRequest) GetFindOption() isGetOrderRequest_FindOption {
+func (x *RefundOrderItem) GetTicketClassId() string {
 	if x != nil {
-		return x.FindOption
+		return x.TicketClassId
 	}
-	return nil
+	return ""
 }
 
-func (x *GetOrderRequest) GetCode() string {
+func (x *RefundOrderItem) GetQuantity() int32 {
 	if x != nil {
-		if x, ok := x.FindOption.(*GetOrderRequest_Code); ok {
-			return x.Code
-		}
+		return x.Quantity
 	}
-	return ""
+	return 0
 }
 
-func (x *GetOrderRequest) GetId() string {
+type RefundOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	Items         []*RefundOrderItem     `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	Operator      string                 `protobuf:"bytes,4,opt,name=operator,proto3" json:"operator,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundOrderRequest) Reset() {
+	*x = RefundOrderRequest{}
+	mi := &file_order_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundOrderRequest) ProtoMessage() {}
+
+func (x *RefundOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[18]
 	if x != nil {
-		if x, ok := x.FindOption.(*GetOrderRequest_Id); ok {
-			return x.Id
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-type isGetOrderRequest_FindOption interface {
-	isGetOrderRequest_FindOption()
+// Deprecated: Use RefundOrderRequest.ProtoReflect.Descriptor instead.
+func (*RefundOrderRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{18}
 }
 
-type GetOrderRequest_Code struct {
-	Code string `protobuf:"bytes,1,opt,name=code,proto3,oneof"`
+func (x *RefundOrderRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
 }
 
-type GetOrderRequest_Id struct {
-	Id string `protobuf:"bytes,2,opt,name=id,proto3,oneof"`
+func (x *RefundOrderRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
 }
 
-func (*GetOrderRequest_Code) isGetOrderRequest_FindOption() {}
+func (x *RefundOrderRequest) GetItems() []*RefundOrderItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
 
-func (*GetOrderRequest_Id) isGetOrderRequest_FindOption() {}
+func (x *RefundOrderRequest) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
 
-type GetOrderResponse struct {
+type RefundOrderResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetOrderResponse) Reset() {
-	*x = GetOrderResponse{}
-	mi := &file_order_proto_msgTypes[10]
+func (x *RefundOrderResponse) Reset() {
+	*x = RefundOrderResponse{}
+	mi := &file_order_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetOrderResponse) String() string {
+func (x *RefundOrderResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOrderResponse) ProtoMessage() {}
+func (*RefundOrderResponse) ProtoMessage() {}
 
-func (x *GetOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[10]
+func (x *RefundOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -883,40 +1703,40 @@ func (x *GetOrderResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOrderResponse.ProtoReflect.Descriptor instead.
-func (*GetOrderResponse) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use RefundOrderResponse.ProtoReflect.Descriptor instead.
+func (*RefundOrderResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *GetOrderResponse) GetOrder() *Order {
+func (x *RefundOrderResponse) GetOrder() *Order {
 	if x != nil {
 		return x.Order
 	}
 	return nil
 }
 
-type ListOrdersRequest struct {
+type GetOrderHistoryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Filter        *OrderFilter           `protobuf:"bytes,1,opt,name=filter,proto3,oneof" json:"filter,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListOrdersRequest) Reset() {
-	*x = ListOrdersRequest{}
-	mi := &file_order_proto_msgTypes[11]
+func (x *GetOrderHistoryRequest) Reset() {
+	*x = GetOrderHistoryRequest{}
+	mi := &file_order_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListOrdersRequest) String() string {
+func (x *GetOrderHistoryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListOrdersRequest) ProtoMessage() {}
+func (*GetOrderHistoryRequest) ProtoMessage() {}
 
-func (x *ListOrdersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[11]
+func (x *GetOrderHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -927,40 +1747,42 @@ func (x *ListOrdersRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListOrdersRequest.ProtoReflect.Descriptor instead.
-func (*ListOrdersRequest) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use GetOrderHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *ListOrdersRequest) GetFilter() *OrderFilter {
+func (x *GetOrderHistoryRequest) GetId() string {
 	if x != nil {
-		return x.Filter
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-type ListOrdersResponse struct {
+type OrderActor struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Orders        []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	Type          OrderActorType         `protobuf:"varint,1,opt,name=type,proto3,enum=order.OrderActorType" json:"type,omitempty"`
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Source        OrderChangeSource      `protobuf:"varint,3,opt,name=source,proto3,enum=order.OrderChangeSource" json:"source,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListOrdersResponse) Reset() {
-	*x = ListOrdersResponse{}
-	mi := &file_order_proto_msgTypes[12]
+func (x *OrderActor) Reset() {
+	*x = OrderActor{}
+	mi := &file_order_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListOrdersResponse) String() string {
+func (x *OrderActor) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListOrdersResponse) ProtoMessage() {}
+func (*OrderActor) ProtoMessage() {}
 
-func (x *ListOrdersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[12]
+func (x *OrderActor) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -971,40 +1793,140 @@ func (x *ListOrdersResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListOrdersResponse.ProtoReflect.Descriptor instead.
-func (*ListOrdersResponse) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use OrderActor.ProtoReflect.Descriptor instead.
+func (*OrderActor) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *ListOrdersResponse) GetOrders() []*Order {
+func (x *OrderActor) GetType() OrderActorType {
 	if x != nil {
-		return x.Orders
+		return x.Type
+	}
+	return OrderActorType_ORDER_ACTOR_TYPE_UNSPECIFIED
+}
+
+func (x *OrderActor) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *OrderActor) GetSource() OrderChangeSource {
+	if x != nil {
+		return x.Source
+	}
+	return OrderChangeSource_ORDER_CHANGE_SOURCE_UNSPECIFIED
+}
+
+type OrderStatusChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          OrderStatus            `protobuf:"varint,1,opt,name=from,proto3,enum=order.OrderStatus" json:"from,omitempty"`
+	To            OrderStatus            `protobuf:"varint,2,opt,name=to,proto3,enum=order.OrderStatus" json:"to,omitempty"`
+	Actor         *OrderActor            `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	WorkflowId    string                 `protobuf:"bytes,4,opt,name=workflow_id,json=workflowId,proto3" json:"workflow_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	ChangedAt     string                 `protobuf:"bytes,6,opt,name=changed_at,json=changedAt,proto3" json:"changed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderStatusChange) Reset() {
+	*x = OrderStatusChange{}
+	mi := &file_order_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderStatusChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderStatusChange) ProtoMessage() {}
+
+func (x *OrderStatusChange) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderStatusChange.ProtoReflect.Descriptor instead.
+func (*OrderStatusChange) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *OrderStatusChange) GetFrom() OrderStatus {
+	if x != nil {
+		return x.From
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
+func (x *OrderStatusChange) GetTo() OrderStatus {
+	if x != nil {
+		return x.To
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
+func (x *OrderStatusChange) GetActor() *OrderActor {
+	if x != nil {
+		return x.Actor
 	}
 	return nil
 }
 
-type CancelOrderRequest struct {
+func (x *OrderStatusChange) GetWorkflowId() string {
+	if x != nil {
+		return x.WorkflowId
+	}
+	return ""
+}
+
+func (x *OrderStatusChange) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *OrderStatusChange) GetChangedAt() string {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return ""
+}
+
+type GetOrderHistoryResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	OrderCode     string                 `protobuf:"bytes,2,opt,name=order_code,json=orderCode,proto3" json:"order_code,omitempty"`
+	Changes       []*OrderStatusChange   `protobuf:"bytes,3,rep,name=changes,proto3" json:"changes,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CancelOrderRequest) Reset() {
-	*x = CancelOrderRequest{}
-	mi := &file_order_proto_msgTypes[13]
+func (x *GetOrderHistoryResponse) Reset() {
+	*x = GetOrderHistoryResponse{}
+	mi := &file_order_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CancelOrderRequest) String() string {
+func (x *GetOrderHistoryResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CancelOrderRequest) ProtoMessage() {}
+func (*GetOrderHistoryResponse) ProtoMessage() {}
 
-func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[13]
+func (x *GetOrderHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1015,23 +1937,37 @@ func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CancelOrderRequest.ProtoReflect.Descriptor instead.
-func (*CancelOrderRequest) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use GetOrderHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetOrderHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *CancelOrderRequest) GetId() string {
+func (x *GetOrderHistoryResponse) GetOrderId() string {
 	if x != nil {
-		return x.Id
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *GetOrderHistoryResponse) GetOrderCode() string {
+	if x != nil {
+		return x.OrderCode
 	}
 	return ""
 }
 
+func (x *GetOrderHistoryResponse) GetChanges() []*OrderStatusChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
 var File_order_proto protoreflect.FileDescriptor
 
 const file_order_proto_rawDesc = "" +
 	"\n" +
-	"\vorder.proto\x12\x05order\x1a\x1bgoogle/protobuf/empty.proto\"\xc5\x03\n" +
+	"\vorder.proto\x12\x05order\x1a\x1bgoogle/protobuf/empty.proto\"\xce\x05\n" +
 	"\x05Order\x12\x0e\n" +
 	"\x02id\x18\n" +
 	" \x01(\tR\x02id\x12\x12\n" +
@@ -1051,15 +1987,24 @@ const file_order_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\b \x01(\tR\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\t \x01(\tR\tupdatedAt\"p\n" +
+	"updated_at\x18\t \x01(\tR\tupdatedAt\x122\n" +
+	"\x15refunded_amount_cents\x18\x10 \x01(\x03R\x13refundedAmountCents\x12#\n" +
+	"\rrefund_reason\x18\x11 \x01(\tR\frefundReason\x12(\n" +
+	"\x10net_amount_cents\x18\x12 \x01(\x03R\x0enetAmountCents\x12%\n" +
+	"\x0efailure_reason\x18\x13 \x01(\tR\rfailureReason\x12\x17\n" +
+	"\apaid_at\x18\x14 \x01(\tR\x06paidAt\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x15 \x01(\tR\tpaymentId\x12%\n" +
+	"\x0etransaction_id\x18\x16 \x01(\tR\rtransactionId\"\x9d\x01\n" +
 	"\tOrderItem\x12&\n" +
 	"\x0fticket_class_id\x18\x01 \x01(\tR\rticketClassId\x12\x1a\n" +
 	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x1f\n" +
 	"\vprice_cents\x18\x03 \x01(\x03R\n" +
-	"priceCents\"U\n" +
+	"priceCents\x12+\n" +
+	"\x11refunded_quantity\x18\x04 \x01(\x05R\x10refundedQuantity\"U\n" +
 	"\x0fCreateOrderItem\x12&\n" +
 	"\x0fticket_class_id\x18\x01 \x01(\tR\rticketClassId\x12\x1a\n" +
-	"\bquantity\x18\x02 \x01(\x05R\bquantity\"\xe6\x02\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\"\xfc\x02\n" +
 	"\x12CreateOrderRequest\x12\x19\n" +
 	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12#\n" +
@@ -1073,23 +2018,51 @@ const file_order_proto_rawDesc = "" +
 	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x12%\n" +
 	"\x0echeckout_token\x18\t \x01(\tR\rcheckoutToken\x12!\n" +
 	"\fredirect_url\x18\n" +
-	" \x01(\tR\vredirectUrl\"Z\n" +
+	" \x01(\tR\vredirectUrl\x12\x14\n" +
+	"\x05async\x18\v \x01(\bR\x05async\"\xb6\x01\n" +
 	"\x13CreateOrderResponse\x12\"\n" +
 	"\x05order\x18\x01 \x01(\v2\f.order.OrderR\x05order\x12\x1f\n" +
 	"\vpayment_url\x18\x02 \x01(\tR\n" +
-	"paymentUrl\"\xc8\x01\n" +
+	"paymentUrl\x12\x1d\n" +
+	"\n" +
+	"order_code\x18\x03 \x01(\tR\torderCode\x12;\n" +
+	"\x0echeckout_state\x18\x04 \x01(\x0e2\x14.order.CheckoutStateR\rcheckoutState\"\xb6\x01\n" +
+	"\x10CheckoutProgress\x121\n" +
+	"\x14availability_checked\x18\x01 \x01(\bR\x13availabilityChecked\x12'\n" +
+	"\x0forder_persisted\x18\x02 \x01(\bR\x0eorderPersisted\x12\x1a\n" +
+	"\breserved\x18\x03 \x01(\bR\breserved\x12*\n" +
+	"\x11payment_url_ready\x18\x04 \x01(\bR\x0fpaymentUrlReady\"9\n" +
+	"\x18GetCheckoutStatusRequest\x12\x1d\n" +
+	"\n" +
+	"order_code\x18\x01 \x01(\tR\torderCode\"\xf6\x01\n" +
+	"\x19GetCheckoutStatusResponse\x12\x1d\n" +
+	"\n" +
+	"order_code\x18\x01 \x01(\tR\torderCode\x12*\n" +
+	"\x05state\x18\x02 \x01(\x0e2\x14.order.CheckoutStateR\x05state\x123\n" +
+	"\bprogress\x18\x03 \x01(\v2\x17.order.CheckoutProgressR\bprogress\x12\x1f\n" +
+	"\vpayment_url\x18\x04 \x01(\tR\n" +
+	"paymentUrl\x12\"\n" +
+	"\x05order\x18\x05 \x01(\v2\f.order.OrderR\x05order\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\"\x99\x02\n" +
 	"\x0ePaginationInfo\x12\x12\n" +
 	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
 	"\tpage_size\x18\x02 \x01(\x03R\bpageSize\x12\x14\n" +
 	"\x05count\x18\x03 \x01(\x03R\x05count\x12\x1b\n" +
-	"\tlast_page\x18\x04 \x01(\x05R\blastPage\x12\x14\n" +
-	"\x05total\x18\a \x01(\x03R\x05total\x12\x19\n" +
+	"\tlast_page\x18\x04 \x01(\x05R\blastPage\x12\x19\n" +
+	"\x05total\x18\a \x01(\x03H\x00R\x05total\x88\x01\x01\x12\x19\n" +
 	"\bhas_next\x18\x05 \x01(\bR\ahasNext\x12!\n" +
-	"\fhas_previous\x18\x06 \x01(\bR\vhasPrevious\"\x83\x01\n" +
-	"\x14GetManyOrdersRequest\x12\x12\n" +
-	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
+	"\fhas_previous\x18\x06 \x01(\bR\vhasPrevious\x12\x1f\n" +
+	"\vnext_cursor\x18\b \x01(\tR\n" +
+	"nextCursor\x12\x1f\n" +
+	"\vprev_cursor\x18\t \x01(\tR\n" +
+	"prevCursorB\b\n" +
+	"\x06_total\"\xc4\x01\n" +
+	"\x14GetManyOrdersRequest\x12\x16\n" +
+	"\x04page\x18\x01 \x01(\x05B\x02\x18\x01R\x04page\x12\x1b\n" +
 	"\tpage_size\x18\x02 \x01(\x03R\bpageSize\x12/\n" +
-	"\x06filter\x18\x03 \x01(\v2\x12.order.OrderFilterH\x00R\x06filter\x88\x01\x01B\t\n" +
+	"\x06filter\x18\x03 \x01(\v2\x12.order.OrderFilterH\x00R\x06filter\x88\x01\x01\x12\x16\n" +
+	"\x06cursor\x18\x04 \x01(\tR\x06cursor\x12#\n" +
+	"\rinclude_total\x18\x05 \x01(\bR\fincludeTotalB\t\n" +
 	"\a_filter\"\xa0\x01\n" +
 	"\vOrderFilter\x12\x1c\n" +
 	"\auser_id\x18\x01 \x01(\tH\x00R\x06userId\x88\x01\x01\x12\x1e\n" +
@@ -1109,27 +2082,88 @@ const file_order_proto_rawDesc = "" +
 	"\x02id\x18\x02 \x01(\tH\x00R\x02idB\r\n" +
 	"\vfind_option\"6\n" +
 	"\x10GetOrderResponse\x12\"\n" +
-	"\x05order\x18\x01 \x01(\v2\f.order.OrderR\x05order\"O\n" +
+	"\x05order\x18\x01 \x01(\v2\f.order.OrderR\x05order\"}\n" +
 	"\x11ListOrdersRequest\x12/\n" +
-	"\x06filter\x18\x01 \x01(\v2\x12.order.OrderFilterH\x00R\x06filter\x88\x01\x01B\t\n" +
-	"\a_filter\":\n" +
+	"\x06filter\x18\x01 \x01(\v2\x12.order.OrderFilterH\x00R\x06filter\x88\x01\x01\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x03R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x03 \x01(\tR\x06cursorB\t\n" +
+	"\a_filter\"[\n" +
 	"\x12ListOrdersResponse\x12$\n" +
-	"\x06orders\x18\x01 \x03(\v2\f.order.OrderR\x06orders\"$\n" +
+	"\x06orders\x18\x01 \x03(\v2\f.order.OrderR\x06orders\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"=\n" +
 	"\x12CancelOrderRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id*\x95\x01\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"U\n" +
+	"\x0fRefundOrderItem\x12&\n" +
+	"\x0fticket_class_id\x18\x01 \x01(\tR\rticketClassId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\"\x86\x01\n" +
+	"\x12RefundOrderRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12,\n" +
+	"\x05items\x18\x03 \x03(\v2\x16.order.RefundOrderItemR\x05items\x12\x1a\n" +
+	"\boperator\x18\x04 \x01(\tR\boperator\"9\n" +
+	"\x13RefundOrderResponse\x12\"\n" +
+	"\x05order\x18\x01 \x01(\v2\f.order.OrderR\x05order\"(\n" +
+	"\x16GetOrderHistoryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"y\n" +
+	"\n" +
+	"OrderActor\x12)\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x15.order.OrderActorTypeR\x04type\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x120\n" +
+	"\x06source\x18\x03 \x01(\x0e2\x18.order.OrderChangeSourceR\x06source\"\xe0\x01\n" +
+	"\x11OrderStatusChange\x12&\n" +
+	"\x04from\x18\x01 \x01(\x0e2\x12.order.OrderStatusR\x04from\x12\"\n" +
+	"\x02to\x18\x02 \x01(\x0e2\x12.order.OrderStatusR\x02to\x12'\n" +
+	"\x05actor\x18\x03 \x01(\v2\x11.order.OrderActorR\x05actor\x12\x1f\n" +
+	"\vworkflow_id\x18\x04 \x01(\tR\n" +
+	"workflowId\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x12\x1d\n" +
+	"\n" +
+	"changed_at\x18\x06 \x01(\tR\tchangedAt\"\x87\x01\n" +
+	"\x17GetOrderHistoryResponse\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1d\n" +
+	"\n" +
+	"order_code\x18\x02 \x01(\tR\torderCode\x122\n" +
+	"\achanges\x18\x03 \x03(\v2\x18.order.OrderStatusChangeR\achanges*\x89\x01\n" +
+	"\x0eOrderActorType\x12 \n" +
+	"\x1cORDER_ACTOR_TYPE_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15ORDER_ACTOR_TYPE_USER\x10\x01\x12\x1d\n" +
+	"\x19ORDER_ACTOR_TYPE_OPERATOR\x10\x02\x12\x1b\n" +
+	"\x17ORDER_ACTOR_TYPE_SYSTEM\x10\x03*\x97\x01\n" +
+	"\x11OrderChangeSource\x12#\n" +
+	"\x1fORDER_CHANGE_SOURCE_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18ORDER_CHANGE_SOURCE_GRPC\x10\x01\x12\x1d\n" +
+	"\x19ORDER_CHANGE_SOURCE_KAFKA\x10\x02\x12 \n" +
+	"\x1cORDER_CHANGE_SOURCE_WORKFLOW\x10\x03*\xd1\x01\n" +
+	"\rCheckoutState\x12\x1e\n" +
+	"\x1aCHECKOUT_STATE_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17CHECKOUT_STATE_CREATING\x10\x01\x12#\n" +
+	"\x1fCHECKOUT_STATE_AWAITING_PAYMENT\x10\x02\x12%\n" +
+	"!CHECKOUT_STATE_PROCESSING_PAYMENT\x10\x03\x12\x1c\n" +
+	"\x18CHECKOUT_STATE_COMPLETED\x10\x04\x12\x19\n" +
+	"\x15CHECKOUT_STATE_FAILED\x10\x05*\x90\x02\n" +
 	"\vOrderStatus\x12\x1c\n" +
 	"\x18ORDER_STATUS_UNSPECIFIED\x10\x00\x12\x18\n" +
 	"\x14ORDER_STATUS_PENDING\x10\x01\x12\x1a\n" +
 	"\x16ORDER_STATUS_COMPLETED\x10\x02\x12\x19\n" +
 	"\x15ORDER_STATUS_CANCELED\x10\x03\x12\x17\n" +
-	"\x13ORDER_STATUS_FAILED\x10\x042\xe2\x02\n" +
+	"\x13ORDER_STATUS_FAILED\x10\x04\x12\x19\n" +
+	"\x15ORDER_STATUS_REFUNDED\x10\x05\x12#\n" +
+	"\x1fORDER_STATUS_PARTIALLY_REFUNDED\x10\x06\x12\x1f\n" +
+	"\x1bORDER_STATUS_PAYMENT_REVIEW\x10\a\x12\x18\n" +
+	"\x14ORDER_STATUS_TIMEOUT\x10\b2\xae\x05\n" +
 	"\fOrderService\x12D\n" +
 	"\vCreateOrder\x12\x19.order.CreateOrderRequest\x1a\x1a.order.CreateOrderResponse\x12;\n" +
 	"\bGetOrder\x12\x16.order.GetOrderRequest\x1a\x17.order.GetOrderResponse\x12J\n" +
 	"\rGetManyOrders\x12\x1b.order.GetManyOrdersRequest\x1a\x1c.order.GetManyOrdersResponse\x12A\n" +
 	"\n" +
 	"ListOrders\x12\x18.order.ListOrdersRequest\x1a\x19.order.ListOrdersResponse\x12@\n" +
-	"\vCancelOrder\x12\x19.order.CancelOrderRequest\x1a\x16.google.protobuf.EmptyB7Z5github.com/vogiaan1904/ticketbottle-proto/proto/orderb\x06proto3"
+	"\vCancelOrder\x12\x19.order.CancelOrderRequest\x1a\x16.google.protobuf.Empty\x12D\n" +
+	"\vRefundOrder\x12\x19.order.RefundOrderRequest\x1a\x1a.order.RefundOrderResponse\x12P\n" +
+	"\x0fGetOrderHistory\x12\x1d.order.GetOrderHistoryRequest\x1a\x1e.order.GetOrderHistoryResponse\x12V\n" +
+	"\x11GetCheckoutStatus\x12\x1f.order.GetCheckoutStatusRequest\x1a .order.GetCheckoutStatusResponse\x12Z\n" +
+	"\x13WatchCheckoutStatus\x12\x1f.order.GetCheckoutStatusRequest\x1a .order.GetCheckoutStatusResponse0\x01B7Z5github.com/vogiaan1904/ticketbottle-proto/proto/orderb\x06proto3"
 
 var (
 	file_order_proto_rawDescOnce sync.Once
@@ -1143,53 +2177,86 @@ func file_order_proto_rawDescGZIP() []byte {
 	return file_order_proto_rawDescData
 }
 
-var file_order_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_order_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_order_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_order_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
 var file_order_proto_goTypes = []any{
-	(OrderStatus)(0),              // 0: order.OrderStatus
-	(*Order)(nil),                 // 1: order.Order
-	(*OrderItem)(nil),             // 2: order.OrderItem
-	(*CreateOrderItem)(nil),       // 3: order.CreateOrderItem
-	(*CreateOrderRequest)(nil),    // 4: order.CreateOrderRequest
-	(*CreateOrderResponse)(nil),   // 5: order.CreateOrderResponse
-	(*PaginationInfo)(nil),        // 6: order.PaginationInfo
-	(*GetManyOrdersRequest)(nil),  // 7: order.GetManyOrdersRequest
-	(*OrderFilter)(nil),           // 8: order.OrderFilter
-	(*GetManyOrdersResponse)(nil), // 9: order.GetManyOrdersResponse
-	(*GetOrderRequest)(nil),       // 10: order.GetOrderRequest
-	(*GetOrderResponse)(nil),      // 11: order.GetOrderResponse
-	(*ListOrdersRequest)(nil),     // 12: order.ListOrdersRequest
-	(*ListOrdersResponse)(nil),    // 13: order.ListOrdersResponse
-	(*CancelOrderRequest)(nil),    // 14: order.CancelOrderRequest
-	(*emptypb.Empty)(nil),         // 15: google.protobuf.Empty
+	(OrderActorType)(0),               // 0: order.OrderActorType
+	(OrderChangeSource)(0),            // 1: order.OrderChangeSource
+	(CheckoutState)(0),                // 2: order.CheckoutState
+	(OrderStatus)(0),                  // 3: order.OrderStatus
+	(*Order)(nil),                     // 4: order.Order
+	(*OrderItem)(nil),                 // 5: order.OrderItem
+	(*CreateOrderItem)(nil),           // 6: order.CreateOrderItem
+	(*CreateOrderRequest)(nil),        // 7: order.CreateOrderRequest
+	(*CreateOrderResponse)(nil),       // 8: order.CreateOrderResponse
+	(*CheckoutProgress)(nil),          // 9: order.CheckoutProgress
+	(*GetCheckoutStatusRequest)(nil),  // 10: order.GetCheckoutStatusRequest
+	(*GetCheckoutStatusResponse)(nil), // 11: order.GetCheckoutStatusResponse
+	(*PaginationInfo)(nil),            // 12: order.PaginationInfo
+	(*GetManyOrdersRequest)(nil),      // 13: order.GetManyOrdersRequest
+	(*OrderFilter)(nil),               // 14: order.OrderFilter
+	(*GetManyOrdersResponse)(nil),     // 15: order.GetManyOrdersResponse
+	(*GetOrderRequest)(nil),           // 16: order.GetOrderRequest
+	(*GetOrderResponse)(nil),          // 17: order.GetOrderResponse
+	(*ListOrdersRequest)(nil),         // 18: order.ListOrdersRequest
+	(*ListOrdersResponse)(nil),        // 19: order.ListOrdersResponse
+	(*CancelOrderRequest)(nil),        // 20: order.CancelOrderRequest
+	(*RefundOrderItem)(nil),           // 21: order.RefundOrderItem
+	(*RefundOrderRequest)(nil),        // 22: order.RefundOrderRequest
+	(*RefundOrderResponse)(nil),       // 23: order.RefundOrderResponse
+	(*GetOrderHistoryRequest)(nil),    // 24: order.GetOrderHistoryRequest
+	(*OrderActor)(nil),                // 25: order.OrderActor
+	(*OrderStatusChange)(nil),         // 26: order.OrderStatusChange
+	(*GetOrderHistoryResponse)(nil),   // 27: order.GetOrderHistoryResponse
+	(*emptypb.Empty)(nil),             // 28: google.protobuf.Empty
 }
 var file_order_proto_depIdxs = []int32{
-	0,  // 0: order.Order.status:type_name -> order.OrderStatus
-	2,  // 1: order.Order.items:type_name -> order.OrderItem
-	3,  // 2: order.CreateOrderRequest.items:type_name -> order.CreateOrderItem
-	1,  // 3: order.CreateOrderResponse.order:type_name -> order.Order
-	8,  // 4: order.GetManyOrdersRequest.filter:type_name -> order.OrderFilter
-	0,  // 5: order.OrderFilter.status:type_name -> order.OrderStatus
-	1,  // 6: order.GetManyOrdersResponse.orders:type_name -> order.Order
-	6,  // 7: order.GetManyOrdersResponse.pagination:type_name -> order.PaginationInfo
-	1,  // 8: order.GetOrderResponse.order:type_name -> order.Order
-	8,  // 9: order.ListOrdersRequest.filter:type_name -> order.OrderFilter
-	1,  // 10: order.ListOrdersResponse.orders:type_name -> order.Order
-	4,  // 11: order.OrderService.CreateOrder:input_type -> order.CreateOrderRequest
-	10, // 12: order.OrderService.GetOrder:input_type -> order.GetOrderRequest
-	7,  // 13: order.OrderService.GetManyOrders:input_type -> order.GetManyOrdersRequest
-	12, // 14: order.OrderService.ListOrders:input_type -> order.ListOrdersRequest
-	14, // 15: order.OrderService.CancelOrder:input_type -> order.CancelOrderRequest
-	5,  // 16: order.OrderService.CreateOrder:output_type -> order.CreateOrderResponse
-	11, // 17: order.OrderService.GetOrder:output_type -> order.GetOrderResponse
-	9,  // 18: order.OrderService.GetManyOrders:output_type -> order.GetManyOrdersResponse
-	13, // 19: order.OrderService.ListOrders:output_type -> order.ListOrdersResponse
-	15, // 20: order.OrderService.CancelOrder:output_type -> google.protobuf.Empty
-	16, // [16:21] is the sub-list for method output_type
-	11, // [11:16] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	3,  // 0: order.Order.status:type_name -> order.OrderStatus
+	5,  // 1: order.Order.items:type_name -> order.OrderItem
+	6,  // 2: order.CreateOrderRequest.items:type_name -> order.CreateOrderItem
+	4,  // 3: order.CreateOrderResponse.order:type_name -> order.Order
+	2,  // 4: order.CreateOrderResponse.checkout_state:type_name -> order.CheckoutState
+	2,  // 5: order.GetCheckoutStatusResponse.state:type_name -> order.CheckoutState
+	9,  // 6: order.GetCheckoutStatusResponse.progress:type_name -> order.CheckoutProgress
+	4,  // 7: order.GetCheckoutStatusResponse.order:type_name -> order.Order
+	14, // 8: order.GetManyOrdersRequest.filter:type_name -> order.OrderFilter
+	3,  // 9: order.OrderFilter.status:type_name -> order.OrderStatus
+	4,  // 10: order.GetManyOrdersResponse.orders:type_name -> order.Order
+	12, // 11: order.GetManyOrdersResponse.pagination:type_name -> order.PaginationInfo
+	4,  // 12: order.GetOrderResponse.order:type_name -> order.Order
+	14, // 13: order.ListOrdersRequest.filter:type_name -> order.OrderFilter
+	4,  // 14: order.ListOrdersResponse.orders:type_name -> order.Order
+	21, // 15: order.RefundOrderRequest.items:type_name -> order.RefundOrderItem
+	4,  // 16: order.RefundOrderResponse.order:type_name -> order.Order
+	0,  // 17: order.OrderActor.type:type_name -> order.OrderActorType
+	1,  // 18: order.OrderActor.source:type_name -> order.OrderChangeSource
+	3,  // 19: order.OrderStatusChange.from:type_name -> order.OrderStatus
+	3,  // 20: order.OrderStatusChange.to:type_name -> order.OrderStatus
+	25, // 21: order.OrderStatusChange.actor:type_name -> order.OrderActor
+	26, // 22: order.GetOrderHistoryResponse.changes:type_name -> order.OrderStatusChange
+	7,  // 23: order.OrderService.CreateOrder:input_type -> order.CreateOrderRequest
+	16, // 24: order.OrderService.GetOrder:input_type -> order.GetOrderRequest
+	13, // 25: order.OrderService.GetManyOrders:input_type -> order.GetManyOrdersRequest
+	18, // 26: order.OrderService.ListOrders:input_type -> order.ListOrdersRequest
+	20, // 27: order.OrderService.CancelOrder:input_type -> order.CancelOrderRequest
+	22, // 28: order.OrderService.RefundOrder:input_type -> order.RefundOrderRequest
+	24, // 29: order.OrderService.GetOrderHistory:input_type -> order.GetOrderHistoryRequest
+	10, // 30: order.OrderService.GetCheckoutStatus:input_type -> order.GetCheckoutStatusRequest
+	10, // 31: order.OrderService.WatchCheckoutStatus:input_type -> order.GetCheckoutStatusRequest
+	8,  // 32: order.OrderService.CreateOrder:output_type -> order.CreateOrderResponse
+	17, // 33: order.OrderService.GetOrder:output_type -> order.GetOrderResponse
+	15, // 34: order.OrderService.GetManyOrders:output_type -> order.GetManyOrdersResponse
+	19, // 35: order.OrderService.ListOrders:output_type -> order.ListOrdersResponse
+	28, // 36: order.OrderService.CancelOrder:output_type -> google.protobuf.Empty
+	23, // 37: order.OrderService.RefundOrder:output_type -> order.RefundOrderResponse
+	27, // 38: order.OrderService.GetOrderHistory:output_type -> order.GetOrderHistoryResponse
+	11, // 39: order.OrderService.GetCheckoutStatus:output_type -> order.GetCheckoutStatusResponse
+	11, // 40: order.OrderService.WatchCheckoutStatus:output_type -> order.GetCheckoutStatusResponse
+	32, // [32:41] is the sub-list for method output_type
+	23, // [23:32] is the sub-list for method input_type
+	23, // [23:23] is the sub-list for extension type_name
+	23, // [23:23] is the sub-list for extension extendee
+	0,  // [0:23] is the sub-list for field type_name
 }
 
 func init() { file_order_proto_init() }
@@ -1197,20 +2264,21 @@ func file_order_proto_init() {
 	if File_order_proto != nil {
 		return
 	}
-	file_order_proto_msgTypes[6].OneofWrappers = []any{}
-	file_order_proto_msgTypes[7].OneofWrappers = []any{}
-	file_order_proto_msgTypes[9].OneofWrappers = []any{
+	file_order_proto_msgTypes[8].OneofWrappers = []any{}
+	file_order_proto_msgTypes[9].OneofWrappers = []any{}
+	file_order_proto_msgTypes[10].OneofWrappers = []any{}
+	file_order_proto_msgTypes[12].OneofWrappers = []any{
 		(*GetOrderRequest_Code)(nil),
 		(*GetOrderRequest_Id)(nil),
 	}
-	file_order_proto_msgTypes[11].OneofWrappers = []any{}
+	file_order_proto_msgTypes[14].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_order_proto_rawDesc), len(file_order_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   14,
+			NumEnums:      4,
+			NumMessages:   24,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
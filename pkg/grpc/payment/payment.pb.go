@@ -80,6 +80,7 @@ const (
 	PaymentStatus_COMPLETED PaymentStatus = 1
 	PaymentStatus_FAILED    PaymentStatus = 2
 	PaymentStatus_CANCELED  PaymentStatus = 3
+	PaymentStatus_REFUNDED  PaymentStatus = 4
 )
 
 // Enum value maps for PaymentStatus.
@@ -89,12 +90,14 @@ var (
 		1: "COMPLETED",
 		2: "FAILED",
 		3: "CANCELED",
+		4: "REFUNDED",
 	}
 	PaymentStatus_value = map[string]int32{
 		"PENDING":   0,
 		"COMPLETED": 1,
 		"FAILED":    2,
 		"CANCELED":  3,
+		"REFUNDED":  4,
 	}
 )
 
@@ -219,6 +222,7 @@ func (x *CreatePaymentIntentRequest) GetTimeoutSeconds() int32 {
 
 type CreatePaymentIntentResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	PaymentId     string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
 	PaymentUrl    string                 `protobuf:"bytes,2,opt,name=payment_url,json=paymentUrl,proto3" json:"payment_url,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -254,6 +258,13 @@ func (*CreatePaymentIntentResponse) Descriptor() ([]byte, []int) {
 	return file_payment_proto_rawDescGZIP(), []int{1}
 }
 
+func (x *CreatePaymentIntentResponse) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
 func (x *CreatePaymentIntentResponse) GetPaymentUrl() string {
 	if x != nil {
 		return x.PaymentUrl
@@ -453,6 +464,126 @@ func (x *CancelPaymentIntentResponse) GetStatus() PaymentStatus {
 	return PaymentStatus_PENDING
 }
 
+type RefundPaymentRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrderCode      string                 `protobuf:"bytes,1,opt,name=order_code,json=orderCode,proto3" json:"order_code,omitempty"`
+	AmountCents    int64                  `protobuf:"varint,2,opt,name=amount_cents,json=amountCents,proto3" json:"amount_cents,omitempty"`
+	Reason         string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	IdempotencyKey string                 `protobuf:"bytes,4,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RefundPaymentRequest) Reset() {
+	*x = RefundPaymentRequest{}
+	mi := &file_payment_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundPaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundPaymentRequest) ProtoMessage() {}
+
+func (x *RefundPaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundPaymentRequest.ProtoReflect.Descriptor instead.
+func (*RefundPaymentRequest) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RefundPaymentRequest) GetOrderCode() string {
+	if x != nil {
+		return x.OrderCode
+	}
+	return ""
+}
+
+func (x *RefundPaymentRequest) GetAmountCents() int64 {
+	if x != nil {
+		return x.AmountCents
+	}
+	return 0
+}
+
+func (x *RefundPaymentRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *RefundPaymentRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+type RefundPaymentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RefundId      string                 `protobuf:"bytes,1,opt,name=refund_id,json=refundId,proto3" json:"refund_id,omitempty"`
+	Status        PaymentStatus          `protobuf:"varint,2,opt,name=status,proto3,enum=payment.PaymentStatus" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundPaymentResponse) Reset() {
+	*x = RefundPaymentResponse{}
+	mi := &file_payment_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundPaymentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundPaymentResponse) ProtoMessage() {}
+
+func (x *RefundPaymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundPaymentResponse.ProtoReflect.Descriptor instead.
+func (*RefundPaymentResponse) Descriptor() ([]byte, []int) {
+	return file_payment_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RefundPaymentResponse) GetRefundId() string {
+	if x != nil {
+		return x.RefundId
+	}
+	return ""
+}
+
+func (x *RefundPaymentResponse) GetStatus() PaymentStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PaymentStatus_PENDING
+}
+
 type GetPaymentUrlByIdempotencyKeyRequest struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	IdempotencyKey string                 `protobuf:"bytes,1,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
@@ -462,7 +593,7 @@ type GetPaymentUrlByIdempotencyKeyRequest struct {
 
 func (x *GetPaymentUrlByIdempotencyKeyRequest) Reset() {
 	*x = GetPaymentUrlByIdempotencyKeyRequest{}
-	mi := &file_payment_proto_msgTypes[6]
+	mi := &file_payment_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -474,7 +605,7 @@ func (x *GetPaymentUrlByIdempotencyKeyRequest) String() string {
 func (*GetPaymentUrlByIdempotencyKeyRequest) ProtoMessage() {}
 
 func (x *GetPaymentUrlByIdempotencyKeyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_payment_proto_msgTypes[6]
+	mi := &file_payment_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -487,7 +618,7 @@ func (x *GetPaymentUrlByIdempotencyKeyRequest) ProtoReflect() protoreflect.Messa
 
 // Deprecated: Use GetPaymentUrlByIdempotencyKeyRequest.ProtoReflect.Descriptor instead.
 func (*GetPaymentUrlByIdempotencyKeyRequest) Descriptor() ([]byte, []int) {
-	return file_payment_proto_rawDescGZIP(), []int{6}
+	return file_payment_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *GetPaymentUrlByIdempotencyKeyRequest) GetIdempotencyKey() string {
@@ -507,7 +638,7 @@ type GetPaymentUrlByIdempotencyKeyResponse struct {
 
 func (x *GetPaymentUrlByIdempotencyKeyResponse) Reset() {
 	*x = GetPaymentUrlByIdempotencyKeyResponse{}
-	mi := &file_payment_proto_msgTypes[7]
+	mi := &file_payment_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -519,7 +650,7 @@ func (x *GetPaymentUrlByIdempotencyKeyResponse) String() string {
 func (*GetPaymentUrlByIdempotencyKeyResponse) ProtoMessage() {}
 
 func (x *GetPaymentUrlByIdempotencyKeyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_payment_proto_msgTypes[7]
+	mi := &file_payment_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -532,7 +663,7 @@ func (x *GetPaymentUrlByIdempotencyKeyResponse) ProtoReflect() protoreflect.Mess
 
 // Deprecated: Use GetPaymentUrlByIdempotencyKeyResponse.ProtoReflect.Descriptor instead.
 func (*GetPaymentUrlByIdempotencyKeyResponse) Descriptor() ([]byte, []int) {
-	return file_payment_proto_rawDescGZIP(), []int{7}
+	return file_payment_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *GetPaymentUrlByIdempotencyKeyResponse) GetPaymentUrl() string {
@@ -562,8 +693,10 @@ const file_payment_proto_rawDesc = "" +
 	"\bprovider\x18\x04 \x01(\x0e2\x18.payment.PaymentProviderR\bprovider\x12'\n" +
 	"\x0fidempotency_key\x18\x05 \x01(\tR\x0eidempotencyKey\x12!\n" +
 	"\fredirect_url\x18\x06 \x01(\tR\vredirectUrl\x12'\n" +
-	"\x0ftimeout_seconds\x18\a \x01(\x05R\x0etimeoutSeconds\">\n" +
-	"\x1bCreatePaymentIntentResponse\x12\x1f\n" +
+	"\x0ftimeout_seconds\x18\a \x01(\x05R\x0etimeoutSeconds\"]\n" +
+	"\x1bCreatePaymentIntentResponse\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x01 \x01(\tR\tpaymentId\x12\x1f\n" +
 	"\vpayment_url\x18\x02 \x01(\tR\n" +
 	"paymentUrl\"<\n" +
 	"\x1bConfirmPaymentIntentRequest\x12\x1d\n" +
@@ -579,6 +712,15 @@ const file_payment_proto_rawDesc = "" +
 	"\x1bCancelPaymentIntentResponse\x12\x1d\n" +
 	"\n" +
 	"payment_id\x18\x01 \x01(\tR\tpaymentId\x12.\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x16.payment.PaymentStatusR\x06status\"\x99\x01\n" +
+	"\x14RefundPaymentRequest\x12\x1d\n" +
+	"\n" +
+	"order_code\x18\x01 \x01(\tR\torderCode\x12!\n" +
+	"\famount_cents\x18\x02 \x01(\x03R\vamountCents\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12'\n" +
+	"\x0fidempotency_key\x18\x04 \x01(\tR\x0eidempotencyKey\"d\n" +
+	"\x15RefundPaymentResponse\x12\x1b\n" +
+	"\trefund_id\x18\x01 \x01(\tR\brefundId\x12.\n" +
 	"\x06status\x18\x02 \x01(\x0e2\x16.payment.PaymentStatusR\x06status\"O\n" +
 	"$GetPaymentUrlByIdempotencyKeyRequest\x12'\n" +
 	"\x0fidempotency_key\x18\x01 \x01(\tR\x0eidempotencyKey\"x\n" +
@@ -590,17 +732,19 @@ const file_payment_proto_rawDesc = "" +
 	"\aUNKNOWN\x10\x00\x12\v\n" +
 	"\aZALOPAY\x10\x01\x12\t\n" +
 	"\x05PAYOS\x10\x02\x12\t\n" +
-	"\x05VNPAY\x10\x03*E\n" +
+	"\x05VNPAY\x10\x03*S\n" +
 	"\rPaymentStatus\x12\v\n" +
 	"\aPENDING\x10\x00\x12\r\n" +
 	"\tCOMPLETED\x10\x01\x12\n" +
 	"\n" +
 	"\x06FAILED\x10\x02\x12\f\n" +
-	"\bCANCELED\x10\x032\xb9\x03\n" +
+	"\bCANCELED\x10\x03\x12\f\n" +
+	"\bREFUNDED\x10\x042\x89\x04\n" +
 	"\x0ePaymentService\x12`\n" +
 	"\x13CreatePaymentIntent\x12#.payment.CreatePaymentIntentRequest\x1a$.payment.CreatePaymentIntentResponse\x12c\n" +
 	"\x14ConfirmPaymentIntent\x12$.payment.ConfirmPaymentIntentRequest\x1a%.payment.ConfirmPaymentIntentResponse\x12`\n" +
-	"\x13CancelPaymentIntent\x12#.payment.CancelPaymentIntentRequest\x1a$.payment.CancelPaymentIntentResponse\x12~\n" +
+	"\x13CancelPaymentIntent\x12#.payment.CancelPaymentIntentRequest\x1a$.payment.CancelPaymentIntentResponse\x12N\n" +
+	"\rRefundPayment\x12\x1d.payment.RefundPaymentRequest\x1a\x1e.payment.RefundPaymentResponse\x12~\n" +
 	"\x1dGetPaymentUrlByIdempotencyKey\x12-.payment.GetPaymentUrlByIdempotencyKeyRequest\x1a..payment.GetPaymentUrlByIdempotencyKeyResponseB9Z7github.com/vogiaan1904/ticketbottle-proto/proto/paymentb\x06proto3"
 
 var (
@@ -616,7 +760,7 @@ func file_payment_proto_rawDescGZIP() []byte {
 }
 
 var file_payment_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_payment_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_payment_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_payment_proto_goTypes = []any{
 	(PaymentProvider)(0),                          // 0: payment.PaymentProvider
 	(PaymentStatus)(0),                            // 1: payment.PaymentStatus
@@ -626,27 +770,32 @@ var file_payment_proto_goTypes = []any{
 	(*ConfirmPaymentIntentResponse)(nil),          // 5: payment.ConfirmPaymentIntentResponse
 	(*CancelPaymentIntentRequest)(nil),            // 6: payment.CancelPaymentIntentRequest
 	(*CancelPaymentIntentResponse)(nil),           // 7: payment.CancelPaymentIntentResponse
-	(*GetPaymentUrlByIdempotencyKeyRequest)(nil),  // 8: payment.GetPaymentUrlByIdempotencyKeyRequest
-	(*GetPaymentUrlByIdempotencyKeyResponse)(nil), // 9: payment.GetPaymentUrlByIdempotencyKeyResponse
+	(*RefundPaymentRequest)(nil),                  // 8: payment.RefundPaymentRequest
+	(*RefundPaymentResponse)(nil),                 // 9: payment.RefundPaymentResponse
+	(*GetPaymentUrlByIdempotencyKeyRequest)(nil),  // 10: payment.GetPaymentUrlByIdempotencyKeyRequest
+	(*GetPaymentUrlByIdempotencyKeyResponse)(nil), // 11: payment.GetPaymentUrlByIdempotencyKeyResponse
 }
 var file_payment_proto_depIdxs = []int32{
-	0, // 0: payment.CreatePaymentIntentRequest.provider:type_name -> payment.PaymentProvider
-	1, // 1: payment.ConfirmPaymentIntentResponse.status:type_name -> payment.PaymentStatus
-	1, // 2: payment.CancelPaymentIntentResponse.status:type_name -> payment.PaymentStatus
-	1, // 3: payment.GetPaymentUrlByIdempotencyKeyResponse.status:type_name -> payment.PaymentStatus
-	2, // 4: payment.PaymentService.CreatePaymentIntent:input_type -> payment.CreatePaymentIntentRequest
-	4, // 5: payment.PaymentService.ConfirmPaymentIntent:input_type -> payment.ConfirmPaymentIntentRequest
-	6, // 6: payment.PaymentService.CancelPaymentIntent:input_type -> payment.CancelPaymentIntentRequest
-	8, // 7: payment.PaymentService.GetPaymentUrlByIdempotencyKey:input_type -> payment.GetPaymentUrlByIdempotencyKeyRequest
-	3, // 8: payment.PaymentService.CreatePaymentIntent:output_type -> payment.CreatePaymentIntentResponse
-	5, // 9: payment.PaymentService.ConfirmPaymentIntent:output_type -> payment.ConfirmPaymentIntentResponse
-	7, // 10: payment.PaymentService.CancelPaymentIntent:output_type -> payment.CancelPaymentIntentResponse
-	9, // 11: payment.PaymentService.GetPaymentUrlByIdempotencyKey:output_type -> payment.GetPaymentUrlByIdempotencyKeyResponse
-	8, // [8:12] is the sub-list for method output_type
-	4, // [4:8] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	0,  // 0: payment.CreatePaymentIntentRequest.provider:type_name -> payment.PaymentProvider
+	1,  // 1: payment.ConfirmPaymentIntentResponse.status:type_name -> payment.PaymentStatus
+	1,  // 2: payment.CancelPaymentIntentResponse.status:type_name -> payment.PaymentStatus
+	1,  // 3: payment.RefundPaymentResponse.status:type_name -> payment.PaymentStatus
+	1,  // 4: payment.GetPaymentUrlByIdempotencyKeyResponse.status:type_name -> payment.PaymentStatus
+	2,  // 5: payment.PaymentService.CreatePaymentIntent:input_type -> payment.CreatePaymentIntentRequest
+	4,  // 6: payment.PaymentService.ConfirmPaymentIntent:input_type -> payment.ConfirmPaymentIntentRequest
+	6,  // 7: payment.PaymentService.CancelPaymentIntent:input_type -> payment.CancelPaymentIntentRequest
+	8,  // 8: payment.PaymentService.RefundPayment:input_type -> payment.RefundPaymentRequest
+	10, // 9: payment.PaymentService.GetPaymentUrlByIdempotencyKey:input_type -> payment.GetPaymentUrlByIdempotencyKeyRequest
+	3,  // 10: payment.PaymentService.CreatePaymentIntent:output_type -> payment.CreatePaymentIntentResponse
+	5,  // 11: payment.PaymentService.ConfirmPaymentIntent:output_type -> payment.ConfirmPaymentIntentResponse
+	7,  // 12: payment.PaymentService.CancelPaymentIntent:output_type -> payment.CancelPaymentIntentResponse
+	9,  // 13: payment.PaymentService.RefundPayment:output_type -> payment.RefundPaymentResponse
+	11, // 14: payment.PaymentService.GetPaymentUrlByIdempotencyKey:output_type -> payment.GetPaymentUrlByIdempotencyKeyResponse
+	10, // [10:15] is the sub-list for method output_type
+	5,  // [5:10] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_payment_proto_init() }
@@ -660,7 +809,7 @@ func file_payment_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_payment_proto_rawDesc), len(file_payment_proto_rawDesc)),
 			NumEnums:      2,
-			NumMessages:   8,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -22,6 +22,7 @@ const (
 	PaymentService_CreatePaymentIntent_FullMethodName           = "/payment.PaymentService/CreatePaymentIntent"
 	PaymentService_ConfirmPaymentIntent_FullMethodName          = "/payment.PaymentService/ConfirmPaymentIntent"
 	PaymentService_CancelPaymentIntent_FullMethodName           = "/payment.PaymentService/CancelPaymentIntent"
+	PaymentService_RefundPayment_FullMethodName                 = "/payment.PaymentService/RefundPayment"
 	PaymentService_GetPaymentUrlByIdempotencyKey_FullMethodName = "/payment.PaymentService/GetPaymentUrlByIdempotencyKey"
 )
 
@@ -32,6 +33,7 @@ type PaymentServiceClient interface {
 	CreatePaymentIntent(ctx context.Context, in *CreatePaymentIntentRequest, opts ...grpc.CallOption) (*CreatePaymentIntentResponse, error)
 	ConfirmPaymentIntent(ctx context.Context, in *ConfirmPaymentIntentRequest, opts ...grpc.CallOption) (*ConfirmPaymentIntentResponse, error)
 	CancelPaymentIntent(ctx context.Context, in *CancelPaymentIntentRequest, opts ...grpc.CallOption) (*CancelPaymentIntentResponse, error)
+	RefundPayment(ctx context.Context, in *RefundPaymentRequest, opts ...grpc.CallOption) (*RefundPaymentResponse, error)
 	GetPaymentUrlByIdempotencyKey(ctx context.Context, in *GetPaymentUrlByIdempotencyKeyRequest, opts ...grpc.CallOption) (*GetPaymentUrlByIdempotencyKeyResponse, error)
 }
 
@@ -73,6 +75,16 @@ func (c *paymentServiceClient) CancelPaymentIntent(ctx context.Context, in *Canc
 	return out, nil
 }
 
+func (c *paymentServiceClient) RefundPayment(ctx context.Context, in *RefundPaymentRequest, opts ...grpc.CallOption) (*RefundPaymentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RefundPaymentResponse)
+	err := c.cc.Invoke(ctx, PaymentService_RefundPayment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *paymentServiceClient) GetPaymentUrlByIdempotencyKey(ctx context.Context, in *GetPaymentUrlByIdempotencyKeyRequest, opts ...grpc.CallOption) (*GetPaymentUrlByIdempotencyKeyResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetPaymentUrlByIdempotencyKeyResponse)
@@ -90,6 +102,7 @@ type PaymentServiceServer interface {
 	CreatePaymentIntent(context.Context, *CreatePaymentIntentRequest) (*CreatePaymentIntentResponse, error)
 	ConfirmPaymentIntent(context.Context, *ConfirmPaymentIntentRequest) (*ConfirmPaymentIntentResponse, error)
 	CancelPaymentIntent(context.Context, *CancelPaymentIntentRequest) (*CancelPaymentIntentResponse, error)
+	RefundPayment(context.Context, *RefundPaymentRequest) (*RefundPaymentResponse, error)
 	GetPaymentUrlByIdempotencyKey(context.Context, *GetPaymentUrlByIdempotencyKeyRequest) (*GetPaymentUrlByIdempotencyKeyResponse, error)
 	mustEmbedUnimplementedPaymentServiceServer()
 }
@@ -110,6 +123,9 @@ func (UnimplementedPaymentServiceServer) ConfirmPaymentIntent(context.Context, *
 func (UnimplementedPaymentServiceServer) CancelPaymentIntent(context.Context, *CancelPaymentIntentRequest) (*CancelPaymentIntentResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CancelPaymentIntent not implemented")
 }
+func (UnimplementedPaymentServiceServer) RefundPayment(context.Context, *RefundPaymentRequest) (*RefundPaymentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefundPayment not implemented")
+}
 func (UnimplementedPaymentServiceServer) GetPaymentUrlByIdempotencyKey(context.Context, *GetPaymentUrlByIdempotencyKeyRequest) (*GetPaymentUrlByIdempotencyKeyResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPaymentUrlByIdempotencyKey not implemented")
 }
@@ -188,6 +204,24 @@ func _PaymentService_CancelPaymentIntent_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PaymentService_RefundPayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefundPaymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).RefundPayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_RefundPayment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).RefundPayment(ctx, req.(*RefundPaymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PaymentService_GetPaymentUrlByIdempotencyKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetPaymentUrlByIdempotencyKeyRequest)
 	if err := dec(in); err != nil {
@@ -225,6 +259,10 @@ var PaymentService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CancelPaymentIntent",
 			Handler:    _PaymentService_CancelPaymentIntent_Handler,
 		},
+		{
+			MethodName: "RefundPayment",
+			Handler:    _PaymentService_RefundPayment_Handler,
+		},
 		{
 			MethodName: "GetPaymentUrlByIdempotencyKey",
 			Handler:    _PaymentService_GetPaymentUrlByIdempotencyKey_Handler,
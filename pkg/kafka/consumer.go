@@ -10,6 +10,8 @@ import (
 type ConsumerConfig struct {
 	Brokers []string
 	GroupID string
+	// InitialOffset is used when the group has no committed offset yet, defaults to sarama.OffsetNewest
+	InitialOffset int64
 }
 
 func NewConsumer(cfg ConsumerConfig) (sarama.ConsumerGroup, error) {
@@ -17,6 +19,9 @@ func NewConsumer(cfg ConsumerConfig) (sarama.ConsumerGroup, error) {
 	saramaCfg.Version = sarama.V2_8_0_0
 	saramaCfg.Consumer.Group.Rebalance.Strategy = sarama.NewBalanceStrategyRoundRobin()
 	saramaCfg.Consumer.Offsets.Initial = sarama.OffsetNewest
+	if cfg.InitialOffset != 0 {
+		saramaCfg.Consumer.Offsets.Initial = cfg.InitialOffset
+	}
 	saramaCfg.Consumer.Return.Errors = true
 
 	consGroup, err := sarama.NewConsumerGroup(cfg.Brokers, cfg.GroupID, saramaCfg)
@@ -0,0 +1,318 @@
+// Package kafkatest is an in-memory Kafka broker for tests. It hands out a sarama.SyncProducer and
+// sarama.ConsumerGroup members that share its topics, so the producers and the consumer of the
+// order service run against it unchanged and without a real broker.
+//
+// A typical test wires the broker into the consumer and the producer, then publishes an event and
+// waits for the outcome:
+//
+//	b := kafkatest.NewBroker(kafkatest.DefaultPartitions)
+//	prod := b.NewSyncProducer()
+//	cons := consumer.NewConsumer(b.NewConsumerGroup("order-service", sarama.OffsetOldest), prod, svc, l, consumer.Config{Workers: 4, MaxInFlight: 16})
+//	_ = cons.Start(ctx)
+//
+//	_, _, _ = prod.SendMessage(&sarama.ProducerMessage{Topic: kafka.TopicPaymentCompleted, Value: sarama.ByteEncoder(payload)})
+//	msgs, err := b.WaitForMessages(ctx, kafka.TopicCheckoutCompleted, 1)
+//
+// svc is the order service with its activities publishing through prod, see the end-to-end test of
+// the order consumer for one running the workflows in the Temporal test environment.
+package kafkatest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/IBM/sarama"
+)
+
+// DefaultPartitions is a sensible partition count for a test broker
+const DefaultPartitions = 3
+
+var (
+	ErrTransactionsNotSupported = errors.New("kafkatest: transactions are not supported")
+	ErrUnknownPartition         = errors.New("kafkatest: unknown partition")
+)
+
+// Broker keeps topics and consumer groups in memory. Topics are created with the broker's partition
+// count the first time they are produced to or subscribed to.
+type Broker struct {
+	mu         sync.Mutex
+	partitions int32
+	topics     map[string][]*partition
+	groups     map[string]*group
+	// keyless spreads messages without a key round-robin over the partitions of a topic
+	keyless map[string]int32
+	members int
+	// appended is closed and replaced whenever a message is appended to any topic
+	appended chan struct{}
+}
+
+type partition struct {
+	msgs []*sarama.ConsumerMessage
+}
+
+func NewBroker(partitions int32) *Broker {
+	if partitions < 1 {
+		partitions = 1
+	}
+
+	return &Broker{
+		partitions: partitions,
+		topics:     make(map[string][]*partition),
+		groups:     make(map[string]*group),
+		keyless:    make(map[string]int32),
+		appended:   make(chan struct{}),
+	}
+}
+
+// CreateTopic creates a topic with its own partition count, an existing topic is left as is
+func (b *Broker) CreateTopic(topic string, partitions int32) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.topics[topic]; ok {
+		return
+	}
+	b.createTopicLocked(topic, partitions)
+}
+
+func (b *Broker) createTopicLocked(topic string, partitions int32) []*partition {
+	if partitions < 1 {
+		partitions = 1
+	}
+
+	ps := make([]*partition, partitions)
+	for i := range ps {
+		ps[i] = &partition{}
+	}
+	b.topics[topic] = ps
+
+	return ps
+}
+
+func (b *Broker) topicLocked(topic string) []*partition {
+	if ps, ok := b.topics[topic]; ok {
+		return ps
+	}
+	return b.createTopicLocked(topic, b.partitions)
+}
+
+// produce appends msg to its partition, picked like sarama's hash partitioner: by key hash, or
+// round-robin for messages without a key
+func (b *Broker) produce(msg *sarama.ProducerMessage) (int32, int64, error) {
+	var key, val []byte
+	var err error
+	if msg.Key != nil {
+		if key, err = msg.Key.Encode(); err != nil {
+			return -1, -1, err
+		}
+	}
+	if msg.Value != nil {
+		if val, err = msg.Value.Encode(); err != nil {
+			return -1, -1, err
+		}
+	}
+
+	hdrs := make([]*sarama.RecordHeader, len(msg.Headers))
+	for i, h := range msg.Headers {
+		hdrs[i] = &sarama.RecordHeader{Key: h.Key, Value: h.Value}
+	}
+
+	ts := msg.Timestamp
+	if ts.IsZero() {
+		ts = time.Now()
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ps := b.topicLocked(msg.Topic)
+	n := int32(len(ps))
+
+	var p int32
+	if msg.Key != nil {
+		h := fnv.New32a()
+		h.Write(key)
+		p = int32(h.Sum32()) % n
+		if p < 0 {
+			p = -p
+		}
+	} else {
+		p = b.keyless[msg.Topic] % n
+		b.keyless[msg.Topic]++
+	}
+
+	part := ps[p]
+	off := int64(len(part.msgs))
+	part.msgs = append(part.msgs, &sarama.ConsumerMessage{
+		Headers:   hdrs,
+		Timestamp: ts,
+		Key:       key,
+		Value:     val,
+		Topic:     msg.Topic,
+		Partition: p,
+		Offset:    off,
+	})
+
+	msg.Partition = p
+	msg.Offset = off
+	msg.Timestamp = ts
+
+	close(b.appended)
+	b.appended = make(chan struct{})
+
+	return p, off, nil
+}
+
+// Messages returns every message of a topic, partition by partition in offset order
+func (b *Broker) Messages(topic string) []*sarama.ConsumerMessage {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var msgs []*sarama.ConsumerMessage
+	for _, p := range b.topics[topic] {
+		msgs = append(msgs, p.msgs...)
+	}
+
+	return msgs
+}
+
+// WaitForMessages blocks until a topic holds at least n messages and returns all of them
+func (b *Broker) WaitForMessages(ctx context.Context, topic string, n int) ([]*sarama.ConsumerMessage, error) {
+	for {
+		b.mu.Lock()
+		total := 0
+		for _, p := range b.topics[topic] {
+			total += len(p.msgs)
+		}
+		appended := b.appended
+		b.mu.Unlock()
+
+		if total >= n {
+			return b.Messages(topic), nil
+		}
+
+		select {
+		case <-appended:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("kafkatest: %d of %d messages on %s: %w", total, n, topic, ctx.Err())
+		}
+	}
+}
+
+// HighWaterMark is the offset the next message of a partition gets
+func (b *Broker) HighWaterMark(topic string, partition int32) (int64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ps := b.topics[topic]
+	if partition < 0 || int(partition) >= len(ps) {
+		return 0, ErrUnknownPartition
+	}
+
+	return int64(len(ps[partition].msgs)), nil
+}
+
+// CommittedOffset is the next offset a group consumes from a partition, false when the group never
+// marked one
+func (b *Broker) CommittedOffset(groupID string, topic string, partition int32) (int64, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	g, ok := b.groups[groupID]
+	if !ok {
+		return 0, false
+	}
+	off, ok := g.offsets[topic][partition]
+
+	return off, ok
+}
+
+// Rebalance ends the current session of every member of a group, as a broker-side rebalance does.
+// Members get their claims again on their next Consume call.
+func (b *Broker) Rebalance(groupID string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if g, ok := b.groups[groupID]; ok {
+		g.rebalanceLocked()
+	}
+}
+
+type group struct {
+	id string
+	// members maps the ID of every member to the topics it subscribed to
+	members    map[string][]string
+	generation int32
+	// genDone is closed when the current generation ends
+	genDone chan struct{}
+	offsets map[string]map[int32]int64
+}
+
+func (b *Broker) groupLocked(groupID string) *group {
+	if g, ok := b.groups[groupID]; ok {
+		return g
+	}
+
+	g := &group{
+		id:      groupID,
+		members: make(map[string][]string),
+		genDone: make(chan struct{}),
+		offsets: make(map[string]map[int32]int64),
+	}
+	b.groups[groupID] = g
+
+	return g
+}
+
+func (g *group) rebalanceLocked() {
+	g.generation++
+	close(g.genDone)
+	g.genDone = make(chan struct{})
+}
+
+// assignmentLocked spreads the partitions of every subscribed topic round-robin over the members
+// subscribed to it, in member ID order
+func (g *group) assignmentLocked(b *Broker, memberID string) map[string][]int32 {
+	ids := make([]string, 0, len(g.members))
+	for id := range g.members {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	subs := make(map[string][]string)
+	for _, id := range ids {
+		for _, t := range g.members[id] {
+			subs[t] = append(subs[t], id)
+		}
+	}
+
+	claims := make(map[string][]int32)
+	for t, ms := range subs {
+		n := len(b.topicLocked(t))
+		for p := 0; p < n; p++ {
+			if ms[p%len(ms)] == memberID {
+				claims[t] = append(claims[t], int32(p))
+			}
+		}
+	}
+
+	return claims
+}
+
+func (g *group) markLocked(topic string, partition int32, offset int64, reset bool) {
+	ps, ok := g.offsets[topic]
+	if !ok {
+		ps = make(map[int32]int64)
+		g.offsets[topic] = ps
+	}
+
+	if cur, ok := ps[partition]; ok && !reset && offset <= cur {
+		return
+	}
+	ps[partition] = offset
+}
@@ -0,0 +1,386 @@
+package kafkatest
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/IBM/sarama"
+)
+
+// ConsumerGroup is one member of a consumer group of the broker. Create one per consumer instance,
+// members of the same group share the partitions of their topics and the committed offsets.
+type ConsumerGroup struct {
+	b       *Broker
+	groupID string
+	id      string
+	// initial is where a partition without a committed offset starts, sarama.OffsetOldest or
+	// sarama.OffsetNewest
+	initial int64
+
+	mu     sync.Mutex
+	topics []string
+	paused map[string]map[int32]bool
+	// resumed is closed and replaced whenever partitions are resumed
+	resumed chan struct{}
+	errors  chan error
+	closed  bool
+	done    chan struct{}
+}
+
+var _ sarama.ConsumerGroup = (*ConsumerGroup)(nil)
+
+func (b *Broker) NewConsumerGroup(groupID string, initialOffset int64) *ConsumerGroup {
+	b.mu.Lock()
+	b.members++
+	id := fmt.Sprintf("%s-member-%04d", groupID, b.members)
+	b.mu.Unlock()
+
+	return &ConsumerGroup{
+		b:       b,
+		groupID: groupID,
+		id:      id,
+		initial: initialOffset,
+		paused:  make(map[string]map[int32]bool),
+		resumed: make(chan struct{}),
+		errors:  make(chan error, 64),
+		done:    make(chan struct{}),
+	}
+}
+
+// Consume joins the group, then runs a session over the partitions assigned to this member until a
+// rebalance, ctx is done or the member is closed. As with sarama, the session also ends as soon as
+// one ConsumeClaim returns.
+func (c *ConsumerGroup) Consume(ctx context.Context, topics []string, handler sarama.ConsumerGroupHandler) error {
+	if len(topics) == 0 {
+		return fmt.Errorf("kafkatest: no topics provided")
+	}
+
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return sarama.ErrClosedConsumerGroup
+	}
+	c.mu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	sess := c.join(ctx, topics)
+	defer sess.cancel()
+
+	if err := handler.Setup(sess); err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	for _, cl := range sess.claimList {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			c.feed(sess, cl)
+		}()
+		go func() {
+			defer wg.Done()
+			defer sess.cancel()
+			if err := handler.ConsumeClaim(sess, cl); err != nil {
+				c.sendError(err)
+			}
+		}()
+	}
+
+	<-sess.ctx.Done()
+	wg.Wait()
+
+	return handler.Cleanup(sess)
+}
+
+// join registers the member for topics, starting a new generation when the subscription changed,
+// and sets up the session of the current generation
+func (c *ConsumerGroup) join(ctx context.Context, topics []string) *session {
+	c.mu.Lock()
+	c.topics = slices.Clone(topics)
+	c.mu.Unlock()
+
+	b := c.b
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	g := b.groupLocked(c.groupID)
+	if cur, ok := g.members[c.id]; !ok || !slices.Equal(cur, topics) {
+		g.members[c.id] = slices.Clone(topics)
+		g.rebalanceLocked()
+	}
+
+	sctx, cancel := context.WithCancel(ctx)
+	sess := &session{
+		c:          c,
+		g:          g,
+		ctx:        sctx,
+		cancel:     cancel,
+		generation: g.generation,
+		claims:     g.assignmentLocked(b, c.id),
+	}
+
+	for t, ps := range sess.claims {
+		for _, p := range ps {
+			off, ok := g.offsets[t][p]
+			if !ok {
+				off = 0
+				if c.initial == sarama.OffsetNewest {
+					off = int64(len(b.topics[t][p].msgs))
+				}
+			}
+			sess.claimList = append(sess.claimList, &claim{
+				b:         b,
+				topic:     t,
+				partition: p,
+				offset:    off,
+				msgs:      make(chan *sarama.ConsumerMessage),
+			})
+		}
+	}
+
+	genDone := g.genDone
+	go func() {
+		select {
+		case <-genDone:
+		case <-c.done:
+		case <-sctx.Done():
+		}
+		cancel()
+	}()
+
+	return sess
+}
+
+// feed sends the messages of a claim from its initial offset until the session ends, holding back
+// while the partition is paused
+func (c *ConsumerGroup) feed(sess *session, cl *claim) {
+	defer close(cl.msgs)
+
+	off := cl.offset
+	for {
+		if resumed := c.pausedCh(cl.topic, cl.partition); resumed != nil {
+			select {
+			case <-resumed:
+				continue
+			case <-sess.ctx.Done():
+				return
+			}
+		}
+
+		c.b.mu.Lock()
+		var msg *sarama.ConsumerMessage
+		if ps := c.b.topics[cl.topic]; int(cl.partition) < len(ps) && off < int64(len(ps[cl.partition].msgs)) {
+			msg = ps[cl.partition].msgs[off]
+		}
+		appended := c.b.appended
+		c.b.mu.Unlock()
+
+		if msg == nil {
+			select {
+			case <-appended:
+				continue
+			case <-sess.ctx.Done():
+				return
+			}
+		}
+
+		select {
+		case cl.msgs <- msg:
+			off++
+		case <-sess.ctx.Done():
+			return
+		}
+	}
+}
+
+// pausedCh returns a channel closed on the next resume when the partition is paused, nil otherwise
+func (c *ConsumerGroup) pausedCh(topic string, partition int32) <-chan struct{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.paused[topic][partition] {
+		return c.resumed
+	}
+	return nil
+}
+
+func (c *ConsumerGroup) sendError(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return
+	}
+	select {
+	case c.errors <- err:
+	default:
+	}
+}
+
+func (c *ConsumerGroup) Errors() <-chan error {
+	return c.errors
+}
+
+// Close leaves the group, which rebalances the partitions of this member over the others
+func (c *ConsumerGroup) Close() error {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return sarama.ErrClosedConsumerGroup
+	}
+	c.closed = true
+	close(c.done)
+	close(c.errors)
+	c.mu.Unlock()
+
+	b := c.b
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if g, ok := b.groups[c.groupID]; ok {
+		if _, ok := g.members[c.id]; ok {
+			delete(g.members, c.id)
+			g.rebalanceLocked()
+		}
+	}
+
+	return nil
+}
+
+func (c *ConsumerGroup) Pause(partitions map[string][]int32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for t, ps := range partitions {
+		if c.paused[t] == nil {
+			c.paused[t] = make(map[int32]bool)
+		}
+		for _, p := range ps {
+			c.paused[t][p] = true
+		}
+	}
+}
+
+func (c *ConsumerGroup) Resume(partitions map[string][]int32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for t, ps := range partitions {
+		for _, p := range ps {
+			delete(c.paused[t], p)
+		}
+	}
+	c.resumeLocked()
+}
+
+func (c *ConsumerGroup) PauseAll() {
+	c.mu.Lock()
+	topics := slices.Clone(c.topics)
+	c.mu.Unlock()
+
+	c.b.mu.Lock()
+	all := make(map[string][]int32, len(topics))
+	for _, t := range topics {
+		for p := range c.b.topicLocked(t) {
+			all[t] = append(all[t], int32(p))
+		}
+	}
+	c.b.mu.Unlock()
+
+	c.Pause(all)
+}
+
+func (c *ConsumerGroup) ResumeAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.paused = make(map[string]map[int32]bool)
+	c.resumeLocked()
+}
+
+func (c *ConsumerGroup) resumeLocked() {
+	close(c.resumed)
+	c.resumed = make(chan struct{})
+}
+
+type session struct {
+	c          *ConsumerGroup
+	g          *group
+	ctx        context.Context
+	cancel     context.CancelFunc
+	generation int32
+	claims     map[string][]int32
+	claimList  []*claim
+}
+
+func (s *session) Claims() map[string][]int32 {
+	return s.claims
+}
+
+func (s *session) MemberID() string {
+	return s.c.id
+}
+
+func (s *session) GenerationID() int32 {
+	return s.generation
+}
+
+// MarkOffset records offset as the next one to consume, offsets never move backwards
+func (s *session) MarkOffset(topic string, partition int32, offset int64, _ string) {
+	s.c.b.mu.Lock()
+	defer s.c.b.mu.Unlock()
+
+	s.g.markLocked(topic, partition, offset, false)
+}
+
+// Commit does nothing, marked offsets are committed right away
+func (s *session) Commit() {}
+
+func (s *session) ResetOffset(topic string, partition int32, offset int64, _ string) {
+	s.c.b.mu.Lock()
+	defer s.c.b.mu.Unlock()
+
+	s.g.markLocked(topic, partition, offset, true)
+}
+
+func (s *session) MarkMessage(msg *sarama.ConsumerMessage, metadata string) {
+	s.MarkOffset(msg.Topic, msg.Partition, msg.Offset+1, metadata)
+}
+
+func (s *session) Context() context.Context {
+	return s.ctx
+}
+
+type claim struct {
+	b         *Broker
+	topic     string
+	partition int32
+	offset    int64
+	msgs      chan *sarama.ConsumerMessage
+}
+
+func (c *claim) Topic() string {
+	return c.topic
+}
+
+func (c *claim) Partition() int32 {
+	return c.partition
+}
+
+func (c *claim) InitialOffset() int64 {
+	return c.offset
+}
+
+func (c *claim) HighWaterMarkOffset() int64 {
+	hwm, _ := c.b.HighWaterMark(c.topic, c.partition)
+	return hwm
+}
+
+func (c *claim) Messages() <-chan *sarama.ConsumerMessage {
+	return c.msgs
+}
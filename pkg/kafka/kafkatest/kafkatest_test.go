@@ -0,0 +1,346 @@
+package kafkatest
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+)
+
+const testTopic = "test.topic"
+
+// recorder is a group handler that marks every message it receives and reports its sessions
+type recorder struct {
+	sessions chan sessionInfo
+	msgs     chan *sarama.ConsumerMessage
+}
+
+type sessionInfo struct {
+	generation int32
+	claims     []int32
+}
+
+func newRecorder() *recorder {
+	return &recorder{
+		sessions: make(chan sessionInfo, 64),
+		msgs:     make(chan *sarama.ConsumerMessage, 64),
+	}
+}
+
+func (h *recorder) Setup(s sarama.ConsumerGroupSession) error {
+	h.sessions <- sessionInfo{generation: s.GenerationID(), claims: slices.Sorted(slices.Values(s.Claims()[testTopic]))}
+	return nil
+}
+
+func (h *recorder) Cleanup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+func (h *recorder) ConsumeClaim(s sarama.ConsumerGroupSession, cl sarama.ConsumerGroupClaim) error {
+	for {
+		select {
+		case msg, ok := <-cl.Messages():
+			if !ok {
+				return nil
+			}
+			s.MarkMessage(msg, "")
+			h.msgs <- msg
+		case <-s.Context().Done():
+			return nil
+		}
+	}
+}
+
+// consume runs Consume in a loop, as the order consumer does, until ctx is done or the member closed
+func consume(ctx context.Context, g *ConsumerGroup, h sarama.ConsumerGroupHandler) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for ctx.Err() == nil {
+			if err := g.Consume(ctx, []string{testTopic}, h); errors.Is(err, sarama.ErrClosedConsumerGroup) {
+				return
+			}
+		}
+	}()
+
+	return done
+}
+
+func produce(t *testing.T, p *SyncProducer, key string, n int) {
+	t.Helper()
+
+	for range n {
+		msg := &sarama.ProducerMessage{Topic: testTopic, Value: sarama.StringEncoder("v")}
+		if key != "" {
+			msg.Key = sarama.StringEncoder(key)
+		}
+		if _, _, err := p.SendMessage(msg); err != nil {
+			t.Fatalf("SendMessage: %v", err)
+		}
+	}
+}
+
+func receive(t *testing.T, h *recorder, n int) []*sarama.ConsumerMessage {
+	t.Helper()
+
+	msgs := make([]*sarama.ConsumerMessage, 0, n)
+	for len(msgs) < n {
+		select {
+		case msg := <-h.msgs:
+			msgs = append(msgs, msg)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("received %d of %d messages", len(msgs), n)
+		}
+	}
+
+	return msgs
+}
+
+// waitSession returns the first session of the member with the given number of claims
+func waitSession(t *testing.T, h *recorder, claims int) sessionInfo {
+	t.Helper()
+
+	for {
+		select {
+		case s := <-h.sessions:
+			if len(s.claims) == claims {
+				return s
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("no session with %d claims", claims)
+		}
+	}
+}
+
+func TestProduceKeepsKeyOnOnePartition(t *testing.T) {
+	b := NewBroker(DefaultPartitions)
+	p := b.NewSyncProducer()
+
+	var part int32 = -1
+	for i := range 5 {
+		pi, off, err := p.SendMessage(&sarama.ProducerMessage{Topic: testTopic, Key: sarama.StringEncoder("order-1")})
+		if err != nil {
+			t.Fatalf("SendMessage: %v", err)
+		}
+		if part == -1 {
+			part = pi
+		}
+		if pi != part || off != int64(i) {
+			t.Fatalf("message %d went to partition %d offset %d, want partition %d offset %d", i, pi, off, part, i)
+		}
+	}
+
+	hwm, err := b.HighWaterMark(testTopic, part)
+	if err != nil {
+		t.Fatalf("HighWaterMark: %v", err)
+	}
+	if hwm != 5 {
+		t.Fatalf("high water mark %d, want 5", hwm)
+	}
+}
+
+func TestProducerSetError(t *testing.T) {
+	b := NewBroker(1)
+	p := b.NewSyncProducer()
+
+	errDown := errors.New("broker down")
+	p.SetError(errDown)
+	if _, _, err := p.SendMessage(&sarama.ProducerMessage{Topic: testTopic}); !errors.Is(err, errDown) {
+		t.Fatalf("SendMessage error %v, want %v", err, errDown)
+	}
+
+	p.SetError(nil)
+	produce(t, p, "", 1)
+	if n := len(b.Messages(testTopic)); n != 1 {
+		t.Fatalf("%d messages, want 1", n)
+	}
+}
+
+func TestConsumeResumesFromCommittedOffset(t *testing.T) {
+	b := NewBroker(1)
+	p := b.NewSyncProducer()
+	produce(t, p, "", 3)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	h := newRecorder()
+	g := b.NewConsumerGroup("group", sarama.OffsetOldest)
+	done := consume(ctx, g, h)
+
+	receive(t, h, 3)
+	cancel()
+	<-done
+	if err := g.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	if off, ok := b.CommittedOffset("group", testTopic, 0); !ok || off != 3 {
+		t.Fatalf("committed offset %d (%t), want 3", off, ok)
+	}
+
+	produce(t, p, "", 2)
+
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	h = newRecorder()
+	g = b.NewConsumerGroup("group", sarama.OffsetOldest)
+	defer g.Close()
+	consume(ctx, g, h)
+
+	msgs := receive(t, h, 2)
+	if msgs[0].Offset != 3 || msgs[1].Offset != 4 {
+		t.Fatalf("resumed at offsets %d, %d, want 3, 4", msgs[0].Offset, msgs[1].Offset)
+	}
+}
+
+func TestConsumeOffsetNewestSkipsExistingMessages(t *testing.T) {
+	b := NewBroker(1)
+	p := b.NewSyncProducer()
+	produce(t, p, "", 2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	h := newRecorder()
+	g := b.NewConsumerGroup("group", sarama.OffsetNewest)
+	defer g.Close()
+	consume(ctx, g, h)
+	waitSession(t, h, 1)
+
+	produce(t, p, "", 1)
+	if msg := receive(t, h, 1)[0]; msg.Offset != 2 {
+		t.Fatalf("first message at offset %d, want 2", msg.Offset)
+	}
+}
+
+// offsetHandler applies its marks in Setup and ends the session
+type offsetHandler struct {
+	fn func(s sarama.ConsumerGroupSession)
+}
+
+var errStop = errors.New("stop")
+
+func (h offsetHandler) Setup(s sarama.ConsumerGroupSession) error {
+	h.fn(s)
+	return errStop
+}
+
+func (h offsetHandler) Cleanup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+func (h offsetHandler) ConsumeClaim(sarama.ConsumerGroupSession, sarama.ConsumerGroupClaim) error {
+	return nil
+}
+
+func TestMarkOffsetNeverMovesBackwards(t *testing.T) {
+	b := NewBroker(1)
+	g := b.NewConsumerGroup("group", sarama.OffsetOldest)
+	defer g.Close()
+
+	err := g.Consume(context.Background(), []string{testTopic}, offsetHandler{fn: func(s sarama.ConsumerGroupSession) {
+		s.MarkOffset(testTopic, 0, 5, "")
+		s.MarkOffset(testTopic, 0, 3, "")
+	}})
+	if !errors.Is(err, errStop) {
+		t.Fatalf("Consume: %v", err)
+	}
+	if off, _ := b.CommittedOffset("group", testTopic, 0); off != 5 {
+		t.Fatalf("committed offset %d after marking 5 then 3, want 5", off)
+	}
+
+	err = g.Consume(context.Background(), []string{testTopic}, offsetHandler{fn: func(s sarama.ConsumerGroupSession) {
+		s.ResetOffset(testTopic, 0, 2, "")
+	}})
+	if !errors.Is(err, errStop) {
+		t.Fatalf("Consume: %v", err)
+	}
+	if off, _ := b.CommittedOffset("group", testTopic, 0); off != 2 {
+		t.Fatalf("committed offset %d after reset to 2, want 2", off)
+	}
+}
+
+func TestRebalanceSpreadsPartitions(t *testing.T) {
+	b := NewBroker(DefaultPartitions)
+	p := b.NewSyncProducer()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	h1 := newRecorder()
+	g1 := b.NewConsumerGroup("group", sarama.OffsetOldest)
+	defer g1.Close()
+	consume(ctx, g1, h1)
+	alone := waitSession(t, h1, DefaultPartitions)
+
+	// A second member takes over part of the partitions in a new generation
+	h2 := newRecorder()
+	g2 := b.NewConsumerGroup("group", sarama.OffsetOldest)
+	done2 := consume(ctx, g2, h2)
+	s1 := waitSession(t, h1, 2)
+	s2 := waitSession(t, h2, 1)
+
+	if s1.generation <= alone.generation || s1.generation != s2.generation {
+		t.Fatalf("generations %d and %d after %d, want one newer generation", s1.generation, s2.generation, alone.generation)
+	}
+	if all := slices.Sorted(slices.Values(append(slices.Clone(s1.claims), s2.claims...))); !slices.Equal(all, []int32{0, 1, 2}) {
+		t.Fatalf("claims %v and %v, want every partition claimed once", s1.claims, s2.claims)
+	}
+
+	// Every message is delivered once, by the member holding its partition
+	for _, key := range []string{"a", "b", "c", "d", "e", "f"} {
+		produce(t, p, key, 1)
+	}
+	seen := make(map[int32]int)
+	for _, msg := range receive(t, h1, countOn(b, s1.claims)) {
+		seen[msg.Partition]++
+	}
+	for _, msg := range receive(t, h2, countOn(b, s2.claims)) {
+		seen[msg.Partition]++
+	}
+	for part, n := range seen {
+		if want := countOn(b, []int32{part}); n != want {
+			t.Fatalf("partition %d delivered %d messages, want %d", part, n, want)
+		}
+	}
+
+	// A member leaving hands its partitions back, the remaining one goes on from the committed offsets
+	if err := g2.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	<-done2
+	waitSession(t, h1, DefaultPartitions)
+
+	produce(t, p, "g", 1)
+	msg := receive(t, h1, 1)[0]
+	if hwm, _ := b.HighWaterMark(testTopic, msg.Partition); msg.Offset != hwm-1 {
+		t.Fatalf("received offset %d of partition %d, want only the new message at %d", msg.Offset, msg.Partition, hwm-1)
+	}
+}
+
+func TestBrokerRebalanceRestartsSessions(t *testing.T) {
+	b := NewBroker(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	h := newRecorder()
+	g := b.NewConsumerGroup("group", sarama.OffsetOldest)
+	defer g.Close()
+	consume(ctx, g, h)
+	first := waitSession(t, h, 1)
+
+	b.Rebalance("group")
+	if next := waitSession(t, h, 1); next.generation != first.generation+1 {
+		t.Fatalf("generation %d after rebalance of %d, want %d", next.generation, first.generation, first.generation+1)
+	}
+}
+
+func countOn(b *Broker, parts []int32) int {
+	n := 0
+	for _, part := range parts {
+		hwm, _ := b.HighWaterMark(testTopic, part)
+		n += int(hwm)
+	}
+	return n
+}
@@ -0,0 +1,96 @@
+package kafkatest
+
+import (
+	"sync"
+
+	"github.com/IBM/sarama"
+)
+
+// SyncProducer publishes to the broker right away. It does not support transactions.
+type SyncProducer struct {
+	b *Broker
+
+	mu     sync.Mutex
+	err    error
+	closed bool
+}
+
+var _ sarama.SyncProducer = (*SyncProducer)(nil)
+
+func (b *Broker) NewSyncProducer() *SyncProducer {
+	return &SyncProducer{b: b}
+}
+
+// SetError makes every send fail with err until it is called again with nil, to simulate an
+// unavailable broker
+func (p *SyncProducer) SetError(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.err = err
+}
+
+func (p *SyncProducer) SendMessage(msg *sarama.ProducerMessage) (int32, int64, error) {
+	p.mu.Lock()
+	closed, err := p.closed, p.err
+	p.mu.Unlock()
+
+	if closed {
+		return -1, -1, sarama.ErrClosedClient
+	}
+	if err != nil {
+		return -1, -1, err
+	}
+
+	return p.b.produce(msg)
+}
+
+func (p *SyncProducer) SendMessages(msgs []*sarama.ProducerMessage) error {
+	var errs sarama.ProducerErrors
+	for _, msg := range msgs {
+		if _, _, err := p.SendMessage(msg); err != nil {
+			errs = append(errs, &sarama.ProducerError{Msg: msg, Err: err})
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func (p *SyncProducer) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.closed = true
+	return nil
+}
+
+func (p *SyncProducer) TxnStatus() sarama.ProducerTxnStatusFlag {
+	return sarama.ProducerTxnFlagReady
+}
+
+func (p *SyncProducer) IsTransactional() bool {
+	return false
+}
+
+func (p *SyncProducer) BeginTxn() error {
+	return ErrTransactionsNotSupported
+}
+
+func (p *SyncProducer) CommitTxn() error {
+	return ErrTransactionsNotSupported
+}
+
+func (p *SyncProducer) AbortTxn() error {
+	return ErrTransactionsNotSupported
+}
+
+func (p *SyncProducer) AddOffsetsToTxn(map[string][]*sarama.PartitionOffsetMetadata, string) error {
+	return ErrTransactionsNotSupported
+}
+
+func (p *SyncProducer) AddMessageToTxn(*sarama.ConsumerMessage, string, *string) error {
+	return ErrTransactionsNotSupported
+}
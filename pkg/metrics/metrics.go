@@ -0,0 +1,46 @@
+// Package metrics publishes counters through expvar, Handler serves them as JSON
+package metrics
+
+import (
+	"expvar"
+	"net/http"
+	"strings"
+)
+
+// CounterVec counts events per combination of label values. It is published as a map keyed by the
+// label pairs, e.g. {"topic=payment.completed,event_type=ticketbottle.payment.completed": 3}.
+type CounterVec struct {
+	labels []string
+	m      *expvar.Map
+}
+
+// NewCounterVec publishes a counter under name, it panics when name is already published
+func NewCounterVec(name string, labels ...string) *CounterVec {
+	return &CounterVec{
+		labels: labels,
+		m:      expvar.NewMap(name),
+	}
+}
+
+// Inc adds one to the counter of the label values, given in the order of the labels
+func (c *CounterVec) Inc(values ...string) {
+	c.m.Add(c.key(values), 1)
+}
+
+func (c *CounterVec) key(values []string) string {
+	pairs := make([]string, len(c.labels))
+	for i, l := range c.labels {
+		v := ""
+		if i < len(values) {
+			v = values[i]
+		}
+		pairs[i] = l + "=" + v
+	}
+
+	return strings.Join(pairs, ",")
+}
+
+// Handler serves every published counter as JSON, mount it on /debug/vars
+func Handler() http.Handler {
+	return expvar.Handler()
+}
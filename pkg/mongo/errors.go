@@ -10,3 +10,17 @@ var (
 	ErrNoDocuments     = mongo.ErrNoDocuments
 	ErrInvalidObjectID = errors.New("invalid object id")
 )
+
+// IsDuplicateKeyError reports whether err was caused by a unique index violation
+func IsDuplicateKeyError(err error) bool {
+	return mongo.IsDuplicateKeyError(err)
+}
+
+// IsIndexNotFoundError reports whether err was caused by dropping an index that does not exist
+func IsIndexNotFoundError(err error) bool {
+	var ce mongo.CommandError
+	if errors.As(err, &ce) {
+		return ce.Code == 27 || ce.Name == "IndexNotFound"
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/vogiaan1904/ticketbottle-order/pkg/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	mongoDriver "go.mongodb.org/mongo-driver/mongo"
+)
+
+// CreateIndexes creates the indexes of a collection. Indexes must be named, so a migration that
+// runs again finds them in place.
+func CreateIndexes(ctx context.Context, col mongo.Collection, models ...mongoDriver.IndexModel) error {
+	for _, m := range models {
+		if _, err := col.CreateIndex(ctx, m); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DropIndexes drops the named indexes of a collection, indexes already gone are skipped
+func DropIndexes(ctx context.Context, col mongo.Collection, names ...string) error {
+	for _, n := range names {
+		if err := col.DropIndex(ctx, n); err != nil && !mongo.IsIndexNotFoundError(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// BackfillField sets field to value on the documents that do not have it yet, so documents written
+// before the field existed read like new ones
+func BackfillField(ctx context.Context, col mongo.Collection, field string, value any) error {
+	_, err := col.UpdateMany(ctx, bson.M{
+		field: bson.M{"$exists": false},
+	}, bson.M{
+		"$set": bson.M{field: value},
+	})
+	return err
+}
+
+// UnsetField removes field from every document, it reverts BackfillField
+func UnsetField(ctx context.Context, col mongo.Collection, field string) error {
+	_, err := col.UpdateMany(ctx, bson.M{
+		field: bson.M{"$exists": true},
+	}, bson.M{
+		"$unset": bson.M{field: ""},
+	})
+	return err
+}
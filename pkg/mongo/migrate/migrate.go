@@ -0,0 +1,305 @@
+// Package migrate applies versioned schema migrations to a MongoDB database. Applied versions are
+// recorded in the migrations collection, and a lock keeps instances that start together from
+// applying the same migration twice.
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/vogiaan1904/ticketbottle-order/pkg/logger"
+	"github.com/vogiaan1904/ticketbottle-order/pkg/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	migrationCollection = "migrations"
+	lockCollection      = "migration_locks"
+	lockID              = "migrate"
+
+	// lockTTL bounds how long a crashed instance keeps the lock, a run taking longer keeps it by
+	// refreshing it between migrations
+	lockTTL          = 10 * time.Minute
+	lockPollInterval = time.Second
+)
+
+var (
+	ErrDuplicateVersion = errors.New("migrate: duplicate migration version")
+	ErrInvalidVersion   = errors.New("migrate: migration versions start at 1")
+	ErrNoDown           = errors.New("migrate: migration cannot be reverted")
+	ErrLockLost         = errors.New("migrate: lock taken over by another instance")
+)
+
+// Migration is one versioned change to the database. Up may run again after a crash between the
+// change and its record, so it must be idempotent: indexes are created with fixed names and
+// backfills only touch the documents that still miss the field.
+type Migration struct {
+	Version     int
+	Description string
+	Up          func(ctx context.Context, db mongo.Database) error
+	// Down reverts Up, a migration without one cannot be reverted
+	Down func(ctx context.Context, db mongo.Database) error
+}
+
+// Status is a migration and when it was applied, AppliedAt is nil for a pending one
+type Status struct {
+	Version     int
+	Description string
+	AppliedAt   *time.Time
+}
+
+type record struct {
+	Version     int       `bson:"_id"`
+	Description string    `bson:"description"`
+	AppliedAt   time.Time `bson:"applied_at"`
+}
+
+type Migrator struct {
+	l          logger.Logger
+	db         mongo.Database
+	migrations []Migration
+	clock      func() time.Time
+}
+
+func New(l logger.Logger, db mongo.Database, migrations []Migration) (*Migrator, error) {
+	ms := make([]Migration, len(migrations))
+	copy(ms, migrations)
+	sort.Slice(ms, func(i, j int) bool { return ms[i].Version < ms[j].Version })
+
+	for i, m := range ms {
+		if m.Version < 1 {
+			return nil, fmt.Errorf("%w: %d", ErrInvalidVersion, m.Version)
+		}
+		if i > 0 && ms[i-1].Version == m.Version {
+			return nil, fmt.Errorf("%w: %d", ErrDuplicateVersion, m.Version)
+		}
+	}
+
+	return &Migrator{
+		l:          l,
+		db:         db,
+		migrations: ms,
+		clock:      time.Now,
+	}, nil
+}
+
+// Latest is the highest known version, 0 without migrations
+func (m *Migrator) Latest() int {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Up applies the pending migrations up to and including version to, in order. A to of 0 applies
+// every pending migration.
+func (m *Migrator) Up(ctx context.Context, to int) (int, error) {
+	if to <= 0 {
+		to = m.Latest()
+	}
+
+	n := 0
+	err := m.withLock(ctx, func(refresh func() error) error {
+		applied, err := m.applied(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, mg := range m.migrations {
+			if mg.Version > to {
+				break
+			}
+			if _, ok := applied[mg.Version]; ok {
+				continue
+			}
+
+			if err := refresh(); err != nil {
+				return err
+			}
+
+			m.l.Infof(ctx, "Applying migration %d: %s", mg.Version, mg.Description)
+			if err := mg.Up(ctx, m.db); err != nil {
+				return fmt.Errorf("migration %d up: %w", mg.Version, err)
+			}
+
+			_, err := m.db.Collection(migrationCollection).InsertOne(ctx, record{
+				Version:     mg.Version,
+				Description: mg.Description,
+				AppliedAt:   m.clock(),
+			})
+			if err != nil {
+				return fmt.Errorf("migration %d record: %w", mg.Version, err)
+			}
+			n++
+		}
+
+		return nil
+	})
+	if err != nil {
+		m.l.Errorf(ctx, "migrate.Migrator.Up: %v", err)
+		return n, err
+	}
+
+	return n, nil
+}
+
+// Down reverts the applied migrations above version to, newest first
+func (m *Migrator) Down(ctx context.Context, to int) (int, error) {
+	n := 0
+	err := m.withLock(ctx, func(refresh func() error) error {
+		applied, err := m.applied(ctx)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			mg := m.migrations[i]
+			if mg.Version <= to {
+				break
+			}
+			if _, ok := applied[mg.Version]; !ok {
+				continue
+			}
+			if mg.Down == nil {
+				return fmt.Errorf("%w: %d", ErrNoDown, mg.Version)
+			}
+
+			if err := refresh(); err != nil {
+				return err
+			}
+
+			m.l.Infof(ctx, "Reverting migration %d: %s", mg.Version, mg.Description)
+			if err := mg.Down(ctx, m.db); err != nil {
+				return fmt.Errorf("migration %d down: %w", mg.Version, err)
+			}
+
+			if _, err := m.db.Collection(migrationCollection).DeleteOne(ctx, bson.M{"_id": mg.Version}); err != nil {
+				return fmt.Errorf("migration %d record: %w", mg.Version, err)
+			}
+			n++
+		}
+
+		return nil
+	})
+	if err != nil {
+		m.l.Errorf(ctx, "migrate.Migrator.Down: %v", err)
+		return n, err
+	}
+
+	return n, nil
+}
+
+// Status lists every known migration in version order with when it was applied
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		m.l.Errorf(ctx, "migrate.Migrator.Status: %v", err)
+		return nil, err
+	}
+
+	sts := make([]Status, len(m.migrations))
+	for i, mg := range m.migrations {
+		sts[i] = Status{Version: mg.Version, Description: mg.Description}
+		if r, ok := applied[mg.Version]; ok {
+			sts[i].AppliedAt = &r.AppliedAt
+		}
+	}
+
+	return sts, nil
+}
+
+func (m *Migrator) applied(ctx context.Context) (map[int]record, error) {
+	cur, err := m.db.Collection(migrationCollection).Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var rs []record
+	if err := cur.All(ctx, &rs); err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int]record, len(rs))
+	for _, r := range rs {
+		applied[r.Version] = r
+	}
+
+	return applied, nil
+}
+
+// withLock runs fn while holding the migration lock, waiting for another instance to release it.
+// fn calls refresh before each migration to extend the lock.
+func (m *Migrator) withLock(ctx context.Context, fn func(refresh func() error) error) error {
+	owner := primitive.NewObjectID().Hex()
+
+	for {
+		ok, err := m.acquire(ctx, owner)
+		if err != nil {
+			return err
+		}
+		if ok {
+			break
+		}
+
+		select {
+		case <-time.After(lockPollInterval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	defer func() {
+		// The lock is released even when ctx is done, otherwise others wait for it to expire
+		if _, err := m.db.Collection(lockCollection).DeleteOne(context.WithoutCancel(ctx), bson.M{
+			"_id":   lockID,
+			"owner": owner,
+		}); err != nil {
+			m.l.Errorf(ctx, "migrate.Migrator.withLock: %v", err)
+		}
+	}()
+
+	return fn(func() error {
+		ok, err := m.acquire(ctx, owner)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrLockLost
+		}
+		return nil
+	})
+}
+
+// acquire takes or extends the lock for owner, it reports false while another owner holds it
+func (m *Migrator) acquire(ctx context.Context, owner string) (bool, error) {
+	now := m.clock()
+	fil := bson.M{
+		"_id": lockID,
+		"$or": bson.A{
+			bson.M{"owner": owner},
+			bson.M{"expires_at": bson.M{"$lte": now}},
+		},
+	}
+
+	// The upsert only inserts when there is no lock, a live lock makes it collide on _id instead
+	_, err := m.db.Collection(lockCollection).UpdateOne(ctx, fil, bson.M{
+		"$set": bson.M{
+			"owner":      owner,
+			"expires_at": now.Add(lockTTL),
+		},
+	}, options.Update().SetUpsert(true))
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
@@ -42,6 +42,8 @@ type Collection interface {
 	Aggregate(ctx context.Context, pipeline any) (Cursor, error)
 	UpdateOne(ctx context.Context, filter any, update any, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error)
 	UpdateMany(ctx context.Context, filter any, update any, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error)
+	CreateIndex(ctx context.Context, model mongo.IndexModel) (string, error)
+	DropIndex(ctx context.Context, name string) error
 }
 
 //go:generate mockery --name=SingleResult --output=mocks --case=underscore
@@ -189,6 +191,15 @@ func (mc *mongoCollection) UpdateMany(ctx context.Context, filter any, update an
 	return mc.coll.UpdateMany(ctx, filter, update, opts[:]...)
 }
 
+func (mc *mongoCollection) CreateIndex(ctx context.Context, model mongo.IndexModel) (string, error) {
+	return mc.coll.Indexes().CreateOne(ctx, model)
+}
+
+func (mc *mongoCollection) DropIndex(ctx context.Context, name string) error {
+	_, err := mc.coll.Indexes().DropOne(ctx, name)
+	return err
+}
+
 func (mc *mongoCollection) CountDocuments(ctx context.Context, filter any, opts ...*options.CountOptions) (int64, error) {
 	return mc.coll.CountDocuments(ctx, filter, opts...)
 }
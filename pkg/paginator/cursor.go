@@ -0,0 +1,69 @@
+package paginator
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+// MaxLimit caps the page size of a cursor query
+const MaxLimit = 100
+
+// ErrInvalidCursor is returned for a cursor that was not produced by EncodeCursor
+var ErrInvalidCursor = errors.New("paginator: invalid cursor")
+
+// CursorQuery asks for one page of a listing paginated by an opaque cursor.
+type CursorQuery struct {
+	// Cursor is the Next or Prev cursor of a previous page, empty for the first page
+	Cursor string
+	Limit  int64
+	// WithTotal also counts every document matching the filter, which reads all of them
+	WithTotal bool
+}
+
+// Adjust sets the limit to the default when it is invalid and caps it at MaxLimit.
+func (q *CursorQuery) Adjust() {
+	if q.Limit < 1 {
+		q.Limit = defaultLimit
+	}
+
+	if q.Limit > MaxLimit {
+		q.Limit = MaxLimit
+	}
+}
+
+// CursorPaginator is one page of a cursor listing.
+type CursorPaginator struct {
+	// Next continues with the following page, empty on the last page
+	Next string
+	// Prev goes back to the page before, empty on the first page
+	Prev     string
+	Count    int64
+	PageSize int64
+	// Total is only set when the query asked for it
+	Total *int64
+}
+
+// EncodeCursor turns the sort key of a document into an opaque cursor.
+func EncodeCursor(key any) (string, error) {
+	b, err := json.Marshal(key)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// DecodeCursor reads the sort key of a cursor made by EncodeCursor into key.
+func DecodeCursor(cursor string, key any) error {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return ErrInvalidCursor
+	}
+
+	if err := json.Unmarshal(b, key); err != nil {
+		return ErrInvalidCursor
+	}
+
+	return nil
+}